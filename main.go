@@ -2,11 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"time"
+	// exports show times in a configured zone, even where the host has no
+	// zone database
+	_ "time/tzdata"
 
 	"github.com/blanc08/stok-gas-management-backend/pkg/api"
 	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/storage"
 	"github.com/blanc08/stok-gas-management-backend/pkg/util"
+	"github.com/blanc08/stok-gas-management-backend/pkg/worker"
 
 	pgxuuid "github.com/jackc/pgx-gofrs-uuid"
 	"github.com/jackc/pgx/v5"
@@ -26,6 +33,10 @@ func main() {
 		log.Fatal("Cannot connect to the database", err)
 	}
 
+	// dates are taken in the business time zone, so a sale or cash session
+	// falls on the same day wherever the database runs
+	pgxConfig.ConnConfig.RuntimeParams["timezone"] = config.ExportTimeZone
+
 	pgxConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
 		// do something with every new connection
 		pgxuuid.Register(conn.TypeMap())
@@ -46,9 +57,32 @@ func main() {
 	// }
 	// defer conn.Close(ctx)
 
-	store := database.NewStore()
+	timeZone, err := time.LoadLocation(config.ExportTimeZone)
+	if err != nil {
+		log.Fatal("cannot load the time zone :", err)
+	}
+
+	store := database.NewStore(timeZone)
+
+	// the first admin is named in the config, everyone else is given a role
+	// by an admin
+	if config.AdminEmail != "" {
+		err = promoteAdmin(context.Background(), store, pool, config.AdminEmail)
+		if err != nil {
+			log.Fatal("cannot promote the admin :", err)
+		}
+	}
+
+	// the server and the background jobs share the storage, imports read
+	// the files uploaded through the server
+	fileStorage, err := storage.NewLocalStorage(config.StorageDir)
+	if err != nil {
+		log.Fatal("cannot create file storage :", err)
+	}
+
+	worker.NewWorker(config, store, pool, fileStorage).Start(context.Background())
 
-	restApiServer, err := api.NewServer(config, store, pool)
+	restApiServer, err := api.NewServer(config, store, pool, fileStorage)
 	if err != nil {
 		log.Fatal("cannot create the server :", err)
 	}
@@ -61,3 +95,26 @@ func main() {
 	}
 
 }
+
+// promoteAdmin gives the user with the email the admin role, a user who has
+// not registered yet is promoted on the first start after they do
+func promoteAdmin(ctx context.Context, store database.Store, pool *pgxpool.Pool, email string) error {
+	user, err := store.GetUser(ctx, pool, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("admin %s has not registered yet", email)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Role == database.UserRoleAdmin {
+		return nil
+	}
+
+	_, err = store.UpdateUserRole(ctx, pool, database.UpdateUserRoleParams{
+		Role: database.UserRoleAdmin,
+		ID:   user.ID,
+	})
+	return err
+}
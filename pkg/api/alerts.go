@@ -0,0 +1,139 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	StockThresholdRequest struct {
+		LocationID   int32 `json:"location_id" validate:"required"`
+		ProductID    int32 `json:"product_id" validate:"required"`
+		MinQty       int32 `json:"min_qty" validate:"min=0"`
+		ReorderPoint int32 `json:"reorder_point" validate:"gtefield=MinQty"`
+		MaxQty       int32 `json:"max_qty" validate:"gtefield=ReorderPoint"`
+	}
+
+	ListStockThresholdsRequest struct {
+		LocationID int32 `query:"location_id"`
+	}
+
+	ListAlertsRequest struct {
+		Status     string `query:"status" validate:"omitempty,oneof=open resolved"`
+		LocationID int32  `query:"location_id"`
+		PageRequest
+	}
+)
+
+func (server *Server) setStockThreshold(ctx *fiber.Ctx) error {
+	var request StockThresholdRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	threshold, err := server.store.UpsertStockThreshold(ctx.Context(), server.pool, database.UpsertStockThresholdParams{
+		LocationID:   request.LocationID,
+		ProductID:    request.ProductID,
+		MinQty:       request.MinQty,
+		ReorderPoint: request.ReorderPoint,
+		MaxQty:       request.MaxQty,
+		UpdatedBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(threshold)
+}
+
+func (server *Server) listStockThresholds(ctx *fiber.Ctx) error {
+	var request ListStockThresholdsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	arg := pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0}
+	return server.sendList(ctx, "stock-thresholds", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListStockThresholds(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListStockThresholdsRows(ctx.Context(), db, arg)
+	})
+}
+
+// deleteStockThreshold stops watching a product at a location, its open
+// alerts are resolved by the next evaluation
+func (server *Server) deleteStockThreshold(ctx *fiber.Ctx) error {
+	locationID, err := ctx.ParamsInt("location_id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	productID, err := ctx.ParamsInt("product_id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	err = server.store.DeleteStockThreshold(ctx.Context(), server.pool, database.DeleteStockThresholdParams{
+		LocationID: int32(locationID),
+		ProductID:  int32(productID),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (server *Server) listAlerts(ctx *fiber.Ctx) error {
+	var request ListAlertsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListStockAlertsParams{
+		Status:     optionalText(request.Status),
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "alerts", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListStockAlerts(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListStockAlertsRows(ctx.Context(), db, arg)
+	})
+}
+
+// listReorderSuggestions returns the quantities to put on purchase orders for
+// the products running low, taking their forecast demand into account
+func (server *Server) listReorderSuggestions(ctx *fiber.Ctx) error {
+	var request ListStockThresholdsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	suggestions, err := server.store.SuggestReorders(ctx.Context(), server.pool, database.SuggestReordersParams{
+		LocationID:  pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		Forecaster:  server.forecaster,
+		HistoryDays: server.config.ForecastHistoryDays,
+		CoverDays:   server.config.ReorderCoverDays,
+		Today:       time.Now(),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return server.sendItems(ctx, "reorder-suggestions", suggestions)
+}
@@ -21,9 +21,11 @@ type (
 	}
 
 	UserResponse struct {
+		ID        int32  `json:"id"`
 		FirstName string `json:"firstName"`
 		LastName  string `json:"lastName"`
 		Email     string `json:"email"`
+		Role      string `json:"role"`
 	}
 
 	LoginRequest struct {
@@ -43,9 +45,11 @@ type (
 
 func newUserResponse(user database.User) UserResponse {
 	return UserResponse{
+		ID:        user.ID,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Email:     user.Email,
+		Role:      user.Role,
 	}
 }
 
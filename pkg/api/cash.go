@@ -0,0 +1,215 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	OpenCashSessionRequest struct {
+		LocationID   int32  `json:"location_id" validate:"required"`
+		OpeningFloat int64  `json:"opening_float" validate:"min=0"`
+		Note         string `json:"note"`
+	}
+
+	CloseCashSessionRequest struct {
+		CountedCash int64  `json:"counted_cash" validate:"min=0"`
+		Note        string `json:"note"`
+	}
+
+	ListCashSessionsRequest struct {
+		LocationID int32  `query:"location_id"`
+		Status     string `query:"status" validate:"omitempty,oneof=open closed"`
+		PageRequest
+	}
+
+	SubmitDailyClosingRequest struct {
+		LocationID   int32  `json:"location_id" validate:"required"`
+		BusinessDate string `json:"business_date" validate:"required,datetime=2006-01-02"`
+	}
+
+	ListDailyClosingsRequest struct {
+		LocationID int32 `query:"location_id"`
+		PageRequest
+	}
+)
+
+// openCashSession starts a shift for the authenticated cashier
+func (server *Server) openCashSession(ctx *fiber.Ctx) error {
+	var request OpenCashSessionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	session, err := server.store.OpenCashSessionTx(ctx.Context(), server.pool, database.OpenCashSessionTxParams{
+		LocationID:   request.LocationID,
+		Cashier:      authorizationPayload(ctx).Issuer,
+		OpeningFloat: request.OpeningFloat,
+		Note:         request.Note,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(session)
+}
+
+func (server *Server) listCashSessions(ctx *fiber.Ctx) error {
+	var request ListCashSessionsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListCashSessionsParams{
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		Status:     optionalText(request.Status),
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "cash-sessions", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListCashSessions(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListCashSessionsRows(ctx.Context(), db, arg)
+	})
+}
+
+// getCurrentCashSession returns the open session of the authenticated cashier
+func (server *Server) getCurrentCashSession(ctx *fiber.Ctx) error {
+	session, err := server.store.GetOpenCashSession(ctx.Context(), server.pool, authorizationPayload(ctx).Issuer)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(session)
+}
+
+func (server *Server) getCashSession(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	session, err := server.store.GetCashSession(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(session)
+}
+
+// closeCashSession ends a shift with the cash counted in the drawer
+func (server *Server) closeCashSession(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request CloseCashSessionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	session, err := server.store.CloseCashSessionTx(ctx.Context(), server.pool, database.CloseCashSessionTxParams{
+		SessionID:   int64(id),
+		CountedCash: request.CountedCash,
+		Note:        request.Note,
+		ClosedBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(session)
+}
+
+func (server *Server) submitDailyClosing(ctx *fiber.Ctx) error {
+	var request SubmitDailyClosingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.SubmitDailyClosingTx(ctx.Context(), server.pool, database.SubmitDailyClosingTxParams{
+		LocationID:   request.LocationID,
+		BusinessDate: optionalDate(request.BusinessDate),
+		SubmittedBy:  authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+func (server *Server) listDailyClosings(ctx *fiber.Ctx) error {
+	var request ListDailyClosingsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListDailyClosingsParams{
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "daily-closings", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListDailyClosings(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListDailyClosingsRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getDailyClosing(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	closing, err := server.store.GetDailyClosing(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(closing)
+}
+
+// approveDailyClosing locks the sales of the closing's location and day
+func (server *Server) approveDailyClosing(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	closing, err := server.store.ApproveDailyClosingTx(ctx.Context(), server.pool, database.ApproveDailyClosingTxParams{
+		DailyClosingID: int64(id),
+		ApprovedBy:     authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(closing)
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/util"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	CustomerRequest struct {
+		Name         string `json:"name" validate:"required"`
+		CustomerType string `json:"customer_type" validate:"required,oneof=household micro_business restaurant sub_agent"`
+		Phone        string `json:"phone" validate:"omitempty,min=8"`
+		NationalID   string `json:"national_id" validate:"omitempty,numeric,len=16"`
+		BusinessID   string `json:"business_id" validate:"omitempty,numeric,len=13"`
+		Address      string `json:"address"`
+		CreditLimit  int64  `json:"credit_limit" validate:"min=0"`
+		// PaymentTermDays defaults to 30 days when left out
+		PaymentTermDays *int32 `json:"payment_term_days" validate:"omitempty,min=0"`
+		// Latitude and Longitude are decimal degrees, both or neither are given
+		Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+		Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	}
+
+	ListCustomersRequest struct {
+		CustomerType string `query:"customer_type"`
+		Phone        string `query:"phone"`
+		IDNumber     string `query:"id_number"`
+		Name         string `query:"name"`
+		PageRequest
+	}
+
+	CustomerResponse struct {
+		database.Customer
+		EmptiesBalances []database.EmptiesBalance `json:"empties_balances"`
+	}
+)
+
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func optionalFloat(value *float64) pgtype.Float8 {
+	if value == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *value, Valid: true}
+}
+
+func (request CustomerRequest) paymentTermDays() int32 {
+	if request.PaymentTermDays == nil {
+		return 30
+	}
+	return *request.PaymentTermDays
+}
+
+func (server *Server) createCustomer(ctx *fiber.Ctx) error {
+	var request CustomerRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	phone := optionalText(util.NormalizePhone(request.Phone))
+
+	// refuse to register the same household or business twice, the unique
+	// indexes still guard against two registrations racing each other
+	duplicates, err := server.store.FindDuplicateCustomers(ctx.Context(), server.pool, database.FindDuplicateCustomersParams{
+		Phone:      phone,
+		NationalID: optionalText(request.NationalID),
+		BusinessID: optionalText(request.BusinessID),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	if len(duplicates) > 0 {
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"message":    "customer is already registered",
+			"duplicates": duplicates,
+		})
+	}
+
+	customer, err := server.store.CreateCustomer(ctx.Context(), server.pool, database.CreateCustomerParams{
+		Name:            request.Name,
+		CustomerType:    request.CustomerType,
+		Phone:           phone,
+		NationalID:      optionalText(request.NationalID),
+		BusinessID:      optionalText(request.BusinessID),
+		Address:         optionalText(request.Address),
+		CreditLimit:     request.CreditLimit,
+		PaymentTermDays: request.paymentTermDays(),
+		Latitude:        optionalFloat(request.Latitude),
+		Longitude:       optionalFloat(request.Longitude),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(CustomerResponse{
+		Customer:        customer,
+		EmptiesBalances: []database.EmptiesBalance{},
+	})
+}
+
+func (server *Server) listCustomers(ctx *fiber.Ctx) error {
+	var request ListCustomersRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListCustomersParams{
+		CustomerType: optionalText(request.CustomerType),
+		Phone:        optionalText(util.NormalizePhone(request.Phone)),
+		IDNumber:     optionalText(request.IDNumber),
+		Name:         optionalText(request.Name),
+		PageSize:     request.limit(),
+		PageOffset:   request.offset(),
+	}
+
+	return server.sendList(ctx, "customers", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListCustomers(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListCustomersRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getCustomer(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response CustomerResponse
+	response.Customer, err = server.store.GetCustomer(ctx.Context(), server.pool, int32(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.EmptiesBalances, err = server.store.ListEmptiesBalances(ctx.Context(), server.pool, response.Customer.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) updateCustomer(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request CustomerRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	customer, err := server.store.UpdateCustomer(ctx.Context(), server.pool, database.UpdateCustomerParams{
+		ID:              int32(id),
+		Name:            request.Name,
+		CustomerType:    request.CustomerType,
+		Phone:           optionalText(util.NormalizePhone(request.Phone)),
+		NationalID:      optionalText(request.NationalID),
+		BusinessID:      optionalText(request.BusinessID),
+		Address:         optionalText(request.Address),
+		CreditLimit:     request.CreditLimit,
+		PaymentTermDays: request.paymentTermDays(),
+		Latitude:        optionalFloat(request.Latitude),
+		Longitude:       optionalFloat(request.Longitude),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(customer)
+}
+
+// deleteCustomer only deactivates the customer, their sales still refer to them
+func (server *Server) deleteCustomer(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	customer, err := server.store.DeactivateCustomer(ctx.Context(), server.pool, int32(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(customer)
+}
+
+// listCustomerEmpties returns the empties the customer still owes, per product
+func (server *Server) listCustomerEmpties(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	return server.sendList(ctx, "customer-empties", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListEmptiesBalances(ctx.Context(), db, int32(id))
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListEmptiesBalancesRows(ctx.Context(), db, int32(id))
+	})
+}
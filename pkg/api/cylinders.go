@@ -0,0 +1,272 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	RegisterCylinderRequest struct {
+		SerialNumber     string `json:"serial_number" validate:"required"`
+		QRCode           string `json:"qr_code"`
+		ProductID        int32  `json:"product_id" validate:"required"`
+		ManufactureDate  string `json:"manufacture_date" validate:"required,datetime=2006-01-02"`
+		TareWeightGrams  int32  `json:"tare_weight_grams" validate:"required,gt=0"`
+		OwnerType        string `json:"owner_type" validate:"required,oneof=company customer"`
+		OwnerCustomerID  int32  `json:"owner_customer_id" validate:"required_if=OwnerType customer"`
+		Status           string `json:"status" validate:"required,oneof=full empty in_repair"`
+		LocationID       int32  `json:"location_id" validate:"required_without=HolderCustomerID"`
+		HolderCustomerID int32  `json:"holder_customer_id" validate:"required_without=LocationID"`
+		NextTestDue      string `json:"next_test_due" validate:"omitempty,datetime=2006-01-02"`
+	}
+
+	ListCylindersRequest struct {
+		ProductID  int32  `query:"product_id"`
+		Status     string `query:"status" validate:"omitempty,oneof=full empty in_repair condemned"`
+		LocationID int32  `query:"location_id"`
+		CustomerID int32  `query:"customer_id"`
+		PageRequest
+	}
+
+	MoveCylinderRequest struct {
+		ToLocationID  int32  `json:"to_location_id" validate:"required_without=ToCustomerID"`
+		ToCustomerID  int32  `json:"to_customer_id" validate:"required_without=ToLocationID"`
+		Status        string `json:"status" validate:"required,oneof=full empty in_repair condemned"`
+		Reason        string `json:"reason" validate:"required"`
+		ReferenceType string `json:"reference_type"`
+		ReferenceID   int64  `json:"reference_id"`
+	}
+
+	RecordInspectionRequest struct {
+		TestDate    string `json:"test_date" validate:"required,datetime=2006-01-02"`
+		Result      string `json:"result" validate:"required,oneof=pass fail"`
+		NextDueDate string `json:"next_due_date" validate:"omitempty,datetime=2006-01-02"`
+		Inspector   string `json:"inspector" validate:"required"`
+		Note        string `json:"note"`
+	}
+
+	ListCylindersDueRequest struct {
+		WithinDays int32 `query:"within_days" validate:"min=0"`
+		PageRequest
+	}
+
+	// SerialsRequest names the tracked cylinders that go with a step, the
+	// body can be left out when there are none
+	SerialsRequest struct {
+		Serials []string `json:"serials" validate:"dive,required"`
+	}
+
+	CylinderResponse struct {
+		Cylinder database.Cylinder           `json:"cylinder"`
+		History  []database.CylinderMovement `json:"history"`
+	}
+)
+
+// optionalDate parses a date the validator already accepted, an empty
+// string is a null date
+func optionalDate(value string) pgtype.Date {
+	date, err := time.Parse(time.DateOnly, value)
+	return pgtype.Date{Time: date, Valid: err == nil}
+}
+
+func (server *Server) registerCylinder(ctx *fiber.Ctx) error {
+	var request RegisterCylinderRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.RegisterCylinderTx(ctx.Context(), server.pool, database.RegisterCylinderTxParams{
+		CreateCylinderParams: database.CreateCylinderParams{
+			SerialNumber:     request.SerialNumber,
+			QrCode:           optionalText(request.QRCode),
+			ProductID:        request.ProductID,
+			ManufactureDate:  optionalDate(request.ManufactureDate),
+			TareWeightGrams:  request.TareWeightGrams,
+			OwnerType:        request.OwnerType,
+			OwnerCustomerID:  pgtype.Int4{Int32: request.OwnerCustomerID, Valid: request.OwnerCustomerID != 0},
+			Status:           request.Status,
+			LocationID:       pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+			HolderCustomerID: pgtype.Int4{Int32: request.HolderCustomerID, Valid: request.HolderCustomerID != 0},
+			NextTestDue:      optionalDate(request.NextTestDue),
+		},
+		CreatedBy: authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listCylinders(ctx *fiber.Ctx) error {
+	var request ListCylindersRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListCylindersParams{
+		ProductID:  pgtype.Int4{Int32: request.ProductID, Valid: request.ProductID != 0},
+		Status:     optionalText(request.Status),
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		CustomerID: pgtype.Int4{Int32: request.CustomerID, Valid: request.CustomerID != 0},
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "cylinders", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListCylinders(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListCylindersRows(ctx.Context(), db, arg)
+	})
+}
+
+// cylinderResponse returns the cylinder together with every place it has been
+func (server *Server) cylinderResponse(ctx *fiber.Ctx, cylinder database.Cylinder) error {
+	history, err := server.store.ListCylinderMovements(ctx.Context(), server.pool, cylinder.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(CylinderResponse{
+		Cylinder: cylinder,
+		History:  history,
+	})
+}
+
+func (server *Server) getCylinder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	cylinder, err := server.store.GetCylinder(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return server.cylinderResponse(ctx, cylinder)
+}
+
+// scanCylinder finds a cylinder by its serial number or its QR code
+func (server *Server) scanCylinder(ctx *fiber.Ctx) error {
+	cylinder, err := server.store.GetCylinderByCode(ctx.Context(), server.pool, ctx.Params("code"))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return server.cylinderResponse(ctx, cylinder)
+}
+
+func (server *Server) moveCylinder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request MoveCylinderRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.MoveCylinderTx(ctx.Context(), server.pool, database.MoveCylinderTxParams{
+		CylinderID:    int64(id),
+		ToLocationID:  pgtype.Int4{Int32: request.ToLocationID, Valid: request.ToLocationID != 0},
+		ToCustomerID:  pgtype.Int4{Int32: request.ToCustomerID, Valid: request.ToCustomerID != 0},
+		Status:        request.Status,
+		Reason:        request.Reason,
+		ReferenceType: optionalText(request.ReferenceType),
+		ReferenceID:   pgtype.Int8{Int64: request.ReferenceID, Valid: request.ReferenceID != 0},
+		CreatedBy:     authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) recordInspection(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request RecordInspectionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.RecordInspectionTx(ctx.Context(), server.pool, database.RecordInspectionTxParams{
+		CylinderID:  int64(id),
+		TestDate:    optionalDate(request.TestDate),
+		Result:      request.Result,
+		NextDueDate: optionalDate(request.NextDueDate),
+		Inspector:   request.Inspector,
+		Note:        request.Note,
+		CreatedBy:   authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listInspections(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	return server.sendList(ctx, "inspections", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListCylinderInspections(ctx.Context(), db, int64(id))
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListCylinderInspectionsRows(ctx.Context(), db, int64(id))
+	})
+}
+
+// listCylindersDue returns the cylinders overdue for their re-test or coming
+// due within the given number of days, most overdue first
+func (server *Server) listCylindersDue(ctx *fiber.Ctx) error {
+	var request ListCylindersDueRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	today := database.Today()
+	arg := database.ListCylindersDueForTestParams{
+		DueBefore:  pgtype.Date{Time: today.Time.AddDate(0, 0, int(request.WithinDays)), Valid: true},
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "cylinders-due", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListCylindersDueForTest(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListCylindersDueForTestRows(ctx.Context(), db, arg)
+	})
+}
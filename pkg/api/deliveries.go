@@ -0,0 +1,442 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	DeliveryStopItemRequest struct {
+		ProductID        int32 `json:"product_id" validate:"required"`
+		Quantity         int32 `json:"quantity" validate:"required,gt=0"`
+		EmptiesToCollect int32 `json:"empties_to_collect" validate:"min=0"`
+	}
+
+	DeliveryStopRequest struct {
+		CustomerID int32                     `json:"customer_id" validate:"required"`
+		Note       string                    `json:"note"`
+		Items      []DeliveryStopItemRequest `json:"items" validate:"required,min=1,dive"`
+	}
+
+	CreateDeliveryOrderRequest struct {
+		SourceLocationID int32                 `json:"source_location_id" validate:"required"`
+		VehicleID        int32                 `json:"vehicle_id" validate:"required"`
+		DriverID         int32                 `json:"driver_id" validate:"required"`
+		ScheduledDate    string                `json:"scheduled_date" validate:"required,datetime=2006-01-02"`
+		Note             string                `json:"note"`
+		Stops            []DeliveryStopRequest `json:"stops" validate:"required,min=1,dive"`
+	}
+
+	ListDeliveryOrdersRequest struct {
+		Status        string `query:"status" validate:"omitempty,oneof=planned confirmed loaded en_route delivered reconciled cancelled"`
+		ScheduledDate string `query:"scheduled_date" validate:"omitempty,datetime=2006-01-02"`
+		VehicleID     int32  `query:"vehicle_id"`
+		PageRequest
+	}
+
+	DeliveredItemRequest struct {
+		ProductID        int32    `json:"product_id" validate:"required"`
+		DeliveredQty     int32    `json:"delivered_qty" validate:"min=0"`
+		EmptiesCollected int32    `json:"empties_collected" validate:"min=0"`
+		Serials          []string `json:"serials" validate:"dive,required"`
+		EmptySerials     []string `json:"empty_serials" validate:"dive,required"`
+	}
+
+	CompleteDeliveryStopRequest struct {
+		Items         []DeliveredItemRequest `json:"items" validate:"required,min=1,dive"`
+		PaymentMethod string                 `json:"payment_method" validate:"required,oneof=cash transfer qris credit"`
+		Note          string                 `json:"note"`
+	}
+
+	FailDeliveryStopRequest struct {
+		Note string `json:"note" validate:"required"`
+	}
+
+	ReconciledItemRequest struct {
+		ProductID int32 `json:"product_id" validate:"required"`
+		FullQty   int32 `json:"full_qty" validate:"min=0"`
+		EmptyQty  int32 `json:"empty_qty" validate:"min=0"`
+	}
+
+	ReconcileDeliveryOrderRequest struct {
+		Items         []ReconciledItemRequest `json:"items" validate:"required,min=1,dive"`
+		CashCollected int64                   `json:"cash_collected" validate:"min=0"`
+		Note          string                  `json:"note"`
+	}
+
+	PlanDeliveriesRequest struct {
+		SourceLocationID int32  `json:"source_location_id" validate:"required"`
+		ScheduledDate    string `json:"scheduled_date" validate:"required,datetime=2006-01-02"`
+	}
+
+	AcceptedRouteRequest struct {
+		DeliveryOrderID int64   `json:"delivery_order_id" validate:"required"`
+		StopIDs         []int64 `json:"stop_ids" validate:"dive,required"`
+	}
+
+	AcceptDeliveryPlanRequest struct {
+		SourceLocationID int32                  `json:"source_location_id" validate:"required"`
+		ScheduledDate    string                 `json:"scheduled_date" validate:"required,datetime=2006-01-02"`
+		Routes           []AcceptedRouteRequest `json:"routes" validate:"required,min=1,dive"`
+	}
+)
+
+func (server *Server) createDeliveryOrder(ctx *fiber.Ctx) error {
+	var request CreateDeliveryOrderRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	stops := make([]database.DeliveryStopParams, 0, len(request.Stops))
+	for _, stop := range request.Stops {
+		items := make([]database.DeliveryStopItemParams, 0, len(stop.Items))
+		for _, item := range stop.Items {
+			items = append(items, database.DeliveryStopItemParams{
+				ProductID:        item.ProductID,
+				Quantity:         item.Quantity,
+				EmptiesToCollect: item.EmptiesToCollect,
+			})
+		}
+
+		stops = append(stops, database.DeliveryStopParams{
+			CustomerID: stop.CustomerID,
+			Note:       stop.Note,
+			Items:      items,
+		})
+	}
+
+	result, err := server.store.CreateDeliveryOrderTx(ctx.Context(), server.pool, database.CreateDeliveryOrderTxParams{
+		SourceLocationID: request.SourceLocationID,
+		VehicleID:        request.VehicleID,
+		DriverID:         request.DriverID,
+		ScheduledDate:    optionalDate(request.ScheduledDate),
+		Note:             request.Note,
+		Stops:            stops,
+		CreatedBy:        authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listDeliveryOrders(ctx *fiber.Ctx) error {
+	var request ListDeliveryOrdersRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListDeliveryOrdersParams{
+		Status:        optionalText(request.Status),
+		ScheduledDate: optionalDate(request.ScheduledDate),
+		VehicleID:     pgtype.Int4{Int32: request.VehicleID, Valid: request.VehicleID != 0},
+		PageSize:      request.limit(),
+		PageOffset:    request.offset(),
+	}
+
+	return server.sendList(ctx, "delivery-orders", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListDeliveryOrders(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListDeliveryOrdersRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getDeliveryOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var result database.DeliveryOrderTxResult
+	result.DeliveryOrder, err = server.store.GetDeliveryOrder(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	result.Stops, err = server.store.ListDeliveryStops(ctx.Context(), server.pool, result.DeliveryOrder.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	result.Items, err = server.store.ListDeliveryStopItems(ctx.Context(), server.pool, result.DeliveryOrder.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+// confirmDeliveryOrder reserves the stock of the order for the configured
+// reservation TTL
+func (server *Server) confirmDeliveryOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	result, err := server.store.ConfirmDeliveryOrderTx(ctx.Context(), server.pool, database.ConfirmDeliveryOrderTxParams{
+		DeliveryOrderID: int64(id),
+		ExpiresAt:       time.Now().Add(server.config.ReservationTTL),
+		ConfirmedBy:     authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+type deliveryOrderStep func(ctx context.Context, db database.TxBeginner, arg database.DeliveryOrderStepTxParams) (database.DeliveryOrderTxResult, error)
+
+// deliveryOrderStepHandler runs a lifecycle step that only needs the order
+// and the acting user
+func (server *Server) deliveryOrderStepHandler(step deliveryOrderStep) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		id, err := ctx.ParamsInt("id")
+		if err != nil {
+			return fiber.ErrBadRequest
+		}
+
+		result, err := step(ctx.Context(), server.pool, database.DeliveryOrderStepTxParams{
+			DeliveryOrderID: int64(id),
+			CreatedBy:       authorizationPayload(ctx).Issuer,
+		})
+		if err != nil {
+			return storeError(err)
+		}
+
+		return ctx.JSON(result)
+	}
+}
+
+func (server *Server) loadDeliveryOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request SerialsRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return fiber.ErrUnprocessableEntity
+		}
+
+		if errs := server.validator.Validate(request); len(errs) > 0 {
+			return badRequest(ctx, errs)
+		}
+	}
+
+	result, err := server.store.LoadDeliveryOrderTx(ctx.Context(), server.pool, database.LoadDeliveryOrderTxParams{
+		DeliveryOrderID: int64(id),
+		Serials:         request.Serials,
+		CreatedBy:       authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+func (server *Server) completeDeliveryStop(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	stopID, err := ctx.ParamsInt("stop_id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request CompleteDeliveryStopRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	items := make([]database.DeliveredItemParams, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, database.DeliveredItemParams{
+			ProductID:        item.ProductID,
+			DeliveredQty:     item.DeliveredQty,
+			EmptiesCollected: item.EmptiesCollected,
+			Serials:          item.Serials,
+			EmptySerials:     item.EmptySerials,
+		})
+	}
+
+	result, err := server.store.CompleteDeliveryStopTx(ctx.Context(), server.pool, database.CompleteDeliveryStopTxParams{
+		DeliveryOrderID: int64(id),
+		StopID:          int64(stopID),
+		Items:           items,
+		PaymentMethod:   request.PaymentMethod,
+		TaxBasisPoints:  server.config.SalesTaxBasisPoints,
+		Note:            request.Note,
+		CompletedBy:     authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+func (server *Server) failDeliveryStop(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	stopID, err := ctx.ParamsInt("stop_id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request FailDeliveryStopRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.FailDeliveryStopTx(ctx.Context(), server.pool, database.FailDeliveryStopTxParams{
+		DeliveryOrderID: int64(id),
+		StopID:          int64(stopID),
+		Note:            request.Note,
+		CompletedBy:     authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+// reconcileDeliveryOrder settles a returned vehicle against what the driver
+// reports to have brought back
+func (server *Server) reconcileDeliveryOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request ReconcileDeliveryOrderRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	items := make([]database.ReconciledItemParams, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, database.ReconciledItemParams{
+			ProductID: item.ProductID,
+			FullQty:   item.FullQty,
+			EmptyQty:  item.EmptyQty,
+		})
+	}
+
+	report, err := server.store.ReconcileDeliveryOrderTx(ctx.Context(), server.pool, database.ReconcileDeliveryOrderTxParams{
+		DeliveryOrderID: int64(id),
+		Items:           items,
+		CashCollected:   request.CashCollected,
+		Note:            request.Note,
+		ReconciledBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(report)
+}
+
+func (server *Server) getDeliveryReconciliation(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	report, err := server.store.GetDeliveryReconciliationReport(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(report)
+}
+
+// planDeliveries proposes a route per vehicle for the planned orders of a
+// source location and day, nothing is saved until the plan is accepted
+func (server *Server) planDeliveries(ctx *fiber.Ctx) error {
+	var request PlanDeliveriesRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	plan, err := server.store.PlanDeliveries(ctx.Context(), server.pool, database.PlanDeliveriesParams{
+		SourceLocationID: request.SourceLocationID,
+		ScheduledDate:    optionalDate(request.ScheduledDate),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(plan)
+}
+
+// acceptDeliveryPlan applies the routes of a plan, as proposed or edited by
+// the dispatcher, to the planned orders
+func (server *Server) acceptDeliveryPlan(ctx *fiber.Ctx) error {
+	var request AcceptDeliveryPlanRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	routes := make([]database.AcceptedRouteParams, 0, len(request.Routes))
+	for _, route := range request.Routes {
+		routes = append(routes, database.AcceptedRouteParams{
+			DeliveryOrderID: route.DeliveryOrderID,
+			StopIDs:         route.StopIDs,
+		})
+	}
+
+	results, err := server.store.AcceptDeliveryPlanTx(ctx.Context(), server.pool, database.AcceptDeliveryPlanTxParams{
+		SourceLocationID: request.SourceLocationID,
+		ScheduledDate:    optionalDate(request.ScheduledDate),
+		Routes:           routes,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(results)
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	ListDepositsRequest struct {
+		CustomerID int32  `query:"customer_id"`
+		Status     string `query:"status" validate:"omitempty,oneof=held partially_refunded refunded voided"`
+		PageRequest
+	}
+
+	RefundDepositRequest struct {
+		DepositID  int64 `json:"deposit_id" validate:"required_without=CustomerID"`
+		CustomerID int32 `json:"customer_id" validate:"required_without=DepositID"`
+		ProductID  int32 `json:"product_id" validate:"required"`
+		LocationID int32 `json:"location_id" validate:"required"`
+		Quantity   int32 `json:"quantity" validate:"required,gt=0"`
+	}
+
+	ListDepositLiabilitiesRequest struct {
+		LocationID int32 `query:"location_id"`
+	}
+
+	DepositResponse struct {
+		Deposit database.CylinderDeposit `json:"deposit"`
+		Refunds []database.DepositRefund `json:"refunds"`
+	}
+)
+
+func (server *Server) listDeposits(ctx *fiber.Ctx) error {
+	var request ListDepositsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListCylinderDepositsParams{
+		CustomerID: pgtype.Int4{Int32: request.CustomerID, Valid: request.CustomerID != 0},
+		Status:     optionalText(request.Status),
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "deposits", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListCylinderDeposits(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListCylinderDepositsRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getDeposit(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response DepositResponse
+	response.Deposit, err = server.store.GetCylinderDeposit(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Refunds, err = server.store.ListDepositRefunds(ctx.Context(), server.pool, response.Deposit.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) refundDeposit(ctx *fiber.Ctx) error {
+	var request RefundDepositRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.RefundDepositTx(ctx.Context(), server.pool, database.RefundDepositTxParams{
+		DepositID:  pgtype.Int8{Int64: request.DepositID, Valid: request.DepositID != 0},
+		CustomerID: pgtype.Int4{Int32: request.CustomerID, Valid: request.CustomerID != 0},
+		ProductID:  request.ProductID,
+		LocationID: request.LocationID,
+		Quantity:   request.Quantity,
+		RefundedBy: authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+// listDepositLiabilities returns the deposits still owed back to customers,
+// per location where they were taken
+func (server *Server) listDepositLiabilities(ctx *fiber.Ctx) error {
+	var request ListDepositLiabilitiesRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	arg := pgtype.Int4{
+		Int32: request.LocationID,
+		Valid: request.LocationID != 0,
+	}
+
+	return server.sendList(ctx, "deposit-liabilities", nil, func(db database.DBTX) (any, error) {
+		return server.store.SumDepositLiabilities(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.SumDepositLiabilitiesRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) reconcileDeposits(ctx *fiber.Ctx) error {
+	reconciliation, err := server.store.ReconcileDeposits(ctx.Context(), server.pool)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return server.sendItems(ctx, "deposit-reconciliation", reconciliation)
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/document"
+	"github.com/gofiber/fiber/v2"
+)
+
+// documentLink is the address of a record in the back-office web app, which
+// the QR code of its document leads back to. The API itself needs a token a
+// phone scanning the code does not have, the web app asks to log in instead.
+func (server *Server) documentLink(path string) string {
+	return strings.TrimSuffix(server.config.DocumentBaseURL, "/") + "/" + path
+}
+
+// sendDocument prints a document and sends it to be shown in the browser,
+// nothing is sent when printing fails
+func (server *Server) sendDocument(ctx *fiber.Ctx, name string, write func(w io.Writer) error) error {
+	var buffer bytes.Buffer
+	if err := write(&buffer); err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, document.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s.pdf"`, name))
+	return ctx.Send(buffer.Bytes())
+}
+
+// productNames maps the id of every product to its name
+func (server *Server) productNames(ctx context.Context) (map[int32]string, error) {
+	products, err := server.store.ListProducts(ctx, server.pool)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int32]string, len(products))
+	for _, product := range products {
+		names[product.ID] = product.Name
+	}
+	return names, nil
+}
+
+// customerParty is a customer as a document is addressed to them
+func customerParty(customer database.Customer) document.Party {
+	party := document.Party{Name: customer.Name}
+	if customer.Address.Valid {
+		party.Lines = append(party.Lines, customer.Address.String)
+	}
+	if customer.Phone.Valid {
+		party.Lines = append(party.Lines, "Telp. "+customer.Phone.String)
+	}
+	if customer.BusinessID.Valid {
+		party.Lines = append(party.Lines, "NIB "+customer.BusinessID.String)
+	}
+	return party
+}
+
+// saleLines are the items of a sale as printed, a new cylinder is told
+// apart from an exchange
+func saleLines(items []database.SaleItem, names map[int32]string) []document.Line {
+	lines := make([]document.Line, len(items))
+	for i, item := range items {
+		description := names[item.ProductID]
+		if item.SaleType == database.SaleTypeNewCylinder {
+			description += " (tabung baru)"
+		}
+
+		lines[i] = document.Line{
+			Description: description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    item.DiscountAmount,
+			Deposit:     int64(item.Quantity) * item.DepositAmount,
+			Amount:      item.LineTotal,
+		}
+	}
+	return lines
+}
+
+func saleTotals(sale database.Sale) document.Totals {
+	return document.Totals{
+		Subtotal: sale.Subtotal,
+		Discount: sale.DiscountTotal,
+		Tax:      sale.TaxTotal,
+		Deposit:  sale.DepositTotal,
+		Total:    sale.Total,
+	}
+}
+
+// getInvoicePDF prints an invoice with the sale it bills
+func (server *Server) getInvoicePDF(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	invoice, err := server.store.GetInvoice(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	sale, err := server.store.GetSale(ctx.Context(), server.pool, invoice.SaleID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	items, err := server.store.ListSaleItems(ctx.Context(), server.pool, sale.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	customer, err := server.store.GetCustomer(ctx.Context(), server.pool, invoice.CustomerID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	names, err := server.productNames(ctx.Context())
+	if err != nil {
+		return storeError(err)
+	}
+
+	totals := saleTotals(sale)
+	totals.Total = invoice.Amount
+
+	name := strings.ReplaceAll(invoice.InvoiceNumber, "/", "-")
+	return server.sendDocument(ctx, name, func(w io.Writer) error {
+		return server.documents.WriteInvoice(w, document.Invoice{
+			Number:    invoice.InvoiceNumber,
+			IssueDate: invoice.IssueDate.Time,
+			DueDate:   invoice.DueDate.Time,
+			Status:    invoice.Status,
+			Customer:  customerParty(customer),
+			Lines:     saleLines(items, names),
+			Totals:    totals,
+			Paid:      invoice.PaidAmount,
+			Link:      server.documentLink(fmt.Sprintf("invoices/%d", invoice.ID)),
+		})
+	})
+}
+
+// getDeliveryNotePDF prints the surat jalan of a delivery order, which goes
+// with the driver and is signed by every customer on the route
+func (server *Server) getDeliveryNotePDF(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	order, err := server.store.GetDeliveryOrder(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	stops, err := server.store.ListDeliveryStops(ctx.Context(), server.pool, order.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	items, err := server.store.ListDeliveryStopItems(ctx.Context(), server.pool, order.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	source, err := server.store.GetLocation(ctx.Context(), server.pool, order.SourceLocationID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	vehicle, err := server.store.GetVehicle(ctx.Context(), server.pool, order.VehicleID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	driver, err := server.store.GetDriver(ctx.Context(), server.pool, order.DriverID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	user, err := server.store.GetUserByID(ctx.Context(), server.pool, driver.UserID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	names, err := server.productNames(ctx.Context())
+	if err != nil {
+		return storeError(err)
+	}
+
+	note := document.DeliveryNote{
+		Number:  fmt.Sprintf("SJ-%06d", order.ID),
+		Date:    order.ScheduledDate.Time,
+		Source:  source.Name,
+		Vehicle: vehicle.PlateNumber,
+		Driver:  fmt.Sprintf("%s %s (%s)", user.FirstName, user.LastName, driver.Phone),
+		Note:    order.Note,
+		Stops:   make([]document.DeliveryStop, len(stops)),
+		Link:    server.documentLink(fmt.Sprintf("deliveries/%d", order.ID)),
+	}
+
+	for i, stop := range stops {
+		customer, err := server.store.GetCustomer(ctx.Context(), server.pool, stop.CustomerID)
+		if err != nil {
+			return storeError(err)
+		}
+
+		note.Stops[i] = document.DeliveryStop{
+			Sequence: stop.Sequence,
+			Customer: customerParty(customer),
+			Closed:   stop.Status != database.DeliveryStopStatusPending,
+		}
+
+		for _, item := range items {
+			if item.DeliveryStopID != stop.ID {
+				continue
+			}
+
+			note.Stops[i].Items = append(note.Stops[i].Items, document.DeliveryItem{
+				Description:      names[item.ProductID],
+				Quantity:         item.Quantity,
+				EmptiesToCollect: item.EmptiesToCollect,
+				DeliveredQty:     item.DeliveredQty,
+				EmptiesCollected: item.EmptiesCollected,
+			})
+		}
+	}
+
+	return server.sendDocument(ctx, note.Number, func(w io.Writer) error {
+		return server.documents.WriteDeliveryNote(w, note)
+	})
+}
+
+// getReceiptPDF prints the receipt of a sale for the receipt printer of the
+// outlet
+func (server *Server) getReceiptPDF(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	sale, err := server.store.GetSale(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	items, err := server.store.ListSaleItems(ctx.Context(), server.pool, sale.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	location, err := server.store.GetLocation(ctx.Context(), server.pool, sale.LocationID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	var customerName string
+	if sale.CustomerID.Valid {
+		customer, err := server.store.GetCustomer(ctx.Context(), server.pool, sale.CustomerID.Int32)
+		if err != nil {
+			return storeError(err)
+		}
+		customerName = customer.Name
+	}
+
+	names, err := server.productNames(ctx.Context())
+	if err != nil {
+		return storeError(err)
+	}
+
+	receipt := document.Receipt{
+		Number:        fmt.Sprintf("%06d", sale.ID),
+		Time:          sale.CreatedAt,
+		Location:      location.Name,
+		Cashier:       sale.CreatedBy,
+		Customer:      customerName,
+		Lines:         saleLines(items, names),
+		Totals:        saleTotals(sale),
+		PaymentMethod: sale.PaymentMethod,
+		Voided:        sale.Status == database.SaleStatusVoided,
+		Link:          server.documentLink(fmt.Sprintf("sales/%d", sale.ID)),
+	}
+
+	return server.sendDocument(ctx, "receipt-"+receipt.Number, func(w io.Writer) error {
+		return server.documents.WriteReceipt(w, receipt)
+	})
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes the client can do something about
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// storeErrorCodes maps errors returned by the store to the status sent to the client
+var storeErrorCodes = map[error]int{
+	pgx.ErrNoRows:                           fiber.StatusNotFound,
+	database.ErrInsufficientStock:           fiber.StatusConflict,
+	database.ErrInvalidSaleType:             fiber.StatusBadRequest,
+	database.ErrEmptiesWithoutCustomer:      fiber.StatusBadRequest,
+	database.ErrDepositRequired:             fiber.StatusBadRequest,
+	database.ErrEmptiesOnNewCylinderBuy:     fiber.StatusBadRequest,
+	database.ErrSaleEmpty:                   fiber.StatusBadRequest,
+	database.ErrInvalidDiscount:             fiber.StatusBadRequest,
+	database.ErrSaleStatus:                  fiber.StatusConflict,
+	database.ErrCustomerInactive:            fiber.StatusUnprocessableEntity,
+	database.ErrSerialsWithoutCustomer:      fiber.StatusBadRequest,
+	database.ErrPriceAboveCeiling:           fiber.StatusUnprocessableEntity,
+	database.ErrPriceNotApproved:            fiber.StatusUnprocessableEntity,
+	database.ErrPriceExists:                 fiber.StatusConflict,
+	database.ErrQuotaExceeded:               fiber.StatusUnprocessableEntity,
+	database.ErrNotEligibleForSubsidy:       fiber.StatusUnprocessableEntity,
+	database.ErrSubsidizedSaleNeedsCustomer: fiber.StatusBadRequest,
+	database.ErrTransferStatus:              fiber.StatusConflict,
+	database.ErrTransferEmpty:               fiber.StatusBadRequest,
+	database.ErrTransferOverReceipt:         fiber.StatusBadRequest,
+	database.ErrTransferUnknownItem:         fiber.StatusBadRequest,
+	database.ErrPurchaseOrderStatus:         fiber.StatusConflict,
+	database.ErrPurchaseOrderEmpty:          fiber.StatusBadRequest,
+	database.ErrPurchaseOrderUnknownItem:    fiber.StatusBadRequest,
+	database.ErrCreditSaleNeedsCustomer:     fiber.StatusBadRequest,
+	database.ErrCreditLimitExceeded:         fiber.StatusUnprocessableEntity,
+	database.ErrInvoiceStatus:               fiber.StatusConflict,
+	database.ErrInvoiceOverpayment:          fiber.StatusBadRequest,
+	database.ErrInvoiceHasPayments:          fiber.StatusConflict,
+	database.ErrDepositRefundExceedsHeld:    fiber.StatusBadRequest,
+	database.ErrDepositAlreadyRefunded:      fiber.StatusConflict,
+	database.ErrCylinderCondemned:           fiber.StatusConflict,
+	database.ErrCylinderPosition:            fiber.StatusBadRequest,
+	database.ErrCylinderTestOverdue:         fiber.StatusConflict,
+	database.ErrCylinderNotMoved:            fiber.StatusUnprocessableEntity,
+	database.ErrIncidentStatus:              fiber.StatusConflict,
+	database.ErrInvalidDisposition:          fiber.StatusBadRequest,
+	database.ErrCylinderQuarantined:         fiber.StatusConflict,
+	database.ErrCylinderNotInService:        fiber.StatusConflict,
+	database.ErrStockCountStatus:            fiber.StatusConflict,
+	database.ErrStockCountIncomplete:        fiber.StatusUnprocessableEntity,
+	database.ErrStockReserved:               fiber.StatusConflict,
+	database.ErrDeliveryStatus:              fiber.StatusConflict,
+	database.ErrDeliveryEmpty:               fiber.StatusUnprocessableEntity,
+	database.ErrDeliveryStopStatus:          fiber.StatusConflict,
+	database.ErrDeliveryUnknownItem:         fiber.StatusUnprocessableEntity,
+	database.ErrDeliveryOverDrop:            fiber.StatusUnprocessableEntity,
+	database.ErrStopEmptiesWithoutDrop:      fiber.StatusUnprocessableEntity,
+	database.ErrStopNothingDelivered:        fiber.StatusUnprocessableEntity,
+	database.ErrVehicleCapacity:             fiber.StatusUnprocessableEntity,
+	database.ErrVehicleInactive:             fiber.StatusConflict,
+	database.ErrDriverInactive:              fiber.StatusConflict,
+	database.ErrDeliveryProofStatus:         fiber.StatusConflict,
+	database.ErrDeliveryProofRecorded:       fiber.StatusConflict,
+	database.ErrPlanNoDepotCoordinates:      fiber.StatusUnprocessableEntity,
+	database.ErrPlanIncomplete:              fiber.StatusUnprocessableEntity,
+	database.ErrReconciliationMissingItem:   fiber.StatusUnprocessableEntity,
+	database.ErrReconciliationOverReport:    fiber.StatusUnprocessableEntity,
+	database.ErrNoCashSession:               fiber.StatusConflict,
+	database.ErrCashSessionStatus:           fiber.StatusConflict,
+	database.ErrCashSessionsNotClosed:       fiber.StatusConflict,
+	database.ErrDailyClosingStatus:          fiber.StatusConflict,
+	database.ErrSalesDayClosed:              fiber.StatusConflict,
+	database.ErrDailyClosingChanged:         fiber.StatusConflict,
+	database.ErrValuationMethod:             fiber.StatusBadRequest,
+	storage.ErrNotFound:                     fiber.StatusNotFound,
+	storage.ErrInvalidKey:                   fiber.StatusBadRequest,
+}
+
+// storeError converts an error returned by the store into a fiber error,
+// anything not known to be the client's fault is an internal error
+func storeError(err error) *fiber.Error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return fiber.NewError(fiber.StatusConflict, pgErr.Detail)
+		case foreignKeyViolation:
+			return fiber.NewError(fiber.StatusBadRequest, pgErr.Detail)
+		}
+	}
+
+	for target, code := range storeErrorCodes {
+		if errors.Is(err, target) {
+			return fiber.NewError(code, err.Error())
+		}
+	}
+
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+}
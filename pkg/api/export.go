@@ -0,0 +1,146 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/export"
+	"github.com/blanc08/stok-gas-management-backend/pkg/util"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// PageRequest picks the page of a list sent as JSON. An export holds every
+// row unless it asks for a page.
+type PageRequest struct {
+	PageID   int32 `query:"page_id" validate:"required_with=PageSize,omitempty,min=1"`
+	PageSize int32 `query:"page_size" validate:"required_with=PageID,omitempty,min=5,max=100"`
+}
+
+func (page PageRequest) limit() int32 {
+	if page.PageSize == 0 {
+		return math.MaxInt32
+	}
+	return page.PageSize
+}
+
+func (page PageRequest) offset() int32 {
+	if page.PageID == 0 {
+		return 0
+	}
+	return (page.PageID - 1) * page.PageSize
+}
+
+// exportFormat is the spreadsheet format a list is asked for in, by ?format=
+// or else by the Accept header. It is empty for JSON.
+func exportFormat(ctx *fiber.Ctx) (string, error) {
+	switch format := ctx.Query("format"); format {
+	case "json":
+		return "", nil
+	case export.FormatCSV, export.FormatXLSX:
+		return format, nil
+	case "":
+	default:
+		return "", fiber.NewError(fiber.StatusBadRequest, export.ErrUnknownFormat.Error())
+	}
+
+	xlsx := export.ContentType(export.FormatXLSX)
+	switch ctx.Accepts(fiber.MIMEApplicationJSON, "text/csv", xlsx) {
+	case "text/csv":
+		return export.FormatCSV, nil
+	case xlsx:
+		return export.FormatXLSX, nil
+	}
+	return "", nil
+}
+
+// missingPage is the validation error of a list sent as JSON without a page
+var missingPage = []util.ErrorResponse{{Error: true, FailedField: "PageID", Tag: "required"}}
+
+// sendList sends the result of a list query as JSON, or streams its rows into
+// a spreadsheet when one is asked for. list and rows run the same query, rows
+// handing it back unread. A list sent as JSON must be paged when it has a
+// page.
+func (server *Server) sendList(ctx *fiber.Ctx, name string, page *PageRequest, list func(db database.DBTX) (any, error), rows func(db database.DBTX) (pgx.Rows, error)) error {
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	if format == "" {
+		if page != nil && page.PageID == 0 {
+			return badRequest(ctx, missingPage)
+		}
+
+		result, err := list(server.pool)
+		if err != nil {
+			return storeError(err)
+		}
+		return ctx.JSON(result)
+	}
+
+	// the query is run before anything is sent, so its failure still gets an
+	// error status
+	result, err := rows(server.pool)
+	if err != nil {
+		return storeError(err)
+	}
+
+	server.sendExport(ctx, name, format, func(writer export.Writer) error {
+		return export.WriteRows(writer, result)
+	}, result.Close)
+	return nil
+}
+
+// sendItems sends a list worked out in memory as JSON, or as a spreadsheet
+// when one is asked for
+func (server *Server) sendItems(ctx *fiber.Ctx, name string, items any) error {
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	if format == "" {
+		return ctx.JSON(items)
+	}
+
+	server.sendExport(ctx, name, format, func(writer export.Writer) error {
+		return export.WriteSlice(writer, items)
+	}, func() {})
+	return nil
+}
+
+// sendExport streams an export into the response as it is written, after the
+// handler has returned, and then calls done. The status is sent with the
+// first row, so a failure part way through is logged and cuts the file short.
+func (server *Server) sendExport(ctx *fiber.Ctx, name string, format string, write func(writer export.Writer) error, done func()) {
+	lang := ctx.Query("lang")
+	if lang == "" {
+		lang = ctx.AcceptsLanguages(export.Languages...)
+	}
+	locale := export.NewLocale(lang, server.timeZone)
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().In(server.timeZone).Format("20060102"), format)
+	ctx.Attachment(filename)
+	ctx.Set(fiber.HeaderContentType, export.ContentType(format))
+
+	// ctx is released once the handler returns, the stream uses none of it
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer done()
+
+		writer, err := export.NewWriter(format, w, locale)
+		if err == nil {
+			err = write(writer)
+			if closeErr := writer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			log.Printf("cannot export %s: %v", filename, err)
+		}
+	})
+}
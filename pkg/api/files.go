@@ -0,0 +1,244 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// proofPath is where the files of a stop's proof of delivery are served from
+const proofPath = "/api/v1/deliveries/%d/stops/%d/proof/"
+
+// imageExtensions are the image types accepted as uploads
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type (
+	RecordDeliveryProofRequest struct {
+		RecipientName string   `form:"recipient_name" validate:"required"`
+		Latitude      *float64 `form:"latitude" validate:"required,min=-90,max=90"`
+		Longitude     *float64 `form:"longitude" validate:"required,min=-180,max=180"`
+	}
+
+	DeliveryProofResponse struct {
+		Stop         database.DeliveryStop `json:"stop"`
+		SignatureURL string                `json:"signature_url"`
+		PhotoURL     string                `json:"photo_url"`
+	}
+)
+
+// uploadImage checks the size and the actual content of an uploaded image
+// and puts it in storage under prefix, it returns the key of the stored file
+func (server *Server) uploadImage(ctx *fiber.Ctx, field string, prefix string) (string, error) {
+	header, err := ctx.FormFile(field)
+	if err != nil {
+		return "", fiber.NewError(fiber.StatusBadRequest, field+" is required")
+	}
+
+	if header.Size > server.config.UploadMaxBytes {
+		return "", fiber.NewError(fiber.StatusRequestEntityTooLarge,
+			fmt.Sprintf("%s exceeds %d bytes", field, server.config.UploadMaxBytes))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return "", fiber.ErrUnprocessableEntity
+	}
+	defer file.Close()
+
+	// the declared content type is not trusted, the file is sniffed instead
+	extension, err := sniffImage(file)
+	if err != nil {
+		return "", fiber.NewError(fiber.StatusBadRequest, field+" must be a jpeg or png image")
+	}
+
+	key := fmt.Sprintf("%s/%s-%s%s", prefix, field, uuid.NewString(), extension)
+	if err := server.storage.Put(ctx.Context(), key, file); err != nil {
+		return "", storeError(err)
+	}
+
+	return key, nil
+}
+
+// sniffImage returns the extension of a jpeg or png file and rewinds it
+func sniffImage(file multipart.File) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	extension, ok := imageExtensions[http.DetectContentType(head[:n])]
+	if !ok {
+		return "", fmt.Errorf("unsupported file type")
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	return extension, err
+}
+
+// recordDeliveryProof takes the recipient's name, signature, a photo and the
+// position of the driver for a completed stop, from those who may see the
+// delivery order
+func (server *Server) recordDeliveryProof(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	stopID, err := ctx.ParamsInt("stop_id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	order, err := server.store.GetDeliveryOrder(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	// checked before anything is uploaded
+	allowed, err := server.canSeeDelivery(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fiber.ErrForbidden
+	}
+
+	var request RecordDeliveryProofRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	prefix := fmt.Sprintf("deliveries/%d/stops/%d", order.ID, stopID)
+	signatureKey, err := server.uploadImage(ctx, "signature", prefix)
+	if err != nil {
+		return err
+	}
+
+	photoKey, err := server.uploadImage(ctx, "photo", prefix)
+	if err != nil {
+		server.storage.Delete(ctx.Context(), signatureKey)
+		return err
+	}
+
+	stop, err := server.store.RecordDeliveryProofTx(ctx.Context(), server.pool, database.RecordDeliveryProofTxParams{
+		DeliveryOrderID: order.ID,
+		StopID:          int64(stopID),
+		RecipientName:   request.RecipientName,
+		SignatureKey:    signatureKey,
+		PhotoKey:        photoKey,
+		Latitude:        *request.Latitude,
+		Longitude:       *request.Longitude,
+		CapturedBy:      authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		// the files are only kept when they are linked to the stop
+		server.storage.Delete(ctx.Context(), signatureKey)
+		server.storage.Delete(ctx.Context(), photoKey)
+		return storeError(err)
+	}
+
+	urlPath := fmt.Sprintf(proofPath, id, stopID)
+	return ctx.Status(fiber.StatusCreated).JSON(DeliveryProofResponse{
+		Stop:         stop,
+		SignatureURL: urlPath + "signature",
+		PhotoURL:     urlPath + "photo",
+	})
+}
+
+// canSeeDelivery tells whether the authenticated user is an admin, planned
+// the delivery order or drives it
+func (server *Server) canSeeDelivery(ctx *fiber.Ctx, order database.DeliveryOrder) (bool, error) {
+	issuer := authorizationPayload(ctx).Issuer
+	if order.CreatedBy == issuer {
+		return true, nil
+	}
+
+	admin, err := server.isAdmin(ctx)
+	if err != nil || admin {
+		return admin, err
+	}
+
+	driver, err := server.store.GetDriver(ctx.Context(), server.pool, order.DriverID)
+	if err != nil {
+		return false, storeError(err)
+	}
+
+	user, err := server.store.GetUserByID(ctx.Context(), server.pool, driver.UserID)
+	if err != nil {
+		return false, storeError(err)
+	}
+
+	return user.Email == issuer, nil
+}
+
+// getDeliveryProofFile serves the signature or the photo of a stop's proof of
+// delivery to those who may see the delivery order
+func (server *Server) getDeliveryProofFile(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	stopID, err := ctx.ParamsInt("stop_id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	order, err := server.store.GetDeliveryOrder(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	allowed, err := server.canSeeDelivery(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return fiber.ErrForbidden
+	}
+
+	stop, err := server.store.GetDeliveryStop(ctx.Context(), server.pool, database.GetDeliveryStopParams{
+		ID:              int64(stopID),
+		DeliveryOrderID: order.ID,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	var key string
+	switch ctx.Params("file") {
+	case "signature":
+		key = stop.SignatureKey.String
+	case "photo":
+		key = stop.PhotoKey.String
+	}
+
+	if key == "" {
+		return fiber.ErrNotFound
+	}
+
+	file, err := server.storage.Get(ctx.Context(), key)
+	if err != nil {
+		return storeError(err)
+	}
+
+	ctx.Set(fiber.HeaderContentType, mime.TypeByExtension(path.Ext(key)))
+	return ctx.SendStream(file)
+}
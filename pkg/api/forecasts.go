@@ -0,0 +1,140 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/forecast"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	GetDemandForecastRequest struct {
+		LocationID  int32 `query:"location_id" validate:"required"`
+		ProductID   int32 `query:"product_id" validate:"required"`
+		HorizonDays int   `query:"horizon_days" validate:"omitempty,min=1,max=366"`
+		// Algorithm defaults to the configured one
+		Algorithm string `query:"algorithm" validate:"omitempty,oneof=holt_winters exponential_smoothing"`
+	}
+
+	DemandForecastResponse struct {
+		Algorithm string `json:"algorithm"`
+		database.DemandForecast
+	}
+
+	HolidayRequest struct {
+		Name             string  `json:"name" validate:"required"`
+		StartsOn         string  `json:"starts_on" validate:"required,datetime=2006-01-02"`
+		EndsOn           string  `json:"ends_on" validate:"required,datetime=2006-01-02"`
+		DemandMultiplier float64 `json:"demand_multiplier" validate:"required,gt=0"`
+	}
+
+	ListHolidaysRequest struct {
+		From string `query:"from" validate:"required,datetime=2006-01-02"`
+		To   string `query:"to" validate:"required,datetime=2006-01-02"`
+	}
+)
+
+// getDemandForecast forecasts the daily and weekly demand of a product at a
+// location, four weeks ahead unless asked otherwise
+func (server *Server) getDemandForecast(ctx *fiber.Ctx) error {
+	var request GetDemandForecastRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	if request.HorizonDays == 0 {
+		request.HorizonDays = 28
+	}
+
+	response := DemandForecastResponse{Algorithm: server.config.ForecastAlgorithm}
+	forecaster := server.forecaster
+	if request.Algorithm != "" {
+		var err error
+		forecaster, err = forecast.New(request.Algorithm)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		response.Algorithm = request.Algorithm
+	}
+
+	var err error
+	response.DemandForecast, err = server.store.ForecastDemand(ctx.Context(), server.pool, database.ForecastDemandParams{
+		LocationID:  request.LocationID,
+		ProductID:   request.ProductID,
+		Forecaster:  forecaster,
+		HistoryDays: server.config.ForecastHistoryDays,
+		HorizonDays: request.HorizonDays,
+		Today:       time.Now(),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) createHoliday(ctx *fiber.Ctx) error {
+	var request HolidayRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	holiday, err := server.store.CreateHoliday(ctx.Context(), server.pool, database.CreateHolidayParams{
+		Name:             request.Name,
+		StartsOn:         optionalDate(request.StartsOn),
+		EndsOn:           optionalDate(request.EndsOn),
+		DemandMultiplier: request.DemandMultiplier,
+		CreatedBy:        authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(holiday)
+}
+
+// listHolidays returns the holidays touching the days from from to to
+func (server *Server) listHolidays(ctx *fiber.Ctx) error {
+	var request ListHolidaysRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListHolidaysParams{
+		FromDate: optionalDate(request.From),
+		ToDate:   optionalDate(request.To),
+	}
+
+	return server.sendList(ctx, "holidays", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListHolidays(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListHolidaysRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) deleteHoliday(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if err := server.store.DeleteHoliday(ctx.Context(), server.pool, int32(id)); err != nil {
+		return storeError(err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	// CreateImportRequest comes as a multipart form along with the CSV file.
+	// A dry run only reports the errors the file would have.
+	CreateImportRequest struct {
+		Kind       string `form:"kind" validate:"required,oneof=products customers opening_stock"`
+		DryRun     bool   `form:"dry_run"`
+		LocationID int32  `form:"location_id" validate:"required_if=Kind opening_stock"`
+	}
+
+	ListImportsRequest struct {
+		Kind   string `query:"kind"`
+		Status string `query:"status"`
+		PageRequest
+	}
+
+	ImportJobResponse struct {
+		database.ImportJob
+		Errors []database.ImportJobError `json:"errors"`
+	}
+)
+
+// uploadCSV checks the size of an uploaded file and that it is text, and
+// puts it in storage under prefix. It returns the key of the stored file and
+// the name it was uploaded under.
+func (server *Server) uploadCSV(ctx *fiber.Ctx, field string, prefix string) (string, string, error) {
+	header, err := ctx.FormFile(field)
+	if err != nil {
+		return "", "", fiber.NewError(fiber.StatusBadRequest, field+" is required")
+	}
+
+	if header.Size > server.config.UploadMaxBytes {
+		return "", "", fiber.NewError(fiber.StatusRequestEntityTooLarge,
+			fmt.Sprintf("%s exceeds %d bytes", field, server.config.UploadMaxBytes))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return "", "", fiber.ErrUnprocessableEntity
+	}
+	defer file.Close()
+
+	if err := sniffText(file); err != nil {
+		return "", "", fiber.NewError(fiber.StatusBadRequest, field+" must be a csv file")
+	}
+
+	key := fmt.Sprintf("%s/%s.csv", prefix, uuid.NewString())
+	if err := server.storage.Put(ctx.Context(), key, file); err != nil {
+		return "", "", storeError(err)
+	}
+
+	return key, header.Filename, nil
+}
+
+// sniffText makes sure a file is plain text and rewinds it
+func sniffText(file multipart.File) error {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return err
+	}
+
+	if !strings.HasPrefix(http.DetectContentType(head[:n]), "text/plain") {
+		return fmt.Errorf("unsupported file type")
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	return err
+}
+
+// createImport stores an uploaded CSV file and queues it for the worker,
+// the job is polled for its outcome
+func (server *Server) createImport(ctx *fiber.Ctx) error {
+	var request CreateImportRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	key, name, err := server.uploadCSV(ctx, "file", "imports")
+	if err != nil {
+		return err
+	}
+
+	job, err := server.store.CreateImportJob(ctx.Context(), server.pool, database.CreateImportJobParams{
+		Kind:       request.Kind,
+		DryRun:     request.DryRun,
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		FileKey:    key,
+		FileName:   name,
+		CreatedBy:  authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		server.storage.Delete(ctx.Context(), key)
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(ImportJobResponse{
+		ImportJob: job,
+		Errors:    []database.ImportJobError{},
+	})
+}
+
+func (server *Server) listImports(ctx *fiber.Ctx) error {
+	var request ListImportsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListImportJobsParams{
+		Kind:       optionalText(request.Kind),
+		Status:     optionalText(request.Status),
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "imports", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListImportJobs(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListImportJobsRows(ctx.Context(), db, arg)
+	})
+}
+
+// getImport returns an import job with the errors found in its file
+func (server *Server) getImport(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response ImportJobResponse
+	response.ImportJob, err = server.store.GetImportJob(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Errors, err = server.store.ListImportJobErrors(ctx.Context(), server.pool, response.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"fmt"
+	"mime"
+	"path"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// incidentPhotoPath is where the photos of an incident are served from
+const incidentPhotoPath = "/api/v1/incidents/%d/photos/"
+
+type (
+	ReportIncidentRequest struct {
+		IncidentType string `json:"incident_type" validate:"required,oneof=damaged leaking"`
+		LocationID   int32  `json:"location_id" validate:"required"`
+		// a serialized cylinder is identified by its id, anything else by its
+		// product and whether it was full or empty
+		CylinderID    int64  `json:"cylinder_id"`
+		ProductID     int32  `json:"product_id" validate:"required_without=CylinderID"`
+		CylinderState string `json:"cylinder_state" validate:"required_without=CylinderID,omitempty,oneof=full empty"`
+		Reason        string `json:"reason" validate:"required"`
+	}
+
+	ListIncidentsRequest struct {
+		From         string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+		To           string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+		Status       string `query:"status" validate:"omitempty,oneof=quarantined repaired returned_to_supplier scrapped"`
+		IncidentType string `query:"incident_type" validate:"omitempty,oneof=damaged leaking"`
+		LocationID   int32  `query:"location_id"`
+		PageRequest
+	}
+
+	DisposeIncidentRequest struct {
+		Disposition string `json:"disposition" validate:"required,oneof=repair return_to_supplier scrap"`
+		Note        string `json:"note"`
+	}
+
+	IncidentResponse struct {
+		Incident database.Incident        `json:"incident"`
+		Photos   []database.IncidentPhoto `json:"photos"`
+	}
+)
+
+func (server *Server) reportIncident(ctx *fiber.Ctx) error {
+	var request ReportIncidentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.ReportIncidentTx(ctx.Context(), server.pool, database.ReportIncidentTxParams{
+		IncidentType:  request.IncidentType,
+		LocationID:    request.LocationID,
+		ProductID:     request.ProductID,
+		CylinderID:    pgtype.Int8{Int64: request.CylinderID, Valid: request.CylinderID != 0},
+		CylinderState: request.CylinderState,
+		Reason:        request.Reason,
+		ReportedBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+// listIncidents is the safety report of incidents reported in a period
+func (server *Server) listIncidents(ctx *fiber.Ctx) error {
+	var request ListIncidentsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	from, to := dateRange(request.From, request.To)
+	arg := database.ListIncidentsParams{
+		FromTime:     from,
+		ToTime:       to,
+		Status:       optionalText(request.Status),
+		IncidentType: optionalText(request.IncidentType),
+		LocationID:   pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		PageSize:     request.limit(),
+		PageOffset:   request.offset(),
+	}
+
+	return server.sendList(ctx, "incidents", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListIncidents(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListIncidentsRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getIncident(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response IncidentResponse
+	response.Incident, err = server.store.GetIncident(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Photos, err = server.store.ListIncidentPhotos(ctx.Context(), server.pool, response.Incident.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) disposeIncident(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request DisposeIncidentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.DisposeIncidentTx(ctx.Context(), server.pool, database.DisposeIncidentTxParams{
+		IncidentID:  int64(id),
+		Disposition: request.Disposition,
+		Note:        request.Note,
+		DisposedBy:  authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+// uploadIncidentPhoto stores a photo of the damaged or leaking cylinder and
+// links it to the incident
+func (server *Server) uploadIncidentPhoto(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	incident, err := server.store.GetIncident(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	key, err := server.uploadImage(ctx, "photo", fmt.Sprintf("incidents/%d", incident.ID))
+	if err != nil {
+		return err
+	}
+
+	photo, err := server.store.CreateIncidentPhoto(ctx.Context(), server.pool, database.CreateIncidentPhotoParams{
+		IncidentID: incident.ID,
+		Url:        fmt.Sprintf(incidentPhotoPath, incident.ID) + path.Base(key),
+		StorageKey: pgtype.Text{String: key, Valid: true},
+	})
+	if err != nil {
+		// the file is only kept when it is linked to the incident
+		server.storage.Delete(ctx.Context(), key)
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(photo)
+}
+
+// getIncidentPhoto serves an uploaded photo of an incident
+func (server *Server) getIncidentPhoto(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	photo, err := server.store.GetIncidentPhotoByKey(ctx.Context(), server.pool, database.GetIncidentPhotoByKeyParams{
+		IncidentID: int64(id),
+		StorageKey: pgtype.Text{String: fmt.Sprintf("incidents/%d/%s", id, path.Base(ctx.Params("file"))), Valid: true},
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	file, err := server.storage.Get(ctx.Context(), photo.StorageKey.String)
+	if err != nil {
+		return storeError(err)
+	}
+
+	ctx.Set(fiber.HeaderContentType, mime.TypeByExtension(path.Ext(photo.StorageKey.String)))
+	return ctx.SendStream(file)
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	CreateProductRequest struct {
+		Code           string `json:"code" validate:"required"`
+		Name           string `json:"name" validate:"required"`
+		NetWeightGrams int32  `json:"net_weight_grams" validate:"required,gt=0"`
+		IsSubsidized   bool   `json:"is_subsidized"`
+		Price          int64  `json:"price" validate:"min=0"`
+		DepositAmount  int64  `json:"deposit_amount" validate:"min=0"`
+	}
+
+	UpdateProductRequest struct {
+		Name          string `json:"name" validate:"required"`
+		IsSubsidized  bool   `json:"is_subsidized"`
+		Price         int64  `json:"price" validate:"min=0"`
+		DepositAmount int64  `json:"deposit_amount" validate:"min=0"`
+	}
+
+	CreateLocationRequest struct {
+		Code         string `json:"code" validate:"required"`
+		Name         string `json:"name" validate:"required"`
+		LocationType string `json:"location_type" validate:"required,oneof=depot outlet vehicle"`
+		Region       string `json:"region"`
+		// Latitude and Longitude are decimal degrees, both or neither are given
+		Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+		Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	}
+
+	UpdateLocationRequest struct {
+		Name   string `json:"name" validate:"required"`
+		Region string `json:"region"`
+		// Latitude and Longitude are decimal degrees, both or neither are given
+		Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+		Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	}
+
+	ListStockRequest struct {
+		LocationID int32 `query:"location_id"`
+	}
+
+	ListStockReservationsRequest struct {
+		LocationID    int32  `query:"location_id"`
+		Status        string `query:"status" validate:"omitempty,oneof=active released expired fulfilled"`
+		ReferenceType string `query:"reference_type" validate:"required_with=ReferenceID"`
+		ReferenceID   int64  `query:"reference_id" validate:"required_with=ReferenceType"`
+		PageRequest
+	}
+
+	ListStockMovementsRequest struct {
+		LocationID int32 `query:"location_id" validate:"required"`
+		// From and To are inclusive and optional, an export of a year of the
+		// ledger is not paged
+		From string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+		To   string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+		PageRequest
+	}
+)
+
+func (server *Server) createProduct(ctx *fiber.Ctx) error {
+	var request CreateProductRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	product, err := server.store.CreateProduct(ctx.Context(), server.pool, database.CreateProductParams{
+		Code:           request.Code,
+		Name:           request.Name,
+		NetWeightGrams: request.NetWeightGrams,
+		IsSubsidized:   request.IsSubsidized,
+		Price:          request.Price,
+		DepositAmount:  request.DepositAmount,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(product)
+}
+
+func (server *Server) updateProduct(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request UpdateProductRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	product, err := server.store.UpdateProduct(ctx.Context(), server.pool, database.UpdateProductParams{
+		ID:            int32(id),
+		Name:          request.Name,
+		IsSubsidized:  request.IsSubsidized,
+		Price:         request.Price,
+		DepositAmount: request.DepositAmount,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(product)
+}
+
+func (server *Server) listProducts(ctx *fiber.Ctx) error {
+	return server.sendList(ctx, "products", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListProducts(ctx.Context(), db)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListProductsRows(ctx.Context(), db)
+	})
+}
+
+func (server *Server) createLocation(ctx *fiber.Ctx) error {
+	var request CreateLocationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	location, err := server.store.CreateLocation(ctx.Context(), server.pool, database.CreateLocationParams{
+		Code:         request.Code,
+		Name:         request.Name,
+		LocationType: request.LocationType,
+		Region:       optionalText(request.Region),
+		Latitude:     optionalFloat(request.Latitude),
+		Longitude:    optionalFloat(request.Longitude),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(location)
+}
+
+func (server *Server) updateLocation(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request UpdateLocationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	location, err := server.store.UpdateLocation(ctx.Context(), server.pool, database.UpdateLocationParams{
+		ID:        int32(id),
+		Name:      request.Name,
+		Region:    optionalText(request.Region),
+		Latitude:  optionalFloat(request.Latitude),
+		Longitude: optionalFloat(request.Longitude),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(location)
+}
+
+func (server *Server) listLocations(ctx *fiber.Ctx) error {
+	return server.sendList(ctx, "locations", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListLocations(ctx.Context(), db)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListLocationsRows(ctx.Context(), db)
+	})
+}
+
+func (server *Server) listStock(ctx *fiber.Ctx) error {
+	var request ListStockRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	arg := pgtype.Int4{
+		Int32: request.LocationID,
+		Valid: request.LocationID != 0,
+	}
+
+	return server.sendList(ctx, "stock", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListStockBalances(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListStockBalancesRows(ctx.Context(), db, arg)
+	})
+}
+
+// dayStart is the start of the day days after a date, null for no date
+func dayStart(date string, days int) pgtype.Timestamptz {
+	day, err := time.ParseInLocation(time.DateOnly, date, time.Local)
+	return pgtype.Timestamptz{Time: day.AddDate(0, 0, days), Valid: err == nil}
+}
+
+func (server *Server) listStockMovements(ctx *fiber.Ctx) error {
+	var request ListStockMovementsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListStockMovementsParams{
+		LocationID: request.LocationID,
+		FromTime:   dayStart(request.From, 0),
+		ToTime:     dayStart(request.To, 1),
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "stock-movements", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListStockMovements(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListStockMovementsRows(ctx.Context(), db, arg)
+	})
+}
+
+// listStockReservations returns the stock held back for orders, optionally
+// only the reservations of one order
+func (server *Server) listStockReservations(ctx *fiber.Ctx) error {
+	var request ListStockReservationsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListStockReservationsParams{
+		LocationID:    pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		Status:        optionalText(request.Status),
+		ReferenceType: optionalText(request.ReferenceType),
+		ReferenceID:   pgtype.Int8{Int64: request.ReferenceID, Valid: request.ReferenceID != 0},
+		PageSize:      request.limit(),
+		PageOffset:    request.offset(),
+	}
+
+	return server.sendList(ctx, "stock-reservations", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListStockReservations(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListStockReservationsRows(ctx.Context(), db, arg)
+	})
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	ListInvoicesRequest struct {
+		CustomerID int32  `query:"customer_id"`
+		Status     string `query:"status" validate:"omitempty,oneof=open partially_paid paid voided"`
+		PageRequest
+	}
+
+	InvoicePaymentRequest struct {
+		Amount        int64  `json:"amount" validate:"required,gt=0"`
+		PaymentMethod string `json:"payment_method" validate:"required,oneof=cash transfer qris"`
+		Reference     string `json:"reference"`
+	}
+
+	ReceivablesAgingRequest struct {
+		AsOf string `query:"as_of" validate:"omitempty,datetime=2006-01-02"`
+	}
+)
+
+func (server *Server) listInvoices(ctx *fiber.Ctx) error {
+	var request ListInvoicesRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListInvoicesParams{
+		CustomerID: pgtype.Int4{Int32: request.CustomerID, Valid: request.CustomerID != 0},
+		Status:     optionalText(request.Status),
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "invoices", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListInvoices(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListInvoicesRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getInvoice(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response database.InvoiceTxResult
+	response.Invoice, err = server.store.GetInvoice(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Payments, err = server.store.ListInvoicePayments(ctx.Context(), server.pool, response.Invoice.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) payInvoice(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request InvoicePaymentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.RecordInvoicePaymentTx(ctx.Context(), server.pool, database.RecordInvoicePaymentTxParams{
+		InvoiceID:     int64(id),
+		Amount:        request.Amount,
+		PaymentMethod: request.PaymentMethod,
+		Reference:     request.Reference,
+		ReceivedBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+// receivablesAging buckets every customer's unpaid invoices by how many days
+// they are past due, invoices not yet due count in the first bucket
+func (server *Server) receivablesAging(ctx *fiber.Ctx) error {
+	var request ReceivablesAgingRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	asOf := time.Now()
+	if request.AsOf != "" {
+		asOf, _ = time.Parse(time.DateOnly, request.AsOf)
+	}
+
+	arg := pgtype.Date{
+		Time:  time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC),
+		Valid: true,
+	}
+
+	return server.sendList(ctx, "receivables-aging", nil, func(db database.DBTX) (any, error) {
+		return server.store.GetReceivablesAging(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.GetReceivablesAgingRows(ctx.Context(), db, arg)
+	})
+}
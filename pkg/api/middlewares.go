@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/token"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -50,3 +52,34 @@ func (server *Server) tokenMiddleware() fiber.Handler {
 		return ctx.Next()
 	}
 }
+
+// authorizationPayload returns the payload stored by tokenMiddleware
+func authorizationPayload(ctx *fiber.Ctx) *token.Payload {
+	return ctx.Locals(AuthorizationPayloadKey).(*token.Payload)
+}
+
+// isAdmin tells whether the authenticated user has the admin role
+func (server *Server) isAdmin(ctx *fiber.Ctx) (bool, error) {
+	user, err := server.store.GetUser(ctx.Context(), server.pool, authorizationPayload(ctx).Issuer)
+	if err != nil {
+		return false, storeError(err)
+	}
+
+	return user.Role == database.UserRoleAdmin, nil
+}
+
+// adminMiddleware only lets admins through, it must run after tokenMiddleware
+func (server *Server) adminMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		admin, err := server.isAdmin(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !admin {
+			return fiber.ErrForbidden
+		}
+
+		return ctx.Next()
+	}
+}
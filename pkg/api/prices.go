@@ -0,0 +1,214 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	CreatePriceListRequest struct {
+		ProductID     int32      `json:"product_id" validate:"required"`
+		CustomerType  string     `json:"customer_type" validate:"omitempty,oneof=household micro_business restaurant sub_agent"`
+		LocationID    int32      `json:"location_id"`
+		Price         int64      `json:"price" validate:"min=0"`
+		EffectiveFrom *time.Time `json:"effective_from"`
+		EffectiveTo   *time.Time `json:"effective_to"`
+	}
+
+	ListPriceListsRequest struct {
+		ProductID int32 `query:"product_id" validate:"required"`
+	}
+
+	ResolvePriceRequest struct {
+		ProductID    int32  `query:"product_id" validate:"required"`
+		LocationID   int32  `query:"location_id" validate:"required"`
+		CustomerType string `query:"customer_type"`
+		CustomerID   int32  `query:"customer_id"`
+	}
+
+	CreateCeilingPriceRequest struct {
+		Region        string     `json:"region" validate:"required"`
+		ProductID     int32      `json:"product_id" validate:"required"`
+		MaxPrice      int64      `json:"max_price" validate:"min=0"`
+		EffectiveFrom *time.Time `json:"effective_from"`
+		EffectiveTo   *time.Time `json:"effective_to"`
+	}
+
+	ListCeilingPricesRequest struct {
+		Region string `query:"region"`
+	}
+
+	ListPriceOverridesRequest struct {
+		From string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+		To   string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	}
+)
+
+// effectivePeriod defaults the start of a price to now and rejects a period
+// ending before it starts
+func effectivePeriod(from *time.Time, to *time.Time) (time.Time, pgtype.Timestamptz, error) {
+	start := time.Now()
+	if from != nil {
+		start = *from
+	}
+
+	if to == nil {
+		return start, pgtype.Timestamptz{}, nil
+	}
+
+	if !to.After(start) {
+		return start, pgtype.Timestamptz{}, fiber.NewError(fiber.StatusBadRequest, "effective_to must be after effective_from")
+	}
+
+	return start, pgtype.Timestamptz{Time: *to, Valid: true}, nil
+}
+
+func (server *Server) createPriceList(ctx *fiber.Ctx) error {
+	var request CreatePriceListRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	from, to, err := effectivePeriod(request.EffectiveFrom, request.EffectiveTo)
+	if err != nil {
+		return err
+	}
+
+	priceList, err := server.store.CreatePriceListTx(ctx.Context(), server.pool, database.CreatePriceListTxParams{
+		ProductID:     request.ProductID,
+		CustomerType:  optionalText(request.CustomerType),
+		LocationID:    pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		Price:         request.Price,
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		CreatedBy:     authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(priceList)
+}
+
+func (server *Server) listPriceLists(ctx *fiber.Ctx) error {
+	var request ListPriceListsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	return server.sendList(ctx, "price-lists", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListPriceLists(ctx.Context(), db, request.ProductID)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListPriceListsRows(ctx.Context(), db, request.ProductID)
+	})
+}
+
+func (server *Server) resolvePrice(ctx *fiber.Ctx) error {
+	var request ResolvePriceRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	customerType := optionalText(request.CustomerType)
+	if request.CustomerID != 0 {
+		customer, err := server.store.GetCustomer(ctx.Context(), server.pool, request.CustomerID)
+		if err != nil {
+			return storeError(err)
+		}
+		customerType = pgtype.Text{String: customer.CustomerType, Valid: true}
+	}
+
+	price, err := server.store.ResolvePrice(ctx.Context(), server.pool, database.ResolvePriceParams{
+		ProductID:    request.ProductID,
+		LocationID:   request.LocationID,
+		CustomerType: customerType,
+		At:           time.Now(),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(price)
+}
+
+func (server *Server) createCeilingPrice(ctx *fiber.Ctx) error {
+	var request CreateCeilingPriceRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	from, to, err := effectivePeriod(request.EffectiveFrom, request.EffectiveTo)
+	if err != nil {
+		return err
+	}
+
+	ceilingPrice, err := server.store.CreateCeilingPriceTx(ctx.Context(), server.pool, database.CreateCeilingPriceTxParams{
+		Region:        request.Region,
+		ProductID:     request.ProductID,
+		MaxPrice:      request.MaxPrice,
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		CreatedBy:     authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(ceilingPrice)
+}
+
+func (server *Server) listCeilingPrices(ctx *fiber.Ctx) error {
+	var request ListCeilingPricesRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	return server.sendList(ctx, "ceiling-prices", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListCeilingPrices(ctx.Context(), db, optionalText(request.Region))
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListCeilingPricesRows(ctx.Context(), db, optionalText(request.Region))
+	})
+}
+
+func (server *Server) listPriceOverrides(ctx *fiber.Ctx) error {
+	var request ListPriceOverridesRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	from, to := dateRange(request.From, request.To)
+	arg := database.ListPriceOverridesParams{
+		FromTime: from,
+		ToTime:   to,
+	}
+
+	return server.sendList(ctx, "price-overrides", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListPriceOverrides(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListPriceOverridesRows(ctx.Context(), db, arg)
+	})
+}
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	CreateSupplierRequest struct {
+		Code    string `json:"code" validate:"required"`
+		Name    string `json:"name" validate:"required"`
+		Phone   string `json:"phone"`
+		Address string `json:"address"`
+	}
+
+	PurchaseOrderItemRequest struct {
+		ProductID  int32 `json:"product_id" validate:"required"`
+		OrderedQty int32 `json:"ordered_qty" validate:"required,gt=0"`
+		UnitPrice  int64 `json:"unit_price" validate:"min=0"`
+	}
+
+	CreatePurchaseOrderRequest struct {
+		SupplierID   int32                      `json:"supplier_id" validate:"required"`
+		LocationID   int32                      `json:"location_id" validate:"required"`
+		ExpectedDate string                     `json:"expected_date" validate:"omitempty,datetime=2006-01-02"`
+		Note         string                     `json:"note"`
+		Items        []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	}
+
+	ListPurchaseOrdersRequest struct {
+		Status     string `query:"status" validate:"omitempty,oneof=draft ordered partially_received received closed"`
+		SupplierID int32  `query:"supplier_id"`
+		PageRequest
+	}
+
+	GoodsReceiptItemRequest struct {
+		ProductID       int32 `json:"product_id" validate:"required"`
+		ReceivedQty     int32 `json:"received_qty" validate:"min=0"`
+		EmptiesReturned int32 `json:"empties_returned" validate:"min=0"`
+	}
+
+	ReceivePurchaseOrderRequest struct {
+		Items []GoodsReceiptItemRequest `json:"items" validate:"required,min=1,dive"`
+		Note  string                    `json:"note"`
+	}
+
+	PurchaseOrderResponse struct {
+		PurchaseOrder database.PurchaseOrder       `json:"purchase_order"`
+		Items         []database.PurchaseOrderItem `json:"items"`
+		GoodsReceipts []database.GoodsReceipt      `json:"goods_receipts"`
+	}
+
+	GoodsReceiptResponse struct {
+		GoodsReceipt database.GoodsReceipt       `json:"goods_receipt"`
+		Items        []database.GoodsReceiptItem `json:"items"`
+	}
+)
+
+func (server *Server) createSupplier(ctx *fiber.Ctx) error {
+	var request CreateSupplierRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	supplier, err := server.store.CreateSupplier(ctx.Context(), server.pool, database.CreateSupplierParams{
+		Code:    request.Code,
+		Name:    request.Name,
+		Phone:   pgtype.Text{String: request.Phone, Valid: request.Phone != ""},
+		Address: pgtype.Text{String: request.Address, Valid: request.Address != ""},
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(supplier)
+}
+
+func (server *Server) listSuppliers(ctx *fiber.Ctx) error {
+	return server.sendList(ctx, "suppliers", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListSuppliers(ctx.Context(), db)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListSuppliersRows(ctx.Context(), db)
+	})
+}
+
+func (server *Server) createPurchaseOrder(ctx *fiber.Ctx) error {
+	var request CreatePurchaseOrderRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	var expectedDate pgtype.Date
+	if request.ExpectedDate != "" {
+		date, _ := time.Parse(time.DateOnly, request.ExpectedDate)
+		expectedDate = pgtype.Date{Time: date, Valid: true}
+	}
+
+	items := make([]database.PurchaseOrderItemParams, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, database.PurchaseOrderItemParams{
+			ProductID:  item.ProductID,
+			OrderedQty: item.OrderedQty,
+			UnitPrice:  item.UnitPrice,
+		})
+	}
+
+	result, err := server.store.CreatePurchaseOrderTx(ctx.Context(), server.pool, database.CreatePurchaseOrderTxParams{
+		SupplierID:   request.SupplierID,
+		LocationID:   request.LocationID,
+		ExpectedDate: expectedDate,
+		Note:         request.Note,
+		Items:        items,
+		CreatedBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listPurchaseOrders(ctx *fiber.Ctx) error {
+	var request ListPurchaseOrdersRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListPurchaseOrdersParams{
+		Status:     pgtype.Text{String: request.Status, Valid: request.Status != ""},
+		SupplierID: pgtype.Int4{Int32: request.SupplierID, Valid: request.SupplierID != 0},
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "purchase-orders", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListPurchaseOrders(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListPurchaseOrdersRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getPurchaseOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response PurchaseOrderResponse
+	response.PurchaseOrder, err = server.store.GetPurchaseOrder(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Items, err = server.store.ListPurchaseOrderItems(ctx.Context(), server.pool, response.PurchaseOrder.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.GoodsReceipts, err = server.store.ListGoodsReceipts(ctx.Context(), server.pool, response.PurchaseOrder.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) orderPurchaseOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	order, err := server.store.OrderPurchaseOrderTx(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(order)
+}
+
+func (server *Server) closePurchaseOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	order, err := server.store.ClosePurchaseOrderTx(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(order)
+}
+
+func (server *Server) receivePurchaseOrder(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request ReceivePurchaseOrderRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	items := make([]database.GoodsReceiptItemParams, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, database.GoodsReceiptItemParams{
+			ProductID:       item.ProductID,
+			ReceivedQty:     item.ReceivedQty,
+			EmptiesReturned: item.EmptiesReturned,
+		})
+	}
+
+	result, err := server.store.ReceivePurchaseOrderTx(ctx.Context(), server.pool, database.ReceivePurchaseOrderTxParams{
+		PurchaseOrderID: int64(id),
+		Items:           items,
+		Note:            request.Note,
+		ReceivedBy:      authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) getGoodsReceipt(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response GoodsReceiptResponse
+	response.GoodsReceipt, err = server.store.GetGoodsReceipt(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Items, err = server.store.ListGoodsReceiptItems(ctx.Context(), server.pool, response.GoodsReceipt.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
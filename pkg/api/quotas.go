@@ -0,0 +1,113 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	CreateQuotaRuleRequest struct {
+		ProductID    int32  `json:"product_id" validate:"required"`
+		CustomerType string `json:"customer_type" validate:"required,oneof=household micro_business restaurant sub_agent"`
+		Period       string `json:"period" validate:"required,oneof=daily weekly monthly"`
+		MaxQty       int32  `json:"max_qty" validate:"required,gt=0"`
+	}
+
+	UpdateQuotaRuleRequest struct {
+		MaxQty   int32 `json:"max_qty" validate:"required,gt=0"`
+		IsActive bool  `json:"is_active"`
+	}
+
+	ListQuotaUsagesRequest struct {
+		From       string `query:"from" validate:"required,datetime=2006-01-02"`
+		To         string `query:"to" validate:"required,datetime=2006-01-02"`
+		CustomerID int32  `query:"customer_id"`
+	}
+)
+
+func (server *Server) createQuotaRule(ctx *fiber.Ctx) error {
+	var request CreateQuotaRuleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	rule, err := server.store.CreateQuotaRule(ctx.Context(), server.pool, database.CreateQuotaRuleParams{
+		ProductID:    request.ProductID,
+		CustomerType: request.CustomerType,
+		Period:       request.Period,
+		MaxQty:       request.MaxQty,
+		CreatedBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(rule)
+}
+
+func (server *Server) listQuotaRules(ctx *fiber.Ctx) error {
+	return server.sendList(ctx, "quota-rules", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListQuotaRules(ctx.Context(), db)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListQuotaRulesRows(ctx.Context(), db)
+	})
+}
+
+func (server *Server) updateQuotaRule(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request UpdateQuotaRuleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	rule, err := server.store.UpdateQuotaRule(ctx.Context(), server.pool, database.UpdateQuotaRuleParams{
+		ID:       int32(id),
+		MaxQty:   request.MaxQty,
+		IsActive: request.IsActive,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(rule)
+}
+
+// listQuotaUsages returns the quota usage history between two dates, as JSON
+// or as a CSV file for regulatory reporting
+func (server *Server) listQuotaUsages(ctx *fiber.Ctx) error {
+	var request ListQuotaUsagesRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	from, to := dateRange(request.From, request.To)
+	arg := database.ListQuotaUsagesParams{
+		FromTime:   from,
+		ToTime:     to,
+		CustomerID: pgtype.Int4{Int32: request.CustomerID, Valid: request.CustomerID != 0},
+	}
+
+	return server.sendList(ctx, "quota-usages", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListQuotaUsages(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListQuotaUsagesRows(ctx.Context(), db, arg)
+	})
+}
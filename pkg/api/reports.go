@@ -0,0 +1,248 @@
+package api
+
+import (
+	"slices"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	// ReportRangeRequest selects the days a report covers, both inclusive,
+	// and how they are totalled
+	ReportRangeRequest struct {
+		From   string `query:"from" validate:"required,datetime=2006-01-02"`
+		To     string `query:"to" validate:"required,datetime=2006-01-02"`
+		Period string `query:"period" validate:"omitempty,oneof=day week month"`
+		// GroupBy splits each period by product, location or both, given as
+		// group_by=product&group_by=location
+		GroupBy    []string `query:"group_by" validate:"dive,oneof=product location"`
+		LocationID int32    `query:"location_id"`
+		ProductID  int32    `query:"product_id"`
+	}
+
+	MovementReportRequest struct {
+		ReportRangeRequest
+		Reason string `query:"reason"`
+	}
+
+	StockValuationRequest struct {
+		Method     string `query:"method" validate:"omitempty,oneof=fifo average"`
+		LocationID int32  `query:"location_id"`
+	}
+
+	TopCustomersRequest struct {
+		From       string `query:"from" validate:"required,datetime=2006-01-02"`
+		To         string `query:"to" validate:"required,datetime=2006-01-02"`
+		LocationID int32  `query:"location_id"`
+		Limit      int32  `query:"limit" validate:"omitempty,min=1,max=100"`
+	}
+
+	EmptiesOutstandingRequest struct {
+		ProductID int32 `query:"product_id"`
+		PageRequest
+	}
+
+	EmptiesOutstandingResponse struct {
+		Totals    []database.SumEmptiesOutstandingRow  `json:"totals"`
+		Customers []database.ListEmptiesOutstandingRow `json:"customers"`
+	}
+)
+
+func (request ReportRangeRequest) period() string {
+	if request.Period == "" {
+		return database.ReportPeriodDay
+	}
+	return request.Period
+}
+
+func (request ReportRangeRequest) groupedBy(dimension string) bool {
+	return slices.Contains(request.GroupBy, dimension)
+}
+
+// getSalesReport totals the completed sales by period, and by product and
+// location when grouped by them. Amounts are before tax.
+func (server *Server) getSalesReport(ctx *fiber.Ctx) error {
+	var request ReportRangeRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListSalesReportParams{
+		Period:         request.period(),
+		ByLocation:     request.groupedBy("location"),
+		ByProduct:      request.groupedBy("product"),
+		FromDate:       optionalDate(request.From),
+		ToDate:         optionalDate(request.To),
+		LocationFilter: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		ProductFilter:  pgtype.Int4{Int32: request.ProductID, Valid: request.ProductID != 0},
+	}
+
+	return server.sendList(ctx, "sales-report", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListSalesReport(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListSalesReportRows(ctx.Context(), db, arg)
+	})
+}
+
+// getMovementReport totals the stock movements in and out by period and
+// reason, and by product and location when grouped by them
+func (server *Server) getMovementReport(ctx *fiber.Ctx) error {
+	var request MovementReportRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListMovementReportParams{
+		Period:         request.period(),
+		ByLocation:     request.groupedBy("location"),
+		ByProduct:      request.groupedBy("product"),
+		FromDate:       optionalDate(request.From),
+		ToDate:         optionalDate(request.To),
+		LocationFilter: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		ProductFilter:  pgtype.Int4{Int32: request.ProductID, Valid: request.ProductID != 0},
+		ReasonFilter:   optionalText(request.Reason),
+	}
+
+	return server.sendList(ctx, "movement-report", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListMovementReport(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListMovementReportRows(ctx.Context(), db, arg)
+	})
+}
+
+// getStockValuation values the full cylinders on hand, FIFO unless the
+// weighted average cost is asked for
+func (server *Server) getStockValuation(ctx *fiber.Ctx) error {
+	var request StockValuationRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	if request.Method == "" {
+		request.Method = database.ValuationMethodFIFO
+	}
+
+	valuation, err := server.store.StockValuation(ctx.Context(), server.pool, database.StockValuationParams{
+		Method:     request.Method,
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	// an export holds the rows, the total is left to the spreadsheet
+	if format != "" {
+		return server.sendItems(ctx, "stock-valuation", valuation.Rows)
+	}
+	return ctx.JSON(valuation)
+}
+
+// getTopCustomers ranks the customers by what they bought, the ten biggest
+// unless asked otherwise
+func (server *Server) getTopCustomers(ctx *fiber.Ctx) error {
+	var request TopCustomersRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	if request.Limit == 0 {
+		request.Limit = 10
+	}
+
+	arg := database.ListTopCustomersParams{
+		FromDate:   optionalDate(request.From),
+		ToDate:     optionalDate(request.To),
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		RowLimit:   request.Limit,
+	}
+
+	return server.sendList(ctx, "top-customers", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListTopCustomers(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListTopCustomersRows(ctx.Context(), db, arg)
+	})
+}
+
+// getEmptiesOutstanding lists the empties customers still owe, largest
+// first, with the totals owed per product
+func (server *Server) getEmptiesOutstanding(ctx *fiber.Ctx) error {
+	var request EmptiesOutstandingRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	format, err := exportFormat(ctx)
+	if err != nil {
+		return err
+	}
+
+	arg := database.ListEmptiesOutstandingParams{
+		ProductID:  pgtype.Int4{Int32: request.ProductID, Valid: request.ProductID != 0},
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	// an export holds the customers, the totals are left to the spreadsheet
+	if format != "" {
+		return server.sendList(ctx, "empties-outstanding", &request.PageRequest, func(db database.DBTX) (any, error) {
+			return server.store.ListEmptiesOutstanding(ctx.Context(), db, arg)
+		}, func(db database.DBTX) (pgx.Rows, error) {
+			return server.store.ListEmptiesOutstandingRows(ctx.Context(), db, arg)
+		})
+	}
+
+	if request.PageID == 0 {
+		return badRequest(ctx, missingPage)
+	}
+
+	var response EmptiesOutstandingResponse
+	response.Totals, err = server.store.SumEmptiesOutstanding(ctx.Context(), server.pool)
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Customers, err = server.store.ListEmptiesOutstanding(ctx.Context(), server.pool, arg)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+// refreshReports brings the report aggregates up to date now instead of at
+// the worker's next run
+func (server *Server) refreshReports(ctx *fiber.Ctx) error {
+	if err := server.store.RefreshReportViews(ctx.Context(), server.pool); err != nil {
+		return storeError(err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
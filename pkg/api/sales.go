@@ -0,0 +1,205 @@
+package api
+
+import (
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	SaleItemRequest struct {
+		ProductID       int32    `json:"product_id" validate:"required"`
+		SaleType        string   `json:"sale_type" validate:"required,oneof=exchange new_cylinder"`
+		Quantity        int32    `json:"quantity" validate:"required,gt=0"`
+		EmptiesReturned int32    `json:"empties_returned" validate:"min=0"`
+		UnitPrice       *int64   `json:"unit_price" validate:"omitempty,min=0"`
+		DiscountAmount  int64    `json:"discount_amount" validate:"min=0"`
+		OverrideReason  string   `json:"override_reason"`
+		Serials         []string `json:"serials" validate:"dive,required"`
+		EmptySerials    []string `json:"empty_serials" validate:"dive,required"`
+	}
+
+	CreateSaleRequest struct {
+		LocationID    int32             `json:"location_id" validate:"required"`
+		CustomerID    int32             `json:"customer_id"`
+		PaymentMethod string            `json:"payment_method" validate:"required,oneof=cash transfer qris credit"`
+		Note          string            `json:"note"`
+		Items         []SaleItemRequest `json:"items" validate:"required,min=1,dive"`
+	}
+
+	ListSalesRequest struct {
+		From          string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+		To            string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+		LocationID    int32  `query:"location_id"`
+		CustomerID    int32  `query:"customer_id"`
+		Status        string `query:"status" validate:"omitempty,oneof=completed voided"`
+		PaymentMethod string `query:"payment_method"`
+		PageRequest
+	}
+
+	VoidSaleRequest struct {
+		Reason string `json:"reason" validate:"required"`
+	}
+
+	SaleResponse struct {
+		Sale  database.Sale       `json:"sale"`
+		Items []database.SaleItem `json:"items"`
+	}
+)
+
+// dateRange parses an inclusive from/to pair of dates into a half-open time
+// range, defaulting to the last 30 days
+func dateRange(from string, to string) (time.Time, time.Time) {
+	year, month, day := time.Now().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+
+	end := today.AddDate(0, 0, 1)
+	if to != "" {
+		date, _ := time.ParseInLocation(time.DateOnly, to, time.Local)
+		end = date.AddDate(0, 0, 1)
+	}
+
+	start := end.AddDate(0, 0, -30)
+	if from != "" {
+		start, _ = time.ParseInLocation(time.DateOnly, from, time.Local)
+	}
+
+	return start, end
+}
+
+func (server *Server) createSale(ctx *fiber.Ctx) error {
+	var request CreateSaleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	var override bool
+	items := make([]database.SaleItemParams, 0, len(request.Items))
+	for _, item := range request.Items {
+		override = override || item.OverrideReason != "" || item.UnitPrice != nil
+		items = append(items, database.SaleItemParams{
+			ProductID:       item.ProductID,
+			SaleType:        item.SaleType,
+			Quantity:        item.Quantity,
+			EmptiesReturned: item.EmptiesReturned,
+			UnitPrice:       item.UnitPrice,
+			DiscountAmount:  item.DiscountAmount,
+			OverrideReason:  item.OverrideReason,
+			Serials:         item.Serials,
+			EmptySerials:    item.EmptySerials,
+		})
+	}
+
+	// an admin's sale approves its own prices, anyone else may only sell at
+	// the price list and the store refuses a price other than that
+	var approvedBy string
+	if override {
+		admin, err := server.isAdmin(ctx)
+		if err != nil {
+			return err
+		}
+
+		if admin {
+			approvedBy = authorizationPayload(ctx).Issuer
+		}
+	}
+
+	result, err := server.store.CreateSaleTx(ctx.Context(), server.pool, database.CreateSaleTxParams{
+		LocationID:     request.LocationID,
+		CustomerID:     pgtype.Int4{Int32: request.CustomerID, Valid: request.CustomerID != 0},
+		PaymentMethod:  request.PaymentMethod,
+		TaxBasisPoints: server.config.SalesTaxBasisPoints,
+		Note:           request.Note,
+		Items:          items,
+		CreatedBy:      authorizationPayload(ctx).Issuer,
+		ApprovedBy:     approvedBy,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listSales(ctx *fiber.Ctx) error {
+	var request ListSalesRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	from, to := dateRange(request.From, request.To)
+	arg := database.ListSalesParams{
+		FromTime:      from,
+		ToTime:        to,
+		LocationID:    pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		CustomerID:    pgtype.Int4{Int32: request.CustomerID, Valid: request.CustomerID != 0},
+		Status:        optionalText(request.Status),
+		PaymentMethod: optionalText(request.PaymentMethod),
+		PageSize:      request.limit(),
+		PageOffset:    request.offset(),
+	}
+
+	return server.sendList(ctx, "sales", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListSales(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListSalesRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getSale(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response SaleResponse
+	response.Sale, err = server.store.GetSale(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Items, err = server.store.ListSaleItems(ctx.Context(), server.pool, response.Sale.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) voidSale(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request VoidSaleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.VoidSaleTx(ctx.Context(), server.pool, database.VoidSaleTxParams{
+		SaleID:   int64(id),
+		Reason:   request.Reason,
+		VoidedBy: authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
@@ -1,9 +1,15 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"time"
 
 	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/document"
+	"github.com/blanc08/stok-gas-management-backend/pkg/forecast"
+	"github.com/blanc08/stok-gas-management-backend/pkg/storage"
 	"github.com/blanc08/stok-gas-management-backend/pkg/token"
 	"github.com/blanc08/stok-gas-management-backend/pkg/util"
 	"github.com/gofiber/fiber/v2"
@@ -16,21 +22,52 @@ type Server struct {
 	pool       *pgxpool.Pool
 	store      database.Store
 	tokenMaker token.Maker
-	app        *fiber.App
-	validator  util.XValidator
+	storage    storage.Storage
+	forecaster forecast.Forecaster
+	documents  *document.Renderer
+	// timeZone is the zone times are shown in by exports and documents
+	timeZone  *time.Location
+	app       *fiber.App
+	validator util.XValidator
 }
 
-func NewServer(config util.Config, store database.Store, pool *pgxpool.Pool) (*Server, error) {
+func NewServer(config util.Config, store database.Store, pool *pgxpool.Pool, fileStorage storage.Storage) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.V4SymmetricSecretKeyHex)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	forecaster, err := forecast.New(config.ForecastAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create forecaster: %w", err)
+	}
+
+	timeZone, err := time.LoadLocation(config.ExportTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load export time zone: %w", err)
+	}
+
+	layouts, err := document.LoadLayouts(config.DocumentLayoutFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load document layouts: %w", err)
+	}
+
+	// the QR codes of printed documents lead back to the API through it, the
+	// address a request came in on may be one customers cannot reach
+	baseURL, err := url.Parse(config.DocumentBaseURL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("DOCUMENT_BASE_URL must be the absolute URL of the API, not %q", config.DocumentBaseURL)
+	}
+
 	server := &Server{
 		config:     config,
 		pool:       pool,
 		store:      store,
 		tokenMaker: tokenMaker,
+		storage:    fileStorage,
+		forecaster: forecaster,
+		documents:  document.NewRenderer(layouts, timeZone),
+		timeZone:   timeZone,
 		validator:  *util.NewValidator(),
 	}
 
@@ -41,8 +78,16 @@ func NewServer(config util.Config, store database.Store, pool *pgxpool.Pool) (*S
 func (server *Server) setupApp() {
 	app := fiber.New(
 		fiber.Config{
+			// room for the files of a proof of delivery and its form fields
+			BodyLimit: 2*int(server.config.UploadMaxBytes) + 1<<20,
 			ErrorHandler: func(c *fiber.Ctx, err error) error {
-				return c.Status(fiber.StatusBadRequest).JSON(util.GlobalErrorHandlerResp{
+				code := fiber.StatusBadRequest
+				var e *fiber.Error
+				if errors.As(err, &e) {
+					code = e.Code
+				}
+
+				return c.Status(code).JSON(util.GlobalErrorHandlerResp{
 					Success: false,
 					Message: err.Error(),
 				})
@@ -73,6 +118,169 @@ func (server *Server) setupApp() {
 		fmt.Println("authorization payload : ", c.Locals("authorization_payload"))
 		return c.SendString("OK")
 	})
+	authenticatedRoutes.Put("/users/:id/role", server.adminMiddleware(), server.updateUserRole)
+
+	// inventory
+	authenticatedRoutes.Get("/products", server.listProducts)
+	authenticatedRoutes.Post("/products", server.createProduct)
+	authenticatedRoutes.Put("/products/:id", server.updateProduct)
+	authenticatedRoutes.Get("/locations", server.listLocations)
+	authenticatedRoutes.Post("/locations", server.createLocation)
+	authenticatedRoutes.Put("/locations/:id", server.updateLocation)
+	authenticatedRoutes.Get("/stock", server.listStock)
+	authenticatedRoutes.Get("/stock/movements", server.listStockMovements)
+	authenticatedRoutes.Get("/stock/reservations", server.listStockReservations)
+
+	// stock opname, only a supervisor approves a count
+	authenticatedRoutes.Post("/stock-counts", server.openStockCount)
+	authenticatedRoutes.Get("/stock-counts", server.listStockCounts)
+	authenticatedRoutes.Get("/stock-counts/:id", server.getStockCount)
+	authenticatedRoutes.Post("/stock-counts/:id/entries", server.submitStockCount)
+	authenticatedRoutes.Post("/stock-counts/:id/approve", server.adminMiddleware(), server.approveStockCount)
+	authenticatedRoutes.Post("/stock-counts/:id/cancel", server.cancelStockCount)
+
+	// stock levels and alerts
+	authenticatedRoutes.Get("/stock-thresholds", server.listStockThresholds)
+	authenticatedRoutes.Put("/stock-thresholds", server.setStockThreshold)
+	authenticatedRoutes.Delete("/stock-thresholds/:location_id/:product_id", server.deleteStockThreshold)
+	authenticatedRoutes.Get("/alerts", server.listAlerts)
+	authenticatedRoutes.Get("/reorder-suggestions", server.listReorderSuggestions)
+
+	// demand forecasts, holidays raise the demand expected on their days
+	authenticatedRoutes.Get("/forecasts", server.getDemandForecast)
+	authenticatedRoutes.Get("/holidays", server.listHolidays)
+	authenticatedRoutes.Post("/holidays", server.adminMiddleware(), server.createHoliday)
+	authenticatedRoutes.Delete("/holidays/:id", server.adminMiddleware(), server.deleteHoliday)
+
+	// reports, read from daily aggregates the worker refreshes
+	authenticatedRoutes.Get("/reports/sales", server.getSalesReport)
+	authenticatedRoutes.Get("/reports/movements", server.getMovementReport)
+	authenticatedRoutes.Get("/reports/stock-valuation", server.getStockValuation)
+	authenticatedRoutes.Get("/reports/top-customers", server.getTopCustomers)
+	authenticatedRoutes.Get("/reports/empties-outstanding", server.getEmptiesOutstanding)
+	authenticatedRoutes.Post("/reports/refresh", server.adminMiddleware(), server.refreshReports)
+
+	// bulk imports, run by the worker in the background
+	authenticatedRoutes.Post("/imports", server.adminMiddleware(), server.createImport)
+	authenticatedRoutes.Get("/imports", server.listImports)
+	authenticatedRoutes.Get("/imports/:id", server.getImport)
+
+	// customers
+	authenticatedRoutes.Post("/customers", server.createCustomer)
+	authenticatedRoutes.Get("/customers", server.listCustomers)
+	authenticatedRoutes.Get("/customers/:id", server.getCustomer)
+	authenticatedRoutes.Put("/customers/:id", server.updateCustomer)
+	authenticatedRoutes.Delete("/customers/:id", server.deleteCustomer)
+	authenticatedRoutes.Get("/customers/:id/empties", server.listCustomerEmpties)
+
+	// sales
+	authenticatedRoutes.Post("/sales", server.createSale)
+	authenticatedRoutes.Get("/sales", server.listSales)
+	authenticatedRoutes.Get("/sales/:id", server.getSale)
+	authenticatedRoutes.Get("/sales/:id/pdf", server.getReceiptPDF)
+	authenticatedRoutes.Post("/sales/:id/void", server.voidSale)
+
+	// cylinder deposits
+	authenticatedRoutes.Get("/deposits", server.listDeposits)
+	authenticatedRoutes.Post("/deposits/refunds", server.refundDeposit)
+	authenticatedRoutes.Get("/deposits/liabilities", server.listDepositLiabilities)
+	authenticatedRoutes.Get("/deposits/reconciliation", server.reconcileDeposits)
+	authenticatedRoutes.Get("/deposits/:id", server.getDeposit)
+
+	// serialized cylinders
+	authenticatedRoutes.Post("/cylinders", server.registerCylinder)
+	authenticatedRoutes.Get("/cylinders", server.listCylinders)
+	authenticatedRoutes.Get("/cylinders/scan/:code", server.scanCylinder)
+	authenticatedRoutes.Get("/cylinders/inspections/due", server.listCylindersDue)
+	authenticatedRoutes.Get("/cylinders/:id", server.getCylinder)
+	authenticatedRoutes.Post("/cylinders/:id/movements", server.moveCylinder)
+	authenticatedRoutes.Post("/cylinders/:id/inspections", server.recordInspection)
+	authenticatedRoutes.Get("/cylinders/:id/inspections", server.listInspections)
+
+	// damaged and leaking cylinders
+	authenticatedRoutes.Post("/incidents", server.reportIncident)
+	authenticatedRoutes.Get("/incidents", server.listIncidents)
+	authenticatedRoutes.Get("/incidents/:id", server.getIncident)
+	authenticatedRoutes.Post("/incidents/:id/dispose", server.disposeIncident)
+	authenticatedRoutes.Post("/incidents/:id/photos", server.uploadIncidentPhoto)
+	authenticatedRoutes.Get("/incidents/:id/photos/:file", server.getIncidentPhoto)
+
+	// cash sessions and daily closing, only a supervisor approves a closing
+	authenticatedRoutes.Post("/cash-sessions", server.openCashSession)
+	authenticatedRoutes.Get("/cash-sessions", server.listCashSessions)
+	authenticatedRoutes.Get("/cash-sessions/current", server.getCurrentCashSession)
+	authenticatedRoutes.Get("/cash-sessions/:id", server.getCashSession)
+	authenticatedRoutes.Post("/cash-sessions/:id/close", server.closeCashSession)
+	authenticatedRoutes.Post("/daily-closings", server.submitDailyClosing)
+	authenticatedRoutes.Get("/daily-closings", server.listDailyClosings)
+	authenticatedRoutes.Get("/daily-closings/:id", server.getDailyClosing)
+	authenticatedRoutes.Post("/daily-closings/:id/approve", server.adminMiddleware(), server.approveDailyClosing)
+
+	// invoices and receivables
+	authenticatedRoutes.Get("/invoices", server.listInvoices)
+	authenticatedRoutes.Get("/invoices/:id", server.getInvoice)
+	authenticatedRoutes.Get("/invoices/:id/pdf", server.getInvoicePDF)
+	authenticatedRoutes.Post("/invoices/:id/payments", server.payInvoice)
+	authenticatedRoutes.Get("/receivables/aging", server.receivablesAging)
+
+	// prices
+	authenticatedRoutes.Get("/prices", server.resolvePrice)
+	authenticatedRoutes.Get("/price-lists", server.listPriceLists)
+	authenticatedRoutes.Post("/price-lists", server.adminMiddleware(), server.createPriceList)
+	authenticatedRoutes.Get("/ceiling-prices", server.listCeilingPrices)
+	authenticatedRoutes.Post("/ceiling-prices", server.adminMiddleware(), server.createCeilingPrice)
+	authenticatedRoutes.Get("/price-overrides", server.listPriceOverrides)
+
+	// subsidized quotas
+	authenticatedRoutes.Post("/quota-rules", server.adminMiddleware(), server.createQuotaRule)
+	authenticatedRoutes.Get("/quota-rules", server.listQuotaRules)
+	authenticatedRoutes.Put("/quota-rules/:id", server.adminMiddleware(), server.updateQuotaRule)
+	authenticatedRoutes.Get("/quota-usages", server.listQuotaUsages)
+
+	// transfers
+	authenticatedRoutes.Post("/transfers", server.createTransfer)
+	authenticatedRoutes.Get("/transfers", server.listTransfers)
+	authenticatedRoutes.Get("/transfers/:id", server.getTransfer)
+	authenticatedRoutes.Post("/transfers/:id/dispatch", server.dispatchTransfer)
+	authenticatedRoutes.Post("/transfers/:id/receive", server.receiveTransfer)
+
+	// vehicles, drivers and deliveries
+	authenticatedRoutes.Post("/vehicles", server.createVehicle)
+	authenticatedRoutes.Get("/vehicles", server.listVehicles)
+	authenticatedRoutes.Get("/vehicles/:id", server.getVehicle)
+	authenticatedRoutes.Put("/vehicles/:id", server.updateVehicle)
+	authenticatedRoutes.Put("/vehicles/:id/capacities", server.setVehicleCapacities)
+	authenticatedRoutes.Post("/drivers", server.createDriver)
+	authenticatedRoutes.Get("/drivers", server.listDrivers)
+	authenticatedRoutes.Put("/drivers/:id", server.updateDriver)
+	authenticatedRoutes.Get("/drivers/:id/shortages", server.listDriverShortages)
+	authenticatedRoutes.Post("/deliveries", server.createDeliveryOrder)
+	authenticatedRoutes.Get("/deliveries", server.listDeliveryOrders)
+	authenticatedRoutes.Post("/deliveries/plan", server.planDeliveries)
+	authenticatedRoutes.Post("/deliveries/plan/accept", server.acceptDeliveryPlan)
+	authenticatedRoutes.Get("/deliveries/:id", server.getDeliveryOrder)
+	authenticatedRoutes.Get("/deliveries/:id/pdf", server.getDeliveryNotePDF)
+	authenticatedRoutes.Post("/deliveries/:id/confirm", server.confirmDeliveryOrder)
+	authenticatedRoutes.Post("/deliveries/:id/load", server.loadDeliveryOrder)
+	authenticatedRoutes.Post("/deliveries/:id/depart", server.deliveryOrderStepHandler(server.store.DepartDeliveryOrderTx))
+	authenticatedRoutes.Post("/deliveries/:id/stops/:stop_id/complete", server.completeDeliveryStop)
+	authenticatedRoutes.Post("/deliveries/:id/stops/:stop_id/fail", server.failDeliveryStop)
+	authenticatedRoutes.Post("/deliveries/:id/stops/:stop_id/proof", server.recordDeliveryProof)
+	authenticatedRoutes.Get("/deliveries/:id/stops/:stop_id/proof/:file", server.getDeliveryProofFile)
+	authenticatedRoutes.Post("/deliveries/:id/reconcile", server.reconcileDeliveryOrder)
+	authenticatedRoutes.Get("/deliveries/:id/reconciliation", server.getDeliveryReconciliation)
+	authenticatedRoutes.Post("/deliveries/:id/cancel", server.deliveryOrderStepHandler(server.store.CancelDeliveryOrderTx))
+
+	// purchasing
+	authenticatedRoutes.Post("/suppliers", server.createSupplier)
+	authenticatedRoutes.Get("/suppliers", server.listSuppliers)
+	authenticatedRoutes.Post("/purchase-orders", server.createPurchaseOrder)
+	authenticatedRoutes.Get("/purchase-orders", server.listPurchaseOrders)
+	authenticatedRoutes.Get("/purchase-orders/:id", server.getPurchaseOrder)
+	authenticatedRoutes.Post("/purchase-orders/:id/order", server.orderPurchaseOrder)
+	authenticatedRoutes.Post("/purchase-orders/:id/receipts", server.receivePurchaseOrder)
+	authenticatedRoutes.Post("/purchase-orders/:id/close", server.closePurchaseOrder)
+	authenticatedRoutes.Get("/goods-receipts/:id", server.getGoodsReceipt)
 
 	server.app = app
 }
@@ -81,6 +289,13 @@ func (server *Server) Start(address string) error {
 	return server.app.Listen(address)
 }
 
+func badRequest(ctx *fiber.Ctx, details []util.ErrorResponse) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"message": "bad request",
+		"details": details,
+	})
+}
+
 func errorResponse(err error) fiber.Error {
 	return fiber.Error{
 		Code:    500,
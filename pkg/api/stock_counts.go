@@ -0,0 +1,161 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	OpenStockCountRequest struct {
+		LocationID int32  `json:"location_id" validate:"required"`
+		Note       string `json:"note"`
+	}
+
+	ListStockCountsRequest struct {
+		LocationID int32  `query:"location_id"`
+		Status     string `query:"status" validate:"omitempty,oneof=counting approved cancelled"`
+		PageRequest
+	}
+
+	StockCountItemRequest struct {
+		ProductID int32 `json:"product_id" validate:"required"`
+		FullQty   int32 `json:"full_qty" validate:"min=0"`
+		EmptyQty  int32 `json:"empty_qty" validate:"min=0"`
+	}
+
+	SubmitStockCountRequest struct {
+		Items []StockCountItemRequest `json:"items" validate:"required,min=1,dive"`
+	}
+)
+
+func (server *Server) openStockCount(ctx *fiber.Ctx) error {
+	var request OpenStockCountRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.OpenStockCountTx(ctx.Context(), server.pool, database.OpenStockCountTxParams{
+		LocationID: request.LocationID,
+		Note:       request.Note,
+		CreatedBy:  authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listStockCounts(ctx *fiber.Ctx) error {
+	var request ListStockCountsRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListStockCountsParams{
+		LocationID: pgtype.Int4{Int32: request.LocationID, Valid: request.LocationID != 0},
+		Status:     optionalText(request.Status),
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "stock-counts", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListStockCounts(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListStockCountsRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getStockCount(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response database.StockCountTxResult
+	response.StockCount, err = server.store.GetStockCount(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	lines, err := server.store.ListStockCountLines(ctx.Context(), server.pool, response.StockCount.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Lines = database.StockCountVariances(lines)
+	return ctx.JSON(response)
+}
+
+func (server *Server) submitStockCount(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request SubmitStockCountRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	items := make([]database.StockCountItemParams, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, database.StockCountItemParams(item))
+	}
+
+	result, err := server.store.SubmitStockCountTx(ctx.Context(), server.pool, database.SubmitStockCountTxParams{
+		StockCountID: int64(id),
+		Items:        items,
+		CountedBy:    authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+func (server *Server) approveStockCount(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	result, err := server.store.ApproveStockCountTx(ctx.Context(), server.pool, database.ApproveStockCountTxParams{
+		StockCountID: int64(id),
+		ApprovedBy:   authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+func (server *Server) cancelStockCount(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	count, err := server.store.CancelStockCountTx(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(count)
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type (
+	TransferItemRequest struct {
+		ProductID int32 `json:"product_id" validate:"required"`
+		FullQty   int32 `json:"full_qty" validate:"min=0"`
+		EmptyQty  int32 `json:"empty_qty" validate:"min=0"`
+	}
+
+	CreateTransferRequest struct {
+		SourceLocationID      int32                 `json:"source_location_id" validate:"required"`
+		DestinationLocationID int32                 `json:"destination_location_id" validate:"required,nefield=SourceLocationID"`
+		Note                  string                `json:"note"`
+		Items                 []TransferItemRequest `json:"items" validate:"required,min=1,dive"`
+	}
+
+	ReceiveTransferRequest struct {
+		Items   []TransferItemRequest `json:"items" validate:"dive"`
+		Serials []string              `json:"serials" validate:"dive,required"`
+		Final   bool                  `json:"final"`
+		Note    string                `json:"note"`
+	}
+
+	ListTransfersRequest struct {
+		Status string `query:"status" validate:"omitempty,oneof=draft dispatched partially_received received"`
+		PageRequest
+	}
+)
+
+func newTransferItemParams(items []TransferItemRequest) []database.TransferItemParams {
+	params := make([]database.TransferItemParams, 0, len(items))
+	for _, item := range items {
+		params = append(params, database.TransferItemParams{
+			ProductID: item.ProductID,
+			FullQty:   item.FullQty,
+			EmptyQty:  item.EmptyQty,
+		})
+	}
+	return params
+}
+
+func (server *Server) createTransfer(ctx *fiber.Ctx) error {
+	var request CreateTransferRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.CreateTransferTx(ctx.Context(), server.pool, database.CreateTransferTxParams{
+		SourceLocationID:      request.SourceLocationID,
+		DestinationLocationID: request.DestinationLocationID,
+		Note:                  request.Note,
+		Items:                 newTransferItemParams(request.Items),
+		CreatedBy:             authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listTransfers(ctx *fiber.Ctx) error {
+	var request ListTransfersRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListTransfersParams{
+		Status:     pgtype.Text{String: request.Status, Valid: request.Status != ""},
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "transfers", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListTransfers(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListTransfersRows(ctx.Context(), db, arg)
+	})
+}
+
+func (server *Server) getTransfer(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var result database.TransferTxResult
+	result.Transfer, err = server.store.GetTransfer(ctx.Context(), server.pool, int64(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	result.Items, err = server.store.ListTransferItems(ctx.Context(), server.pool, result.Transfer.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	result.Discrepancies, err = server.store.ListTransferDiscrepancies(ctx.Context(), server.pool, result.Transfer.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+func (server *Server) dispatchTransfer(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request SerialsRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return fiber.ErrUnprocessableEntity
+		}
+
+		if errs := server.validator.Validate(request); len(errs) > 0 {
+			return badRequest(ctx, errs)
+		}
+	}
+
+	result, err := server.store.DispatchTransferTx(ctx.Context(), server.pool, database.DispatchTransferTxParams{
+		TransferID:   int64(id),
+		Serials:      request.Serials,
+		DispatchedBy: authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
+
+func (server *Server) receiveTransfer(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request ReceiveTransferRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.ReceiveTransferTx(ctx.Context(), server.pool, database.ReceiveTransferTxParams{
+		TransferID: int64(id),
+		Items:      newTransferItemParams(request.Items),
+		Serials:    request.Serials,
+		Final:      request.Final,
+		Note:       request.Note,
+		ReceivedBy: authorizationPayload(ctx).Issuer,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(result)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+)
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=staff admin"`
+}
+
+// updateUserRole gives a user the staff or admin role. An admin cannot change
+// their own role, so there is always an admin left to change it back.
+func (server *Server) updateUserRole(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request UpdateUserRoleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	user, err := server.store.GetUserByID(ctx.Context(), server.pool, int32(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	if user.Email == authorizationPayload(ctx).Issuer {
+		return fiber.NewError(fiber.StatusForbidden, "an admin cannot change their own role")
+	}
+
+	user, err = server.store.UpdateUserRole(ctx.Context(), server.pool, database.UpdateUserRoleParams{
+		Role: request.Role,
+		ID:   user.ID,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(newUserResponse(user))
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+type (
+	VehicleCapacityRequest struct {
+		ProductID int32 `json:"product_id" validate:"required"`
+		Capacity  int32 `json:"capacity" validate:"min=0"`
+	}
+
+	CreateVehicleRequest struct {
+		PlateNumber string                   `json:"plate_number" validate:"required"`
+		Region      string                   `json:"region"`
+		Capacities  []VehicleCapacityRequest `json:"capacities" validate:"dive"`
+	}
+
+	SetVehicleCapacitiesRequest struct {
+		Capacities []VehicleCapacityRequest `json:"capacities" validate:"required,min=1,dive"`
+	}
+
+	UpdateVehicleRequest struct {
+		IsActive bool `json:"is_active"`
+	}
+
+	CreateDriverRequest struct {
+		UserID        int32  `json:"user_id" validate:"required"`
+		LicenseNumber string `json:"license_number" validate:"required"`
+		Phone         string `json:"phone"`
+	}
+
+	UpdateDriverRequest struct {
+		LicenseNumber string `json:"license_number" validate:"required"`
+		Phone         string `json:"phone"`
+		IsActive      bool   `json:"is_active"`
+	}
+
+	ListDriverShortagesRequest struct {
+		PageRequest
+	}
+
+	VehicleResponse struct {
+		Vehicle    database.Vehicle           `json:"vehicle"`
+		Capacities []database.VehicleCapacity `json:"capacities"`
+	}
+)
+
+func newVehicleCapacityParams(capacities []VehicleCapacityRequest) []database.VehicleCapacityParams {
+	params := make([]database.VehicleCapacityParams, 0, len(capacities))
+	for _, capacity := range capacities {
+		params = append(params, database.VehicleCapacityParams{
+			ProductID: capacity.ProductID,
+			Capacity:  capacity.Capacity,
+		})
+	}
+	return params
+}
+
+// createVehicle registers a vehicle along with the location its load is kept at
+func (server *Server) createVehicle(ctx *fiber.Ctx) error {
+	var request CreateVehicleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	result, err := server.store.CreateVehicleTx(ctx.Context(), server.pool, database.CreateVehicleTxParams{
+		PlateNumber: request.PlateNumber,
+		Region:      optionalText(request.Region),
+		Capacities:  newVehicleCapacityParams(request.Capacities),
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (server *Server) listVehicles(ctx *fiber.Ctx) error {
+	return server.sendList(ctx, "vehicles", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListVehicles(ctx.Context(), db)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListVehiclesRows(ctx.Context(), db)
+	})
+}
+
+func (server *Server) getVehicle(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var response VehicleResponse
+	response.Vehicle, err = server.store.GetVehicle(ctx.Context(), server.pool, int32(id))
+	if err != nil {
+		return storeError(err)
+	}
+
+	response.Capacities, err = server.store.ListVehicleCapacities(ctx.Context(), server.pool, response.Vehicle.ID)
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(response)
+}
+
+func (server *Server) updateVehicle(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request UpdateVehicleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	vehicle, err := server.store.UpdateVehicleActive(ctx.Context(), server.pool, database.UpdateVehicleActiveParams{
+		ID:       int32(id),
+		IsActive: request.IsActive,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(vehicle)
+}
+
+func (server *Server) setVehicleCapacities(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request SetVehicleCapacitiesRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	capacities, err := server.store.SetVehicleCapacitiesTx(ctx.Context(), server.pool, int32(id), newVehicleCapacityParams(request.Capacities))
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(capacities)
+}
+
+func (server *Server) createDriver(ctx *fiber.Ctx) error {
+	var request CreateDriverRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	driver, err := server.store.CreateDriver(ctx.Context(), server.pool, database.CreateDriverParams{
+		UserID:        request.UserID,
+		LicenseNumber: request.LicenseNumber,
+		Phone:         request.Phone,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(driver)
+}
+
+func (server *Server) listDrivers(ctx *fiber.Ctx) error {
+	return server.sendList(ctx, "drivers", nil, func(db database.DBTX) (any, error) {
+		return server.store.ListDrivers(ctx.Context(), db)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListDriversRows(ctx.Context(), db)
+	})
+}
+
+func (server *Server) updateDriver(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request UpdateDriverRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.ErrUnprocessableEntity
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	driver, err := server.store.UpdateDriver(ctx.Context(), server.pool, database.UpdateDriverParams{
+		ID:            int32(id),
+		LicenseNumber: request.LicenseNumber,
+		Phone:         request.Phone,
+		IsActive:      request.IsActive,
+	})
+	if err != nil {
+		return storeError(err)
+	}
+
+	return ctx.JSON(driver)
+}
+
+// listDriverShortages returns the shortages recorded against a driver when
+// their vehicles were reconciled
+func (server *Server) listDriverShortages(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	var request ListDriverShortagesRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	if errs := server.validator.Validate(request); len(errs) > 0 {
+		return badRequest(ctx, errs)
+	}
+
+	arg := database.ListDriverShortagesParams{
+		DriverID:   int32(id),
+		PageSize:   request.limit(),
+		PageOffset: request.offset(),
+	}
+
+	return server.sendList(ctx, "driver-shortages", &request.PageRequest, func(db database.DBTX) (any, error) {
+		return server.store.ListDriverShortages(ctx.Context(), db, arg)
+	}, func(db database.DBTX) (pgx.Rows, error) {
+		return server.store.ListDriverShortagesRows(ctx.Context(), db, arg)
+	})
+}
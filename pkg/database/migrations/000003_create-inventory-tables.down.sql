@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "sales";
+DROP TABLE IF EXISTS "empties_balances";
+DROP TABLE IF EXISTS "customers";
+DROP TABLE IF EXISTS "stock_movements";
+DROP TABLE IF EXISTS "stock_balances";
+DROP TABLE IF EXISTS "locations";
+DROP TABLE IF EXISTS "products";
@@ -0,0 +1,98 @@
+CREATE TABLE "products" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "code" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "net_weight_grams" int NOT NULL,
+    "is_subsidized" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "products" ("code");
+
+-- location_type is one of: depot, outlet, in_transit, vehicle
+CREATE TABLE "locations" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "code" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "location_type" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "locations" ("code");
+
+CREATE TABLE "stock_balances" (
+    "location_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "full_qty" int NOT NULL DEFAULT 0,
+    "empty_qty" int NOT NULL DEFAULT 0,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("location_id", "product_id")
+);
+
+-- stock_movements is the append-only ledger, stock_balances is its running total
+CREATE TABLE "stock_movements" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "location_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "full_qty_change" int NOT NULL DEFAULT 0,
+    "empty_qty_change" int NOT NULL DEFAULT 0,
+    "reason" varchar NOT NULL,
+    "reference_type" varchar NOT NULL,
+    "reference_id" bigint NOT NULL,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "stock_movements" ("location_id", "product_id", "created_at");
+CREATE INDEX ON "stock_movements" ("reference_type", "reference_id");
+
+CREATE TABLE "customers" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "customer_type" varchar NOT NULL,
+    "phone" varchar,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- balance is the number of empty cylinders the customer still owes
+CREATE TABLE "empties_balances" (
+    "customer_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "balance" int NOT NULL DEFAULT 0,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("customer_id", "product_id")
+);
+
+-- sale_type is one of: exchange, new_cylinder
+CREATE TABLE "sales" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "location_id" int NOT NULL,
+    "customer_id" int,
+    "product_id" int NOT NULL,
+    "sale_type" varchar NOT NULL,
+    "quantity" int NOT NULL,
+    "empties_returned" int NOT NULL DEFAULT 0,
+    "unit_price" bigint NOT NULL,
+    "deposit_amount" bigint NOT NULL DEFAULT 0,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "sales" ("location_id", "created_at");
+CREATE INDEX ON "sales" ("customer_id");
+
+-- Add Foreign key
+ALTER TABLE "stock_balances"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "stock_balances"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "stock_movements"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "stock_movements"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "empties_balances"
+ADD FOREIGN KEY ("customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "empties_balances"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "sales"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "sales"
+ADD FOREIGN KEY ("customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "sales"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "transfer_discrepancies";
+DROP TABLE IF EXISTS "transfer_items";
+DROP TABLE IF EXISTS "transfers";
+DELETE FROM "locations" WHERE "code" = 'IN-TRANSIT';
@@ -0,0 +1,57 @@
+-- virtual location holding stock that left its source but has not arrived yet
+INSERT INTO "locations" ("code", "name", "location_type")
+VALUES ('IN-TRANSIT', 'In transit', 'in_transit');
+
+-- status is one of: draft, dispatched, partially_received, received
+CREATE TABLE "transfers" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "source_location_id" int NOT NULL,
+    "destination_location_id" int NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'draft',
+    "note" varchar NOT NULL DEFAULT '',
+    "created_by" varchar NOT NULL,
+    "dispatched_by" varchar,
+    "dispatched_at" timestamptz,
+    "received_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("source_location_id" <> "destination_location_id")
+);
+CREATE INDEX ON "transfers" ("status");
+
+CREATE TABLE "transfer_items" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "transfer_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "full_qty" int NOT NULL DEFAULT 0,
+    "empty_qty" int NOT NULL DEFAULT 0,
+    "received_full_qty" int NOT NULL DEFAULT 0,
+    "received_empty_qty" int NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX ON "transfer_items" ("transfer_id", "product_id");
+
+-- quantities dispatched but never received, written off from in-transit stock
+CREATE TABLE "transfer_discrepancies" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "transfer_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "full_qty_short" int NOT NULL DEFAULT 0,
+    "empty_qty_short" int NOT NULL DEFAULT 0,
+    "note" varchar NOT NULL DEFAULT '',
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "transfer_discrepancies" ("transfer_id");
+
+-- Add Foreign key
+ALTER TABLE "transfers"
+ADD FOREIGN KEY ("source_location_id") REFERENCES "locations" ("id");
+ALTER TABLE "transfers"
+ADD FOREIGN KEY ("destination_location_id") REFERENCES "locations" ("id");
+ALTER TABLE "transfer_items"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+ALTER TABLE "transfer_items"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "transfer_discrepancies"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+ALTER TABLE "transfer_discrepancies"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
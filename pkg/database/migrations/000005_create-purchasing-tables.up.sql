@@ -0,0 +1,80 @@
+CREATE TABLE "suppliers" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "code" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "phone" varchar,
+    "address" varchar,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "suppliers" ("code");
+
+-- status is one of: draft, ordered, partially_received, received, closed
+CREATE TABLE "purchase_orders" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "supplier_id" int NOT NULL,
+    "location_id" int NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'draft',
+    "expected_date" date,
+    "note" varchar NOT NULL DEFAULT '',
+    "created_by" varchar NOT NULL,
+    "ordered_at" timestamptz,
+    "closed_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "purchase_orders" ("supplier_id");
+CREATE INDEX ON "purchase_orders" ("status");
+
+-- unit_price is in rupiah
+CREATE TABLE "purchase_order_items" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "purchase_order_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "ordered_qty" int NOT NULL,
+    "unit_price" bigint NOT NULL,
+    "received_qty" int NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX ON "purchase_order_items" ("purchase_order_id", "product_id");
+
+CREATE TABLE "goods_receipts" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "purchase_order_id" bigint NOT NULL,
+    "location_id" int NOT NULL,
+    "note" varchar NOT NULL DEFAULT '',
+    "received_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "goods_receipts" ("purchase_order_id");
+
+-- expected_qty is what was still outstanding on the order when the goods arrived,
+-- variance_qty = received_qty - expected_qty, negative when short and positive when over
+CREATE TABLE "goods_receipt_items" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "goods_receipt_id" bigint NOT NULL,
+    "purchase_order_item_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "expected_qty" int NOT NULL,
+    "received_qty" int NOT NULL,
+    "variance_qty" int NOT NULL,
+    "empties_returned" int NOT NULL DEFAULT 0
+);
+CREATE INDEX ON "goods_receipt_items" ("goods_receipt_id");
+
+-- Add Foreign key
+ALTER TABLE "purchase_orders"
+ADD FOREIGN KEY ("supplier_id") REFERENCES "suppliers" ("id");
+ALTER TABLE "purchase_orders"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "purchase_order_items"
+ADD FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders" ("id");
+ALTER TABLE "purchase_order_items"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "goods_receipts"
+ADD FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders" ("id");
+ALTER TABLE "goods_receipts"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "goods_receipt_items"
+ADD FOREIGN KEY ("goods_receipt_id") REFERENCES "goods_receipts" ("id");
+ALTER TABLE "goods_receipt_items"
+ADD FOREIGN KEY ("purchase_order_item_id") REFERENCES "purchase_order_items" ("id");
+ALTER TABLE "goods_receipt_items"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
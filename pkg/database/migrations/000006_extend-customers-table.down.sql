@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "customers_phone_idx";
+DROP INDEX IF EXISTS "customers_national_id_idx";
+DROP INDEX IF EXISTS "customers_business_id_idx";
+DROP INDEX IF EXISTS "customers_customer_type_idx";
+ALTER TABLE "customers"
+DROP COLUMN "national_id",
+    DROP COLUMN "business_id",
+    DROP COLUMN "address",
+    DROP COLUMN "credit_limit",
+    DROP COLUMN "is_active",
+    DROP COLUMN "updated_at";
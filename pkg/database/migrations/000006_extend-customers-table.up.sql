@@ -0,0 +1,13 @@
+ALTER TABLE "customers"
+ADD COLUMN "national_id" varchar,
+    ADD COLUMN "business_id" varchar,
+    ADD COLUMN "address" varchar,
+    ADD COLUMN "credit_limit" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "is_active" boolean NOT NULL DEFAULT true,
+    ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT (now());
+
+-- a phone number, NIK or NIB identifies a single customer
+CREATE UNIQUE INDEX ON "customers" ("phone");
+CREATE UNIQUE INDEX ON "customers" ("national_id");
+CREATE UNIQUE INDEX ON "customers" ("business_id");
+CREATE INDEX ON "customers" ("customer_type");
@@ -0,0 +1,40 @@
+-- period is one of: daily, weekly, monthly
+CREATE TABLE "quota_rules" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "product_id" int NOT NULL,
+    "customer_type" varchar NOT NULL,
+    "period" varchar NOT NULL,
+    "max_qty" int NOT NULL,
+    "is_active" boolean NOT NULL DEFAULT true,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "quota_rules" ("product_id", "customer_type", "period");
+
+-- one row per rule a sale counted against, period_start identifies the
+-- day, week (monday) or month the usage belongs to
+CREATE TABLE "quota_usages" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "customer_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "quota_rule_id" int NOT NULL,
+    "period_start" date NOT NULL,
+    "quantity" int NOT NULL,
+    "sale_id" bigint NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "quota_usages" ("customer_id", "quota_rule_id", "period_start");
+CREATE INDEX ON "quota_usages" ("created_at");
+
+-- Add Foreign key
+ALTER TABLE "quota_rules"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "quota_usages"
+ADD FOREIGN KEY ("customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "quota_usages"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "quota_usages"
+ADD FOREIGN KEY ("quota_rule_id") REFERENCES "quota_rules" ("id");
+ALTER TABLE "quota_usages"
+ADD FOREIGN KEY ("sale_id") REFERENCES "sales" ("id");
@@ -0,0 +1,47 @@
+-- sales with more than one item keep only their first item
+ALTER TABLE "sales"
+ADD COLUMN "product_id" int,
+    ADD COLUMN "sale_type" varchar,
+    ADD COLUMN "quantity" int,
+    ADD COLUMN "empties_returned" int NOT NULL DEFAULT 0,
+    ADD COLUMN "unit_price" bigint,
+    ADD COLUMN "deposit_amount" bigint NOT NULL DEFAULT 0;
+
+UPDATE "sales" s
+SET "product_id" = i."product_id",
+    "sale_type" = i."sale_type",
+    "quantity" = i."quantity",
+    "empties_returned" = i."empties_returned",
+    "unit_price" = i."unit_price",
+    "deposit_amount" = i."deposit_amount"
+FROM (
+        SELECT DISTINCT ON ("sale_id") *
+        FROM "sale_items"
+        ORDER BY "sale_id",
+            "id"
+    ) i
+WHERE i."sale_id" = s."id";
+
+ALTER TABLE "sales"
+ALTER COLUMN "product_id" SET NOT NULL,
+    ALTER COLUMN "sale_type" SET NOT NULL,
+    ALTER COLUMN "quantity" SET NOT NULL,
+    ALTER COLUMN "unit_price" SET NOT NULL,
+    ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id"),
+    DROP COLUMN "status",
+    DROP COLUMN "payment_method",
+    DROP COLUMN "subtotal",
+    DROP COLUMN "discount_total",
+    DROP COLUMN "tax_total",
+    DROP COLUMN "deposit_total",
+    DROP COLUMN "total",
+    DROP COLUMN "note",
+    DROP COLUMN "voided_by",
+    DROP COLUMN "void_reason",
+    DROP COLUMN "voided_at";
+
+DROP TABLE IF EXISTS "sale_items";
+
+ALTER TABLE "products"
+DROP COLUMN "price",
+    DROP COLUMN "deposit_amount";
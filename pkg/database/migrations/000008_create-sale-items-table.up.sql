@@ -0,0 +1,76 @@
+-- list price and cylinder deposit in rupiah, used when a sale does not quote its own price
+ALTER TABLE "products"
+ADD COLUMN "price" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "deposit_amount" bigint NOT NULL DEFAULT 0;
+
+-- sale_type is one of: exchange, new_cylinder
+-- amounts are in rupiah, deposit_amount is per cylinder and
+-- line_total = quantity * (unit_price + deposit_amount) - discount_amount
+CREATE TABLE "sale_items" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "sale_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "sale_type" varchar NOT NULL,
+    "quantity" int NOT NULL,
+    "empties_returned" int NOT NULL DEFAULT 0,
+    "unit_price" bigint NOT NULL,
+    "discount_amount" bigint NOT NULL DEFAULT 0,
+    "deposit_amount" bigint NOT NULL DEFAULT 0,
+    "line_total" bigint NOT NULL
+);
+CREATE INDEX ON "sale_items" ("sale_id");
+CREATE INDEX ON "sale_items" ("product_id");
+
+INSERT INTO "sale_items" (
+        "sale_id",
+        "product_id",
+        "sale_type",
+        "quantity",
+        "empties_returned",
+        "unit_price",
+        "deposit_amount",
+        "line_total"
+    )
+SELECT "id",
+    "product_id",
+    "sale_type",
+    "quantity",
+    "empties_returned",
+    "unit_price",
+    "deposit_amount",
+    "quantity" * ("unit_price" + "deposit_amount")
+FROM "sales";
+
+-- status is one of: completed, voided
+-- payment_method is one of: cash, transfer, qris
+ALTER TABLE "sales"
+ADD COLUMN "status" varchar NOT NULL DEFAULT 'completed',
+    ADD COLUMN "payment_method" varchar NOT NULL DEFAULT 'cash',
+    ADD COLUMN "subtotal" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "discount_total" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "tax_total" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "deposit_total" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "total" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "note" varchar NOT NULL DEFAULT '',
+    ADD COLUMN "voided_by" varchar,
+    ADD COLUMN "void_reason" varchar,
+    ADD COLUMN "voided_at" timestamptz;
+
+UPDATE "sales"
+SET "subtotal" = "quantity" * "unit_price",
+    "deposit_total" = "quantity" * "deposit_amount",
+    "total" = "quantity" * ("unit_price" + "deposit_amount");
+
+ALTER TABLE "sales"
+DROP COLUMN "product_id",
+    DROP COLUMN "sale_type",
+    DROP COLUMN "quantity",
+    DROP COLUMN "empties_returned",
+    DROP COLUMN "unit_price",
+    DROP COLUMN "deposit_amount";
+
+-- Add Foreign key
+ALTER TABLE "sale_items"
+ADD FOREIGN KEY ("sale_id") REFERENCES "sales" ("id");
+ALTER TABLE "sale_items"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
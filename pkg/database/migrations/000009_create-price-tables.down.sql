@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "price_overrides";
+DROP TABLE IF EXISTS "ceiling_prices";
+DROP TABLE IF EXISTS "price_lists";
+ALTER TABLE "locations" DROP COLUMN "region";
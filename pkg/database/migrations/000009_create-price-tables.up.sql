@@ -0,0 +1,67 @@
+-- region the regulated ceiling price (HET) of the location is set for
+ALTER TABLE "locations"
+ADD COLUMN "region" varchar;
+
+-- a price applies to the product for the customer type and location it names,
+-- a null customer_type or location_id applies to all of them. Prices are in
+-- rupiah and rows are never updated except to close them with effective_to.
+CREATE TABLE "price_lists" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "product_id" int NOT NULL,
+    "customer_type" varchar,
+    "location_id" int,
+    "price" bigint NOT NULL,
+    "effective_from" timestamptz NOT NULL,
+    "effective_to" timestamptz,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("price" >= 0),
+    CHECK ("effective_to" IS NULL OR "effective_to" > "effective_from")
+);
+CREATE INDEX ON "price_lists" ("product_id", "effective_from");
+
+-- regulated ceiling price (harga eceran tertinggi) per region, in rupiah
+CREATE TABLE "ceiling_prices" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "region" varchar NOT NULL,
+    "product_id" int NOT NULL,
+    "max_price" bigint NOT NULL,
+    "effective_from" timestamptz NOT NULL,
+    "effective_to" timestamptz,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("max_price" >= 0),
+    CHECK ("effective_to" IS NULL OR "effective_to" > "effective_from")
+);
+CREATE INDEX ON "ceiling_prices" ("region", "product_id", "effective_from");
+
+-- every sale item sold above the ceiling price with an admin's approval
+CREATE TABLE "price_overrides" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "sale_id" bigint NOT NULL,
+    "sale_item_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "location_id" int NOT NULL,
+    "ceiling_price" bigint NOT NULL,
+    "charged_price" bigint NOT NULL,
+    "reason" varchar NOT NULL,
+    "approved_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "price_overrides" ("created_at");
+
+-- Add Foreign key
+ALTER TABLE "price_lists"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "price_lists"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "ceiling_prices"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "price_overrides"
+ADD FOREIGN KEY ("sale_id") REFERENCES "sales" ("id");
+ALTER TABLE "price_overrides"
+ADD FOREIGN KEY ("sale_item_id") REFERENCES "sale_items" ("id");
+ALTER TABLE "price_overrides"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "price_overrides"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
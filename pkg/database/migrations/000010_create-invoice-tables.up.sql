@@ -0,0 +1,44 @@
+-- days a credit customer has to pay an invoice
+ALTER TABLE "customers"
+ADD COLUMN "payment_term_days" int NOT NULL DEFAULT 30;
+
+-- status is one of: open, partially_paid, paid, voided
+-- amounts are in rupiah
+CREATE TABLE "invoices" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "invoice_number" varchar UNIQUE NOT NULL,
+    "sale_id" bigint UNIQUE NOT NULL,
+    "customer_id" int NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'open',
+    "amount" bigint NOT NULL,
+    "paid_amount" bigint NOT NULL DEFAULT 0,
+    "issue_date" date NOT NULL,
+    "due_date" date NOT NULL,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("paid_amount" >= 0 AND "paid_amount" <= "amount")
+);
+CREATE INDEX ON "invoices" ("customer_id", "status");
+CREATE INDEX ON "invoices" ("due_date");
+
+-- payment_method is one of: cash, transfer, qris
+CREATE TABLE "invoice_payments" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "invoice_id" bigint NOT NULL,
+    "amount" bigint NOT NULL,
+    "payment_method" varchar NOT NULL,
+    "reference" varchar NOT NULL DEFAULT '',
+    "received_by" varchar NOT NULL,
+    "paid_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("amount" > 0)
+);
+CREATE INDEX ON "invoice_payments" ("invoice_id");
+
+-- Add Foreign key
+ALTER TABLE "invoices"
+ADD FOREIGN KEY ("sale_id") REFERENCES "sales" ("id");
+ALTER TABLE "invoices"
+ADD FOREIGN KEY ("customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "invoice_payments"
+ADD FOREIGN KEY ("invoice_id") REFERENCES "invoices" ("id");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "deposit_refunds";
+DROP TABLE IF EXISTS "cylinder_deposits";
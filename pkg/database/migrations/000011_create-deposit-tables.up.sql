@@ -0,0 +1,78 @@
+-- a deposit (jaminan) paid for cylinders taken without handing empties in,
+-- customer_id is null for a walk-in customer who keeps the deposit receipt.
+-- status is one of: held, partially_refunded, refunded, voided
+-- amount_per_unit is in rupiah
+CREATE TABLE "cylinder_deposits" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "customer_id" int,
+    "product_id" int NOT NULL,
+    "location_id" int NOT NULL,
+    "sale_id" bigint,
+    "quantity" int NOT NULL,
+    "amount_per_unit" bigint NOT NULL,
+    "refunded_qty" int NOT NULL DEFAULT 0,
+    "status" varchar NOT NULL DEFAULT 'held',
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("quantity" > 0),
+    CHECK ("refunded_qty" >= 0 AND "refunded_qty" <= "quantity")
+);
+CREATE INDEX ON "cylinder_deposits" ("customer_id", "product_id");
+CREATE INDEX ON "cylinder_deposits" ("location_id", "product_id");
+CREATE INDEX ON "cylinder_deposits" ("sale_id");
+
+-- empties handed back against a deposit, amount is in rupiah
+CREATE TABLE "deposit_refunds" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "deposit_id" bigint NOT NULL,
+    "location_id" int NOT NULL,
+    "quantity" int NOT NULL,
+    "amount" bigint NOT NULL,
+    "refunded_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("quantity" > 0)
+);
+CREATE INDEX ON "deposit_refunds" ("deposit_id");
+
+-- deposits already taken by new cylinder sales
+INSERT INTO "cylinder_deposits" (
+        "customer_id",
+        "product_id",
+        "location_id",
+        "sale_id",
+        "quantity",
+        "amount_per_unit",
+        "status",
+        "created_by",
+        "created_at"
+    )
+SELECT s."customer_id",
+    si."product_id",
+    s."location_id",
+    s."id",
+    si."quantity",
+    si."deposit_amount",
+    CASE
+        WHEN s."status" = 'voided' THEN 'voided'
+        ELSE 'held'
+    END,
+    s."created_by",
+    s."created_at"
+FROM "sale_items" si
+    JOIN "sales" s ON s."id" = si."sale_id"
+WHERE si."sale_type" = 'new_cylinder';
+
+-- Add Foreign key
+ALTER TABLE "cylinder_deposits"
+ADD FOREIGN KEY ("customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "cylinder_deposits"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "cylinder_deposits"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "cylinder_deposits"
+ADD FOREIGN KEY ("sale_id") REFERENCES "sales" ("id");
+ALTER TABLE "deposit_refunds"
+ADD FOREIGN KEY ("deposit_id") REFERENCES "cylinder_deposits" ("id");
+ALTER TABLE "deposit_refunds"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
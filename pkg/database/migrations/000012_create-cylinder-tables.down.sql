@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "cylinder_movements";
+DROP TABLE IF EXISTS "cylinders";
@@ -0,0 +1,63 @@
+-- a cylinder tracked by its stamped serial number and, optionally, the QR
+-- code or barcode attached to it.
+-- owner_type is one of: company, customer
+-- status is one of: full, empty, in_repair, condemned
+-- a cylinder is either at location_id or held by holder_customer_id
+CREATE TABLE "cylinders" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "serial_number" varchar UNIQUE NOT NULL,
+    "qr_code" varchar UNIQUE,
+    "product_id" int NOT NULL,
+    "manufacture_date" date NOT NULL,
+    "tare_weight_grams" int NOT NULL,
+    "owner_type" varchar NOT NULL DEFAULT 'company',
+    "owner_customer_id" int,
+    "status" varchar NOT NULL,
+    "location_id" int,
+    "holder_customer_id" int,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "cylinders" ("product_id");
+CREATE INDEX ON "cylinders" ("location_id");
+CREATE INDEX ON "cylinders" ("holder_customer_id");
+CREATE INDEX ON "cylinders" ("status");
+
+-- every change of place, holder or status of a cylinder
+CREATE TABLE "cylinder_movements" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "cylinder_id" bigint NOT NULL,
+    "from_location_id" int,
+    "to_location_id" int,
+    "from_customer_id" int,
+    "to_customer_id" int,
+    "status" varchar NOT NULL,
+    "reason" varchar NOT NULL,
+    "reference_type" varchar,
+    "reference_id" bigint,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "cylinder_movements" ("cylinder_id", "created_at");
+CREATE INDEX ON "cylinder_movements" ("to_location_id", "created_at");
+CREATE INDEX ON "cylinder_movements" ("to_customer_id", "created_at");
+
+-- Add Foreign key
+ALTER TABLE "cylinders"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "cylinders"
+ADD FOREIGN KEY ("owner_customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "cylinders"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "cylinders"
+ADD FOREIGN KEY ("holder_customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "cylinder_movements"
+ADD FOREIGN KEY ("cylinder_id") REFERENCES "cylinders" ("id");
+ALTER TABLE "cylinder_movements"
+ADD FOREIGN KEY ("from_location_id") REFERENCES "locations" ("id");
+ALTER TABLE "cylinder_movements"
+ADD FOREIGN KEY ("to_location_id") REFERENCES "locations" ("id");
+ALTER TABLE "cylinder_movements"
+ADD FOREIGN KEY ("from_customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "cylinder_movements"
+ADD FOREIGN KEY ("to_customer_id") REFERENCES "customers" ("id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "cylinder_inspections";
+ALTER TABLE "cylinders" DROP COLUMN "test_due_flagged_at";
+ALTER TABLE "cylinders" DROP COLUMN "next_test_due";
+ALTER TABLE "cylinders" DROP COLUMN "last_test_result";
+ALTER TABLE "cylinders" DROP COLUMN "last_test_date";
@@ -0,0 +1,33 @@
+-- re-test schedule of each cylinder, a cylinder never tested is due five
+-- years after it was made. test_due_flagged_at is set by the daily job once
+-- the cylinder comes due within the configured window.
+ALTER TABLE "cylinders"
+ADD COLUMN "last_test_date" date,
+    ADD COLUMN "last_test_result" varchar,
+    ADD COLUMN "next_test_due" date,
+    ADD COLUMN "test_due_flagged_at" timestamptz;
+
+UPDATE "cylinders"
+SET "next_test_due" = "manufacture_date" + interval '5 years';
+
+ALTER TABLE "cylinders"
+ALTER COLUMN "next_test_due" SET NOT NULL;
+CREATE INDEX ON "cylinders" ("next_test_due");
+
+-- result is one of: pass, fail
+CREATE TABLE "cylinder_inspections" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "cylinder_id" bigint NOT NULL,
+    "test_date" date NOT NULL,
+    "result" varchar NOT NULL,
+    "next_due_date" date,
+    "inspector" varchar NOT NULL,
+    "note" varchar NOT NULL DEFAULT '',
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "cylinder_inspections" ("cylinder_id", "test_date");
+
+-- Add Foreign key
+ALTER TABLE "cylinder_inspections"
+ADD FOREIGN KEY ("cylinder_id") REFERENCES "cylinders" ("id");
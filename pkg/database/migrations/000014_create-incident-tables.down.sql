@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "incident_photos";
+DROP TABLE IF EXISTS "incidents";
+ALTER TABLE "stock_movements" DROP COLUMN "quarantine_qty_change";
+ALTER TABLE "stock_balances" DROP COLUMN "quarantine_qty";
@@ -0,0 +1,48 @@
+-- cylinders set aside after an incident, they are counted in neither the
+-- full nor the empty quantity so they can never be sold or dispatched
+ALTER TABLE "stock_balances"
+ADD COLUMN "quarantine_qty" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "stock_movements"
+ADD COLUMN "quarantine_qty_change" int NOT NULL DEFAULT 0;
+
+-- incident_type is one of: damaged, leaking
+-- cylinder_state is what the cylinder was counted as before quarantine: full, empty
+-- status is one of: quarantined, repaired, returned_to_supplier, scrapped
+-- cylinder_id is set when the cylinder is serialized
+CREATE TABLE "incidents" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "incident_type" varchar NOT NULL,
+    "location_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "cylinder_id" bigint,
+    "cylinder_state" varchar NOT NULL,
+    "reason" varchar NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'quarantined',
+    "reported_by" varchar NOT NULL,
+    "reported_at" timestamptz NOT NULL DEFAULT (now()),
+    "disposition_note" varchar,
+    "disposed_by" varchar,
+    "disposed_at" timestamptz
+);
+CREATE INDEX ON "incidents" ("status");
+CREATE INDEX ON "incidents" ("reported_at");
+CREATE INDEX ON "incidents" ("cylinder_id");
+
+CREATE TABLE "incident_photos" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "incident_id" bigint NOT NULL,
+    "url" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "incident_photos" ("incident_id");
+
+-- Add Foreign key
+ALTER TABLE "incidents"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "incidents"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "incidents"
+ADD FOREIGN KEY ("cylinder_id") REFERENCES "cylinders" ("id");
+ALTER TABLE "incident_photos"
+ADD FOREIGN KEY ("incident_id") REFERENCES "incidents" ("id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "stock_count_entries";
+DROP TABLE IF EXISTS "stock_count_lines";
+DROP TABLE IF EXISTS "stock_counts";
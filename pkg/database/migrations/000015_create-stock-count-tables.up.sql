@@ -0,0 +1,51 @@
+-- status is one of: counting, approved, cancelled
+CREATE TABLE "stock_counts" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "location_id" int NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'counting',
+    "note" varchar NOT NULL DEFAULT '',
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "approved_by" varchar,
+    "approved_at" timestamptz
+);
+CREATE INDEX ON "stock_counts" ("location_id", "status");
+-- a location is counted by one session at a time
+CREATE UNIQUE INDEX ON "stock_counts" ("location_id")
+WHERE "status" = 'counting';
+
+-- expected quantities are the balance frozen when the count was opened, the
+-- counted quantities are those of the latest pass
+CREATE TABLE "stock_count_lines" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "stock_count_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "expected_full_qty" int NOT NULL,
+    "expected_empty_qty" int NOT NULL,
+    "counted_full_qty" int,
+    "counted_empty_qty" int,
+    "counted_by" varchar,
+    "counted_at" timestamptz,
+    UNIQUE ("stock_count_id", "product_id")
+);
+
+-- every pass submitted by a counter
+CREATE TABLE "stock_count_entries" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "stock_count_line_id" bigint NOT NULL,
+    "counted_full_qty" int NOT NULL,
+    "counted_empty_qty" int NOT NULL,
+    "counted_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "stock_count_entries" ("stock_count_line_id");
+
+-- Add Foreign key
+ALTER TABLE "stock_counts"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "stock_count_lines"
+ADD FOREIGN KEY ("stock_count_id") REFERENCES "stock_counts" ("id");
+ALTER TABLE "stock_count_lines"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "stock_count_entries"
+ADD FOREIGN KEY ("stock_count_line_id") REFERENCES "stock_count_lines" ("id");
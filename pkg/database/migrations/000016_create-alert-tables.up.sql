@@ -0,0 +1,43 @@
+-- levels of available full cylinders a location should hold: below min_qty
+-- it is short, at or below reorder_point it should reorder up to max_qty
+CREATE TABLE "stock_thresholds" (
+    "location_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "min_qty" int NOT NULL,
+    "reorder_point" int NOT NULL,
+    "max_qty" int NOT NULL,
+    "updated_by" varchar NOT NULL,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("location_id", "product_id"),
+    CHECK ("min_qty" >= 0),
+    CHECK ("reorder_point" >= "min_qty"),
+    CHECK ("max_qty" >= "reorder_point")
+);
+
+-- alert_type is one of: below_min, reorder, above_max
+-- status is one of: open, resolved
+CREATE TABLE "stock_alerts" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "location_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "alert_type" varchar NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'open',
+    "available_qty" int NOT NULL,
+    "threshold_qty" int NOT NULL,
+    "raised_at" timestamptz NOT NULL DEFAULT (now()),
+    "resolved_at" timestamptz
+);
+CREATE INDEX ON "stock_alerts" ("status", "raised_at");
+-- an alert stays open once for as long as its level is crossed
+CREATE UNIQUE INDEX ON "stock_alerts" ("location_id", "product_id", "alert_type")
+WHERE "status" = 'open';
+
+-- Add Foreign key
+ALTER TABLE "stock_thresholds"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "stock_thresholds"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "stock_alerts"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "stock_alerts"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
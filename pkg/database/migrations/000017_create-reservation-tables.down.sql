@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "stock_reservations";
+
+ALTER TABLE "stock_balances" DROP COLUMN IF EXISTS "available_qty";
+ALTER TABLE "stock_balances" DROP COLUMN IF EXISTS "reserved_qty";
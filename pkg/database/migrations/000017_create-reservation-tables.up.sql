@@ -0,0 +1,34 @@
+-- full cylinders held back for a confirmed order, they are still on hand
+-- but can no longer be sold at the counter
+ALTER TABLE "stock_balances"
+ADD COLUMN "reserved_qty" int NOT NULL DEFAULT 0;
+ALTER TABLE "stock_balances"
+ADD COLUMN "available_qty" int NOT NULL GENERATED ALWAYS AS ("full_qty" - "reserved_qty") STORED;
+ALTER TABLE "stock_balances"
+ADD CHECK ("reserved_qty" >= 0);
+
+-- reference_type is the order holding the stock, one of: delivery_order
+-- status is one of: active, released, expired, fulfilled
+-- an active reservation past expires_at is released by the worker
+CREATE TABLE "stock_reservations" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "location_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "quantity" int NOT NULL,
+    "reference_type" varchar NOT NULL,
+    "reference_id" bigint NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'active',
+    "expires_at" timestamptz NOT NULL,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "closed_at" timestamptz,
+    CHECK ("quantity" > 0)
+);
+CREATE INDEX ON "stock_reservations" ("reference_type", "reference_id");
+CREATE INDEX ON "stock_reservations" ("status", "expires_at");
+
+-- Add Foreign key
+ALTER TABLE "stock_reservations"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "stock_reservations"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "delivery_stop_items";
+DROP TABLE IF EXISTS "delivery_stops";
+DROP TABLE IF EXISTS "delivery_orders";
+DROP TABLE IF EXISTS "drivers";
+DROP TABLE IF EXISTS "vehicle_capacities";
+DROP TABLE IF EXISTS "vehicles";
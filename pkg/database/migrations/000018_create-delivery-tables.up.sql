@@ -0,0 +1,104 @@
+-- every vehicle has a location of type vehicle holding the stock it carries
+CREATE TABLE "vehicles" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "plate_number" varchar NOT NULL,
+    "location_id" int NOT NULL,
+    "is_active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "vehicles" ("plate_number");
+CREATE UNIQUE INDEX ON "vehicles" ("location_id");
+
+-- capacity is the number of cylinders of the product the vehicle can carry
+CREATE TABLE "vehicle_capacities" (
+    "vehicle_id" int NOT NULL,
+    "product_id" int NOT NULL,
+    "capacity" int NOT NULL,
+    PRIMARY KEY ("vehicle_id", "product_id"),
+    CHECK ("capacity" >= 0)
+);
+
+CREATE TABLE "drivers" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "user_id" int NOT NULL,
+    "license_number" varchar NOT NULL,
+    "phone" varchar NOT NULL DEFAULT '',
+    "is_active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "drivers" ("user_id");
+
+-- status is one of: planned, confirmed, loaded, en_route, delivered, reconciled, cancelled
+CREATE TABLE "delivery_orders" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "source_location_id" int NOT NULL,
+    "vehicle_id" int NOT NULL,
+    "driver_id" int NOT NULL,
+    "scheduled_date" date NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'planned',
+    "note" varchar NOT NULL DEFAULT '',
+    "created_by" varchar NOT NULL,
+    "confirmed_at" timestamptz,
+    "loaded_at" timestamptz,
+    "departed_at" timestamptz,
+    "delivered_at" timestamptz,
+    "reconciled_at" timestamptz,
+    "cancelled_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "delivery_orders" ("scheduled_date", "status");
+CREATE INDEX ON "delivery_orders" ("vehicle_id");
+
+-- status is one of: pending, completed, failed
+-- sale_id is the sale posted when the stop is completed
+CREATE TABLE "delivery_stops" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "delivery_order_id" bigint NOT NULL,
+    "sequence" int NOT NULL,
+    "customer_id" int NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "sale_id" bigint,
+    "note" varchar NOT NULL DEFAULT '',
+    "completed_by" varchar,
+    "completed_at" timestamptz
+);
+CREATE UNIQUE INDEX ON "delivery_stops" ("delivery_order_id", "sequence");
+
+-- quantity full cylinders are to be dropped and empties_to_collect empties
+-- picked up, delivered_qty and empties_collected are what actually happened
+CREATE TABLE "delivery_stop_items" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "delivery_stop_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "quantity" int NOT NULL,
+    "empties_to_collect" int NOT NULL DEFAULT 0,
+    "delivered_qty" int NOT NULL DEFAULT 0,
+    "empties_collected" int NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX ON "delivery_stop_items" ("delivery_stop_id", "product_id");
+
+-- Add Foreign key
+ALTER TABLE "vehicles"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "vehicle_capacities"
+ADD FOREIGN KEY ("vehicle_id") REFERENCES "vehicles" ("id");
+ALTER TABLE "vehicle_capacities"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "drivers"
+ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "delivery_orders"
+ADD FOREIGN KEY ("source_location_id") REFERENCES "locations" ("id");
+ALTER TABLE "delivery_orders"
+ADD FOREIGN KEY ("vehicle_id") REFERENCES "vehicles" ("id");
+ALTER TABLE "delivery_orders"
+ADD FOREIGN KEY ("driver_id") REFERENCES "drivers" ("id");
+ALTER TABLE "delivery_stops"
+ADD FOREIGN KEY ("delivery_order_id") REFERENCES "delivery_orders" ("id");
+ALTER TABLE "delivery_stops"
+ADD FOREIGN KEY ("customer_id") REFERENCES "customers" ("id");
+ALTER TABLE "delivery_stops"
+ADD FOREIGN KEY ("sale_id") REFERENCES "sales" ("id");
+ALTER TABLE "delivery_stop_items"
+ADD FOREIGN KEY ("delivery_stop_id") REFERENCES "delivery_stops" ("id");
+ALTER TABLE "delivery_stop_items"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
@@ -0,0 +1,7 @@
+ALTER TABLE "delivery_stops" DROP COLUMN IF EXISTS "proof_captured_at";
+ALTER TABLE "delivery_stops" DROP COLUMN IF EXISTS "proof_captured_by";
+ALTER TABLE "delivery_stops" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "delivery_stops" DROP COLUMN IF EXISTS "latitude";
+ALTER TABLE "delivery_stops" DROP COLUMN IF EXISTS "photo_key";
+ALTER TABLE "delivery_stops" DROP COLUMN IF EXISTS "signature_key";
+ALTER TABLE "delivery_stops" DROP COLUMN IF EXISTS "recipient_name";
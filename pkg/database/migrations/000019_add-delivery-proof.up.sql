@@ -0,0 +1,16 @@
+-- proof of delivery captured by the driver at a completed stop, the
+-- signature and photo are keys of the uploaded files in storage
+ALTER TABLE "delivery_stops"
+ADD COLUMN "recipient_name" varchar;
+ALTER TABLE "delivery_stops"
+ADD COLUMN "signature_key" varchar;
+ALTER TABLE "delivery_stops"
+ADD COLUMN "photo_key" varchar;
+ALTER TABLE "delivery_stops"
+ADD COLUMN "latitude" double precision;
+ALTER TABLE "delivery_stops"
+ADD COLUMN "longitude" double precision;
+ALTER TABLE "delivery_stops"
+ADD COLUMN "proof_captured_by" varchar;
+ALTER TABLE "delivery_stops"
+ADD COLUMN "proof_captured_at" timestamptz;
@@ -0,0 +1,4 @@
+ALTER TABLE "customers" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "customers" DROP COLUMN IF EXISTS "latitude";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "latitude";
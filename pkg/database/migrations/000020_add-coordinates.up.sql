@@ -0,0 +1,9 @@
+-- coordinates in decimal degrees, used to plan delivery routes
+ALTER TABLE "locations"
+ADD COLUMN "latitude" double precision;
+ALTER TABLE "locations"
+ADD COLUMN "longitude" double precision;
+ALTER TABLE "customers"
+ADD COLUMN "latitude" double precision;
+ALTER TABLE "customers"
+ADD COLUMN "longitude" double precision;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "driver_shortages";
+DROP TABLE IF EXISTS "delivery_reconciliation_items";
+DROP TABLE IF EXISTS "delivery_reconciliations";
@@ -0,0 +1,60 @@
+-- what the driver reported when the vehicle came back, against what the
+-- order says should be on it; cash_variance is reported_cash - expected_cash
+CREATE TABLE "delivery_reconciliations" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "delivery_order_id" bigint NOT NULL,
+    "driver_id" int NOT NULL,
+    "expected_cash" bigint NOT NULL,
+    "reported_cash" bigint NOT NULL,
+    "cash_variance" bigint NOT NULL,
+    "note" varchar NOT NULL DEFAULT '',
+    "reconciled_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "delivery_reconciliations" ("delivery_order_id");
+
+-- expected full cylinders are loaded_qty - delivered_qty, expected empties
+-- are the empties collected at the stops
+CREATE TABLE "delivery_reconciliation_items" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "reconciliation_id" bigint NOT NULL,
+    "product_id" int NOT NULL,
+    "loaded_qty" int NOT NULL,
+    "delivered_qty" int NOT NULL,
+    "reported_full_qty" int NOT NULL,
+    "full_qty_short" int NOT NULL DEFAULT 0,
+    "empties_collected" int NOT NULL,
+    "reported_empty_qty" int NOT NULL,
+    "empty_qty_short" int NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX ON "delivery_reconciliation_items" ("reconciliation_id", "product_id");
+
+-- shortages held against a driver, product_id is null for a cash shortage
+CREATE TABLE "driver_shortages" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "driver_id" int NOT NULL,
+    "delivery_order_id" bigint NOT NULL,
+    "product_id" int,
+    "full_qty_short" int NOT NULL DEFAULT 0,
+    "empty_qty_short" int NOT NULL DEFAULT 0,
+    "cash_short" bigint NOT NULL DEFAULT 0,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX ON "driver_shortages" ("driver_id", "created_at");
+
+-- Add Foreign key
+ALTER TABLE "delivery_reconciliations"
+ADD FOREIGN KEY ("delivery_order_id") REFERENCES "delivery_orders" ("id");
+ALTER TABLE "delivery_reconciliations"
+ADD FOREIGN KEY ("driver_id") REFERENCES "drivers" ("id");
+ALTER TABLE "delivery_reconciliation_items"
+ADD FOREIGN KEY ("reconciliation_id") REFERENCES "delivery_reconciliations" ("id");
+ALTER TABLE "delivery_reconciliation_items"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+ALTER TABLE "driver_shortages"
+ADD FOREIGN KEY ("driver_id") REFERENCES "drivers" ("id");
+ALTER TABLE "driver_shortages"
+ADD FOREIGN KEY ("delivery_order_id") REFERENCES "delivery_orders" ("id");
+ALTER TABLE "driver_shortages"
+ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "daily_closings";
+ALTER TABLE "deposit_refunds" DROP COLUMN IF EXISTS "cash_session_id";
+ALTER TABLE "invoice_payments" DROP COLUMN IF EXISTS "cash_session_id";
+ALTER TABLE "sales" DROP COLUMN IF EXISTS "cash_session_id";
+DROP TABLE IF EXISTS "cash_sessions";
@@ -0,0 +1,74 @@
+-- status is one of: open, closed
+-- a cashier has at most one open session, the totals and expected_cash are
+-- computed when the session is closed and variance is counted_cash - expected_cash
+CREATE TABLE "cash_sessions" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "location_id" int NOT NULL,
+    "cashier" varchar NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'open',
+    "opening_float" bigint NOT NULL,
+    "cash_total" bigint NOT NULL DEFAULT 0,
+    "transfer_total" bigint NOT NULL DEFAULT 0,
+    "qris_total" bigint NOT NULL DEFAULT 0,
+    "refund_total" bigint NOT NULL DEFAULT 0,
+    "expected_cash" bigint NOT NULL DEFAULT 0,
+    "counted_cash" bigint,
+    "variance" bigint,
+    "note" varchar NOT NULL DEFAULT '',
+    "opened_at" timestamptz NOT NULL DEFAULT (now()),
+    "closed_by" varchar,
+    "closed_at" timestamptz,
+    CHECK ("opening_float" >= 0)
+);
+CREATE UNIQUE INDEX ON "cash_sessions" ("cashier")
+WHERE "status" = 'open';
+CREATE INDEX ON "cash_sessions" ("location_id", "opened_at");
+
+-- payments taken and refunds paid out during a cash session
+ALTER TABLE "sales"
+ADD COLUMN "cash_session_id" bigint;
+ALTER TABLE "invoice_payments"
+ADD COLUMN "cash_session_id" bigint;
+ALTER TABLE "deposit_refunds"
+ADD COLUMN "cash_session_id" bigint;
+CREATE INDEX ON "sales" ("cash_session_id");
+CREATE INDEX ON "invoice_payments" ("cash_session_id");
+CREATE INDEX ON "deposit_refunds" ("cash_session_id");
+
+-- status is one of: submitted, approved
+-- the totals are those of the sessions opened at the location on
+-- business_date, once approved the sales of that day are locked
+CREATE TABLE "daily_closings" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "location_id" int NOT NULL,
+    "business_date" date NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'submitted',
+    "session_count" int NOT NULL,
+    "opening_float" bigint NOT NULL,
+    "cash_total" bigint NOT NULL,
+    "transfer_total" bigint NOT NULL,
+    "qris_total" bigint NOT NULL,
+    "refund_total" bigint NOT NULL,
+    "credit_total" bigint NOT NULL,
+    "expected_cash" bigint NOT NULL,
+    "counted_cash" bigint NOT NULL,
+    "variance" bigint NOT NULL,
+    "submitted_by" varchar NOT NULL,
+    "approved_by" varchar,
+    "approved_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+CREATE UNIQUE INDEX ON "daily_closings" ("location_id", "business_date");
+
+-- Add Foreign key
+ALTER TABLE "cash_sessions"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "sales"
+ADD FOREIGN KEY ("cash_session_id") REFERENCES "cash_sessions" ("id");
+ALTER TABLE "invoice_payments"
+ADD FOREIGN KEY ("cash_session_id") REFERENCES "cash_sessions" ("id");
+ALTER TABLE "deposit_refunds"
+ADD FOREIGN KEY ("cash_session_id") REFERENCES "cash_sessions" ("id");
+ALTER TABLE "daily_closings"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
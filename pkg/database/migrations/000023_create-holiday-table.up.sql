@@ -0,0 +1,14 @@
+-- days of unusual demand such as Lebaran, demand from starts_on to ends_on is
+-- expected to be demand_multiplier times that of an ordinary day
+CREATE TABLE "holidays" (
+    "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "starts_on" date NOT NULL,
+    "ends_on" date NOT NULL,
+    "demand_multiplier" double precision NOT NULL DEFAULT 1,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("ends_on" >= "starts_on"),
+    CHECK ("demand_multiplier" > 0)
+);
+CREATE INDEX ON "holidays" ("starts_on");
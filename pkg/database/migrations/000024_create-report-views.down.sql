@@ -0,0 +1,3 @@
+DROP MATERIALIZED VIEW IF EXISTS "daily_movement_summary";
+DROP MATERIALIZED VIEW IF EXISTS "daily_customer_sales";
+DROP MATERIALIZED VIEW IF EXISTS "daily_sales_summary";
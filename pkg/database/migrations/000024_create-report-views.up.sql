@@ -0,0 +1,45 @@
+-- daily aggregates of the sales and stock ledgers the reports read from, so
+-- that a report over months touches one row per day instead of every line.
+-- They are refreshed by the worker and lag the ledger by up to one interval.
+CREATE MATERIALIZED VIEW "daily_sales_summary" AS
+SELECT s."created_at"::date AS "day",
+    s."location_id",
+    si."product_id",
+    count(DISTINCT s."id")::int AS "sale_count",
+    sum(si."quantity")::bigint AS "quantity",
+    sum(si."empties_returned")::bigint AS "empties_returned",
+    sum(si."quantity" * si."unit_price")::bigint AS "gross_amount",
+    sum(si."discount_amount")::bigint AS "discount_amount",
+    sum(si."deposit_amount")::bigint AS "deposit_amount",
+    sum(si."line_total")::bigint AS "net_amount"
+FROM "sale_items" si
+    JOIN "sales" s ON s."id" = si."sale_id"
+WHERE s."status" = 'completed'
+GROUP BY 1, 2, 3;
+CREATE UNIQUE INDEX ON "daily_sales_summary" ("day", "location_id", "product_id");
+
+CREATE MATERIALIZED VIEW "daily_customer_sales" AS
+SELECT "created_at"::date AS "day",
+    "location_id",
+    "customer_id",
+    count(*)::int AS "sale_count",
+    sum("total")::bigint AS "total_amount"
+FROM "sales"
+WHERE "status" = 'completed'
+    AND "customer_id" IS NOT NULL
+GROUP BY 1, 2, 3;
+CREATE UNIQUE INDEX ON "daily_customer_sales" ("day", "location_id", "customer_id");
+
+CREATE MATERIALIZED VIEW "daily_movement_summary" AS
+SELECT "created_at"::date AS "day",
+    "location_id",
+    "product_id",
+    "reason",
+    count(*)::int AS "movement_count",
+    sum(greatest("full_qty_change", 0))::bigint AS "full_qty_in",
+    sum(greatest(- "full_qty_change", 0))::bigint AS "full_qty_out",
+    sum(greatest("empty_qty_change", 0))::bigint AS "empty_qty_in",
+    sum(greatest(- "empty_qty_change", 0))::bigint AS "empty_qty_out"
+FROM "stock_movements"
+GROUP BY 1, 2, 3, 4;
+CREATE UNIQUE INDEX ON "daily_movement_summary" ("day", "location_id", "product_id", "reason");
@@ -0,0 +1,40 @@
+CREATE TABLE "import_jobs" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    -- kind is one of: products, customers, opening_stock
+    "kind" varchar NOT NULL,
+    -- status is one of: pending, running, completed, validated, rejected, failed
+    "status" varchar NOT NULL DEFAULT 'pending',
+    -- a dry run checks every row and is always rolled back
+    "dry_run" boolean NOT NULL DEFAULT false,
+    -- where an opening stock import puts its stock
+    "location_id" int,
+    "file_key" varchar NOT NULL,
+    "file_name" varchar NOT NULL,
+    "total_rows" int NOT NULL DEFAULT 0,
+    "created_rows" int NOT NULL DEFAULT 0,
+    "updated_rows" int NOT NULL DEFAULT 0,
+    "error_rows" int NOT NULL DEFAULT 0,
+    -- why the file could not be imported at all
+    "error" varchar,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "started_at" timestamptz,
+    "finished_at" timestamptz
+);
+CREATE INDEX ON "import_jobs" ("status", "id");
+
+CREATE TABLE "import_job_errors" (
+    "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "import_job_id" bigint NOT NULL,
+    -- the line of the file, the header being line 1
+    "row_number" int NOT NULL,
+    "column_name" varchar NOT NULL DEFAULT '',
+    "message" varchar NOT NULL
+);
+CREATE INDEX ON "import_job_errors" ("import_job_id", "row_number");
+
+-- Add Foreign key
+ALTER TABLE "import_jobs"
+ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+ALTER TABLE "import_job_errors"
+ADD FOREIGN KEY ("import_job_id") REFERENCES "import_jobs" ("id");
@@ -0,0 +1,3 @@
+ALTER TABLE "price_overrides" DROP COLUMN "list_price";
+ALTER TABLE "price_overrides"
+ALTER COLUMN "ceiling_price" SET NOT NULL;
@@ -0,0 +1,6 @@
+-- a price override is also logged for a price other than the price list's,
+-- ceiling_price is null when the region has none
+ALTER TABLE "price_overrides"
+ADD COLUMN "list_price" bigint;
+ALTER TABLE "price_overrides"
+ALTER COLUMN "ceiling_price" DROP NOT NULL;
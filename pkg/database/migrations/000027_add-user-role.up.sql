@@ -0,0 +1,3 @@
+-- role is one of: staff, admin
+ALTER TABLE "users"
+ADD COLUMN "role" varchar NOT NULL DEFAULT 'staff';
@@ -0,0 +1 @@
+ALTER TABLE "cash_sessions" DROP COLUMN "business_date";
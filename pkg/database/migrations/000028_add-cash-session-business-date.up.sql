@@ -0,0 +1,11 @@
+-- business_date is the day a cash session counts towards, a shift running
+-- past midnight stays on the day it was opened
+ALTER TABLE "cash_sessions"
+ADD COLUMN "business_date" date;
+UPDATE "cash_sessions"
+SET "business_date" = "opened_at"::date;
+ALTER TABLE "cash_sessions"
+ALTER COLUMN "business_date" SET NOT NULL;
+ALTER TABLE "cash_sessions"
+ALTER COLUMN "business_date" SET DEFAULT (now()::date);
+CREATE INDEX ON "cash_sessions" ("location_id", "business_date");
@@ -0,0 +1,19 @@
+-- deposit_amount of a sale item is per cylinder, the summary adds up the
+-- deposit of every cylinder so that gross - discount + deposit = net
+DROP MATERIALIZED VIEW "daily_sales_summary";
+CREATE MATERIALIZED VIEW "daily_sales_summary" AS
+SELECT s."created_at"::date AS "day",
+    s."location_id",
+    si."product_id",
+    count(DISTINCT s."id")::int AS "sale_count",
+    sum(si."quantity")::bigint AS "quantity",
+    sum(si."empties_returned")::bigint AS "empties_returned",
+    sum(si."quantity" * si."unit_price")::bigint AS "gross_amount",
+    sum(si."discount_amount")::bigint AS "discount_amount",
+    sum(si."quantity" * si."deposit_amount")::bigint AS "deposit_amount",
+    sum(si."line_total")::bigint AS "net_amount"
+FROM "sale_items" si
+    JOIN "sales" s ON s."id" = si."sale_id"
+WHERE s."status" = 'completed'
+GROUP BY 1, 2, 3;
+CREATE UNIQUE INDEX ON "daily_sales_summary" ("day", "location_id", "product_id");
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "cylinder_movements_reference_type_reference_id_idx";
@@ -0,0 +1,2 @@
+-- a voided sale looks up the cylinders it moved
+CREATE INDEX ON "cylinder_movements" ("reference_type", "reference_id");
@@ -0,0 +1 @@
+ALTER TABLE "incident_photos" DROP COLUMN "storage_key";
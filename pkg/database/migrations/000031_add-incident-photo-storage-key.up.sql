@@ -0,0 +1,5 @@
+-- photos are uploaded to the file storage, url is where the API serves them.
+-- Photos linked before uploads keep their url and have no key.
+ALTER TABLE "incident_photos"
+ADD COLUMN "storage_key" varchar;
+CREATE UNIQUE INDEX ON "incident_photos" ("storage_key");
@@ -0,0 +1,94 @@
+-- name: UpsertStockThreshold :one
+INSERT INTO stock_thresholds (
+        location_id,
+        product_id,
+        min_qty,
+        reorder_point,
+        max_qty,
+        updated_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (location_id, product_id) DO
+UPDATE
+SET min_qty = EXCLUDED.min_qty,
+    reorder_point = EXCLUDED.reorder_point,
+    max_qty = EXCLUDED.max_qty,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+-- name: ListStockThresholds :many
+SELECT *
+FROM stock_thresholds
+WHERE sqlc.narg(location_id)::int IS NULL
+    OR location_id = sqlc.narg(location_id)
+ORDER BY location_id,
+    product_id;
+-- name: DeleteStockThreshold :exec
+DELETE FROM stock_thresholds
+WHERE location_id = $1
+    AND product_id = $2;
+-- name: ListThresholdLevels :many
+SELECT t.location_id,
+    t.product_id,
+    t.min_qty,
+    t.reorder_point,
+    t.max_qty,
+    COALESCE(b.available_qty, 0)::int AS available_qty
+FROM stock_thresholds t
+    LEFT JOIN stock_balances b ON b.location_id = t.location_id
+    AND b.product_id = t.product_id
+WHERE sqlc.narg(location_id)::int IS NULL
+    OR t.location_id = sqlc.narg(location_id)
+ORDER BY t.location_id,
+    t.product_id;
+-- name: RaiseStockAlert :execrows
+INSERT INTO stock_alerts (
+        location_id,
+        product_id,
+        alert_type,
+        available_qty,
+        threshold_qty
+    )
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT (location_id, product_id, alert_type)
+WHERE status = 'open' DO NOTHING;
+-- name: ResolveStockAlerts :execrows
+UPDATE stock_alerts
+SET status = 'resolved',
+    resolved_at = now()
+WHERE location_id = $1
+    AND product_id = $2
+    AND alert_type = $3
+    AND status = 'open';
+-- name: ResolveOrphanStockAlerts :execrows
+UPDATE stock_alerts a
+SET status = 'resolved',
+    resolved_at = now()
+WHERE a.status = 'open'
+    AND NOT EXISTS (
+        SELECT 1
+        FROM stock_thresholds t
+        WHERE t.location_id = a.location_id
+            AND t.product_id = a.product_id
+    );
+-- name: ListStockAlerts :many
+SELECT *
+FROM stock_alerts
+WHERE (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+    AND (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+ORDER BY raised_at DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: SumOnOrder :many
+SELECT po.location_id,
+    poi.product_id,
+    sum(poi.ordered_qty - poi.received_qty)::bigint AS on_order_qty
+FROM purchase_order_items poi
+    JOIN purchase_orders po ON po.id = poi.purchase_order_id
+WHERE po.status IN ('ordered', 'partially_received')
+    AND poi.received_qty < poi.ordered_qty
+GROUP BY po.location_id,
+    poi.product_id;
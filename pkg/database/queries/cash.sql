@@ -0,0 +1,154 @@
+-- name: CreateCashSession :one
+INSERT INTO cash_sessions (location_id, cashier, opening_float, note)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: GetCashSession :one
+SELECT *
+FROM cash_sessions
+WHERE id = $1
+LIMIT 1;
+-- name: GetCashSessionForUpdate :one
+SELECT *
+FROM cash_sessions
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: GetOpenCashSession :one
+SELECT *
+FROM cash_sessions
+WHERE cashier = $1
+    AND status = 'open'
+LIMIT 1;
+-- name: ListCashSessions :many
+SELECT *
+FROM cash_sessions
+WHERE (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: SumCashSessionSales :many
+SELECT payment_method,
+    sum(total)::bigint AS amount
+FROM sales
+WHERE cash_session_id = $1
+    AND status = 'completed'
+GROUP BY payment_method
+ORDER BY payment_method;
+-- name: SumCashSessionInvoicePayments :many
+SELECT payment_method,
+    sum(amount)::bigint AS amount
+FROM invoice_payments
+WHERE cash_session_id = $1
+GROUP BY payment_method
+ORDER BY payment_method;
+-- name: SumCashSessionRefunds :one
+SELECT COALESCE(sum(amount), 0)::bigint AS amount
+FROM deposit_refunds
+WHERE cash_session_id = $1;
+-- name: CloseCashSession :one
+UPDATE cash_sessions
+SET status = 'closed',
+    cash_total = $2,
+    transfer_total = $3,
+    qris_total = $4,
+    refund_total = $5,
+    expected_cash = $6,
+    counted_cash = $7,
+    variance = $8,
+    note = $9,
+    closed_by = $10,
+    closed_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: ListDayCashSessions :many
+SELECT *
+FROM cash_sessions
+WHERE location_id = sqlc.arg(location_id)
+    AND business_date = sqlc.arg(business_date)
+ORDER BY id;
+-- name: SumDayCreditSales :one
+SELECT COALESCE(sum(s.total), 0)::bigint AS amount
+FROM sales s
+    LEFT JOIN cash_sessions cs ON cs.id = s.cash_session_id
+WHERE s.location_id = sqlc.arg(location_id)
+    AND COALESCE(cs.business_date, s.created_at::date) = sqlc.arg(business_date)::date
+    AND s.payment_method = 'credit'
+    AND s.status = 'completed';
+-- name: SubmitDailyClosing :one
+INSERT INTO daily_closings (
+        location_id,
+        business_date,
+        session_count,
+        opening_float,
+        cash_total,
+        transfer_total,
+        qris_total,
+        refund_total,
+        credit_total,
+        expected_cash,
+        counted_cash,
+        variance,
+        submitted_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) ON CONFLICT (location_id, business_date) DO
+UPDATE
+SET session_count = EXCLUDED.session_count,
+    opening_float = EXCLUDED.opening_float,
+    cash_total = EXCLUDED.cash_total,
+    transfer_total = EXCLUDED.transfer_total,
+    qris_total = EXCLUDED.qris_total,
+    refund_total = EXCLUDED.refund_total,
+    credit_total = EXCLUDED.credit_total,
+    expected_cash = EXCLUDED.expected_cash,
+    counted_cash = EXCLUDED.counted_cash,
+    variance = EXCLUDED.variance,
+    submitted_by = EXCLUDED.submitted_by,
+    updated_at = now()
+WHERE daily_closings.status = 'submitted'
+RETURNING *;
+-- name: GetDailyClosing :one
+SELECT *
+FROM daily_closings
+WHERE id = $1
+LIMIT 1;
+-- name: GetDailyClosingForUpdate :one
+SELECT *
+FROM daily_closings
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ApproveDailyClosing :one
+UPDATE daily_closings
+SET status = 'approved',
+    approved_by = $2,
+    approved_at = now(),
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: ListDailyClosings :many
+SELECT *
+FROM daily_closings
+WHERE (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+ORDER BY business_date DESC,
+    location_id
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: GetSalesDayClosingForShare :one
+SELECT status
+FROM daily_closings
+WHERE location_id = sqlc.arg(location_id)
+    AND business_date = COALESCE(
+        (
+            SELECT business_date
+            FROM cash_sessions
+            WHERE id = sqlc.narg(cash_session_id)
+        ),
+        sqlc.arg(at)::timestamptz::date
+    )
+LIMIT 1 FOR SHARE;
@@ -0,0 +1,88 @@
+-- name: CreateCustomer :one
+INSERT INTO customers (
+        name,
+        customer_type,
+        phone,
+        national_id,
+        business_id,
+        address,
+        credit_limit,
+        payment_term_days,
+        latitude,
+        longitude
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+-- name: GetCustomer :one
+SELECT *
+FROM customers
+WHERE id = $1
+LIMIT 1;
+-- name: ListCustomers :many
+SELECT *
+FROM customers
+WHERE is_active
+    AND (
+        sqlc.narg(customer_type)::varchar IS NULL
+        OR customer_type = sqlc.narg(customer_type)
+    )
+    AND (
+        sqlc.narg(phone)::varchar IS NULL
+        OR phone = sqlc.narg(phone)
+    )
+    AND (
+        sqlc.narg(id_number)::varchar IS NULL
+        OR national_id = sqlc.narg(id_number)
+        OR business_id = sqlc.narg(id_number)
+    )
+    AND (
+        sqlc.narg(name)::varchar IS NULL
+        OR name ILIKE '%' || sqlc.narg(name) || '%'
+    )
+ORDER BY name
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: FindDuplicateCustomers :many
+SELECT *
+FROM customers
+WHERE phone = sqlc.narg(phone)
+    OR national_id = sqlc.narg(national_id)
+    OR business_id = sqlc.narg(business_id)
+ORDER BY id;
+-- name: UpdateCustomer :one
+UPDATE customers
+SET name = $2,
+    customer_type = $3,
+    phone = $4,
+    national_id = $5,
+    business_id = $6,
+    address = $7,
+    credit_limit = $8,
+    payment_term_days = $9,
+    latitude = $10,
+    longitude = $11,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: DeactivateCustomer :one
+UPDATE customers
+SET is_active = false,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: AddEmptiesBalance :one
+INSERT INTO empties_balances (customer_id, product_id, balance)
+VALUES ($1, $2, $3) ON CONFLICT (customer_id, product_id) DO
+UPDATE
+SET balance = empties_balances.balance + EXCLUDED.balance,
+    updated_at = now()
+RETURNING *;
+-- name: ListEmptiesBalances :many
+SELECT *
+FROM empties_balances
+WHERE customer_id = $1
+ORDER BY product_id;
+-- name: GetCustomerForUpdate :one
+SELECT *
+FROM customers
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
@@ -0,0 +1,89 @@
+-- name: CreateCylinder :one
+INSERT INTO cylinders (
+        serial_number,
+        qr_code,
+        product_id,
+        manufacture_date,
+        tare_weight_grams,
+        owner_type,
+        owner_customer_id,
+        status,
+        location_id,
+        holder_customer_id,
+        next_test_due
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+-- name: GetCylinder :one
+SELECT *
+FROM cylinders
+WHERE id = $1
+LIMIT 1;
+-- name: GetCylinderForUpdate :one
+SELECT *
+FROM cylinders
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: GetCylinderByCode :one
+SELECT *
+FROM cylinders
+WHERE serial_number = sqlc.arg(code)
+    OR qr_code = sqlc.arg(code)
+LIMIT 1;
+-- name: ListCylinders :many
+SELECT *
+FROM cylinders
+WHERE (
+        sqlc.narg(product_id)::int IS NULL
+        OR product_id = sqlc.narg(product_id)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+    AND (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+    AND (
+        sqlc.narg(customer_id)::int IS NULL
+        OR holder_customer_id = sqlc.narg(customer_id)
+    )
+ORDER BY serial_number
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: UpdateCylinderPosition :one
+UPDATE cylinders
+SET status = $2,
+    location_id = $3,
+    holder_customer_id = $4,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CreateCylinderMovement :one
+INSERT INTO cylinder_movements (
+        cylinder_id,
+        from_location_id,
+        to_location_id,
+        from_customer_id,
+        to_customer_id,
+        status,
+        reason,
+        reference_type,
+        reference_id,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+-- name: ListCylinderMovements :many
+SELECT *
+FROM cylinder_movements
+WHERE cylinder_id = $1
+ORDER BY created_at,
+    id;
+-- name: ListReferenceCylinderMovements :many
+SELECT *
+FROM cylinder_movements
+WHERE reference_type = $1
+    AND reference_id = $2
+ORDER BY created_at,
+    id;
@@ -0,0 +1,189 @@
+-- name: CreateDeliveryOrder :one
+INSERT INTO delivery_orders (
+        source_location_id,
+        vehicle_id,
+        driver_id,
+        scheduled_date,
+        note,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: GetDeliveryOrder :one
+SELECT *
+FROM delivery_orders
+WHERE id = $1
+LIMIT 1;
+-- name: GetDeliveryOrderForUpdate :one
+SELECT *
+FROM delivery_orders
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ListDeliveryOrders :many
+SELECT *
+FROM delivery_orders
+WHERE (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+    AND (
+        sqlc.narg(scheduled_date)::date IS NULL
+        OR scheduled_date = sqlc.narg(scheduled_date)
+    )
+    AND (
+        sqlc.narg(vehicle_id)::int IS NULL
+        OR vehicle_id = sqlc.narg(vehicle_id)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: UpdateDeliveryOrderStatus :one
+UPDATE delivery_orders
+SET status = sqlc.arg(status)::varchar,
+    confirmed_at = CASE
+        WHEN sqlc.arg(status) = 'confirmed' THEN now()
+        ELSE confirmed_at
+    END,
+    loaded_at = CASE
+        WHEN sqlc.arg(status) = 'loaded' THEN now()
+        ELSE loaded_at
+    END,
+    departed_at = CASE
+        WHEN sqlc.arg(status) = 'en_route' THEN now()
+        ELSE departed_at
+    END,
+    delivered_at = CASE
+        WHEN sqlc.arg(status) = 'delivered' THEN now()
+        ELSE delivered_at
+    END,
+    reconciled_at = CASE
+        WHEN sqlc.arg(status) = 'reconciled' THEN now()
+        ELSE reconciled_at
+    END,
+    cancelled_at = CASE
+        WHEN sqlc.arg(status) = 'cancelled' THEN now()
+        ELSE cancelled_at
+    END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: CreateDeliveryStop :one
+INSERT INTO delivery_stops (delivery_order_id, sequence, customer_id, note)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: ListDeliveryStops :many
+SELECT *
+FROM delivery_stops
+WHERE delivery_order_id = $1
+ORDER BY sequence;
+-- name: GetDeliveryStop :one
+SELECT *
+FROM delivery_stops
+WHERE id = $1
+    AND delivery_order_id = $2
+LIMIT 1;
+-- name: GetDeliveryStopForUpdate :one
+SELECT *
+FROM delivery_stops
+WHERE id = $1
+    AND delivery_order_id = $2
+LIMIT 1 FOR UPDATE;
+-- name: CloseDeliveryStop :one
+UPDATE delivery_stops
+SET status = $2,
+    sale_id = $3,
+    note = $4,
+    completed_by = $5,
+    completed_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CountPendingDeliveryStops :one
+SELECT count(*)
+FROM delivery_stops
+WHERE delivery_order_id = $1
+    AND status = 'pending';
+-- name: CreateDeliveryStopItem :one
+INSERT INTO delivery_stop_items (
+        delivery_stop_id,
+        product_id,
+        quantity,
+        empties_to_collect
+    )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: ListDeliveryStopItems :many
+SELECT i.*
+FROM delivery_stop_items i
+    JOIN delivery_stops s ON s.id = i.delivery_stop_id
+WHERE s.delivery_order_id = $1
+ORDER BY s.sequence,
+    i.id;
+-- name: UpdateDeliveryStopItemResult :one
+UPDATE delivery_stop_items
+SET delivered_qty = $2,
+    empties_collected = $3
+WHERE id = $1
+RETURNING *;
+-- name: SumDeliveryOrderItems :many
+SELECT i.product_id,
+    sum(i.quantity)::int AS quantity,
+    sum(i.empties_to_collect)::int AS empties_to_collect,
+    sum(i.delivered_qty)::int AS delivered_qty,
+    sum(i.empties_collected)::int AS empties_collected
+FROM delivery_stop_items i
+    JOIN delivery_stops s ON s.id = i.delivery_stop_id
+WHERE s.delivery_order_id = $1
+GROUP BY i.product_id
+ORDER BY i.product_id;
+-- name: RecordDeliveryProof :one
+UPDATE delivery_stops
+SET recipient_name = sqlc.arg(recipient_name)::varchar,
+    signature_key = sqlc.arg(signature_key)::varchar,
+    photo_key = sqlc.arg(photo_key)::varchar,
+    latitude = sqlc.arg(latitude)::double precision,
+    longitude = sqlc.arg(longitude)::double precision,
+    proof_captured_by = sqlc.arg(proof_captured_by)::varchar,
+    proof_captured_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: ListPlannedDeliveryOrders :many
+SELECT *
+FROM delivery_orders
+WHERE source_location_id = $1
+    AND scheduled_date = $2
+    AND status = 'planned'
+ORDER BY id;
+-- name: ListPlannedDeliveryOrdersForUpdate :many
+SELECT *
+FROM delivery_orders
+WHERE source_location_id = $1
+    AND scheduled_date = $2
+    AND status = 'planned'
+ORDER BY id FOR UPDATE;
+-- name: ListPlannedDeliveryStops :many
+SELECT s.id,
+    s.delivery_order_id,
+    s.sequence,
+    s.customer_id,
+    c.latitude,
+    c.longitude
+FROM delivery_stops s
+    JOIN delivery_orders o ON o.id = s.delivery_order_id
+    JOIN customers c ON c.id = s.customer_id
+WHERE o.source_location_id = $1
+    AND o.scheduled_date = $2
+    AND o.status = 'planned'
+ORDER BY s.delivery_order_id,
+    s.sequence;
+-- name: ListPlannedDeliveryStopItems :many
+SELECT i.*
+FROM delivery_stop_items i
+    JOIN delivery_stops s ON s.id = i.delivery_stop_id
+    JOIN delivery_orders o ON o.id = s.delivery_order_id
+WHERE o.source_location_id = $1
+    AND o.scheduled_date = $2
+    AND o.status = 'planned'
+ORDER BY i.id;
+-- name: MoveDeliveryStop :exec
+UPDATE delivery_stops
+SET delivery_order_id = $2,
+    sequence = $3
+WHERE id = $1;
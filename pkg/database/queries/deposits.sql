@@ -0,0 +1,125 @@
+-- name: CreateCylinderDeposit :one
+INSERT INTO cylinder_deposits (
+        customer_id,
+        product_id,
+        location_id,
+        sale_id,
+        quantity,
+        amount_per_unit,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: GetCylinderDeposit :one
+SELECT *
+FROM cylinder_deposits
+WHERE id = $1
+LIMIT 1;
+-- name: ListCylinderDeposits :many
+SELECT *
+FROM cylinder_deposits
+WHERE (
+        sqlc.narg(customer_id)::int IS NULL
+        OR customer_id = sqlc.narg(customer_id)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: ListRefundableDepositsForUpdate :many
+SELECT *
+FROM cylinder_deposits
+WHERE product_id = sqlc.arg(product_id)
+    AND status IN ('held', 'partially_refunded')
+    AND (
+        sqlc.narg(deposit_id)::bigint IS NULL
+        OR id = sqlc.narg(deposit_id)
+    )
+    AND (
+        sqlc.narg(customer_id)::int IS NULL
+        OR customer_id = sqlc.narg(customer_id)
+    )
+ORDER BY id FOR UPDATE;
+-- name: ListSaleDepositsForUpdate :many
+SELECT *
+FROM cylinder_deposits
+WHERE sale_id = $1
+ORDER BY id FOR UPDATE;
+-- name: AddDepositRefund :one
+UPDATE cylinder_deposits
+SET refunded_qty = refunded_qty + sqlc.arg(quantity),
+    status = CASE
+        WHEN refunded_qty + sqlc.arg(quantity) >= quantity THEN 'refunded'
+        ELSE 'partially_refunded'
+    END,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: VoidCylinderDeposit :one
+UPDATE cylinder_deposits
+SET status = 'voided',
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CreateDepositRefund :one
+INSERT INTO deposit_refunds (
+        deposit_id,
+        location_id,
+        quantity,
+        amount,
+        refunded_by,
+        cash_session_id
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: ListDepositRefunds :many
+SELECT *
+FROM deposit_refunds
+WHERE deposit_id = $1
+ORDER BY id;
+-- name: SumDepositLiabilities :many
+SELECT location_id,
+    product_id,
+    sum(quantity)::bigint AS deposited_qty,
+    sum(refunded_qty)::bigint AS refunded_qty,
+    sum(quantity - refunded_qty)::bigint AS outstanding_qty,
+    sum((quantity - refunded_qty) * amount_per_unit)::bigint AS outstanding_amount
+FROM cylinder_deposits
+WHERE status <> 'voided'
+    AND (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+GROUP BY location_id,
+    product_id
+ORDER BY location_id,
+    product_id;
+-- name: SumNewCylinderSales :many
+SELECT s.location_id,
+    si.product_id,
+    sum(si.quantity)::bigint AS quantity
+FROM sale_items si
+    JOIN sales s ON s.id = si.sale_id
+WHERE si.sale_type = 'new_cylinder'
+    AND s.status = 'completed'
+GROUP BY s.location_id,
+    si.product_id;
+-- name: SumDepositRefundMovements :many
+SELECT location_id,
+    product_id,
+    sum(empty_qty_change)::bigint AS quantity
+FROM stock_movements
+WHERE reason = 'deposit_refund'
+GROUP BY location_id,
+    product_id;
+-- name: SumDepositRefundsByLocation :many
+SELECT r.location_id,
+    d.product_id,
+    sum(r.quantity)::bigint AS quantity
+FROM deposit_refunds r
+    JOIN cylinder_deposits d ON d.id = r.deposit_id
+WHERE d.status <> 'voided'
+GROUP BY r.location_id,
+    d.product_id;
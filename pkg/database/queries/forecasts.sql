@@ -0,0 +1,30 @@
+-- name: ListDailyDemand :many
+SELECT created_at::date AS day,
+    sum(- full_qty_change)::int AS quantity
+FROM stock_movements
+WHERE location_id = sqlc.arg(location_id)
+    AND product_id = sqlc.arg(product_id)
+    AND reason IN ('sale', 'sale_void')
+    AND created_at >= sqlc.arg(from_date)::date
+    AND created_at < sqlc.arg(to_date)::date
+GROUP BY day
+ORDER BY day;
+-- name: CreateHoliday :one
+INSERT INTO holidays (
+        name,
+        starts_on,
+        ends_on,
+        demand_multiplier,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+-- name: ListHolidays :many
+SELECT *
+FROM holidays
+WHERE ends_on >= sqlc.arg(from_date)::date
+    AND starts_on <= sqlc.arg(to_date)::date
+ORDER BY starts_on;
+-- name: DeleteHoliday :exec
+DELETE FROM holidays
+WHERE id = $1;
@@ -0,0 +1,36 @@
+-- name: CreateGoodsReceipt :one
+INSERT INTO goods_receipts (
+        purchase_order_id,
+        location_id,
+        note,
+        received_by
+    )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: GetGoodsReceipt :one
+SELECT *
+FROM goods_receipts
+WHERE id = $1
+LIMIT 1;
+-- name: ListGoodsReceipts :many
+SELECT *
+FROM goods_receipts
+WHERE purchase_order_id = $1
+ORDER BY id;
+-- name: CreateGoodsReceiptItem :one
+INSERT INTO goods_receipt_items (
+        goods_receipt_id,
+        purchase_order_item_id,
+        product_id,
+        expected_qty,
+        received_qty,
+        variance_qty,
+        empties_returned
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: ListGoodsReceiptItems :many
+SELECT *
+FROM goods_receipt_items
+WHERE goods_receipt_id = $1
+ORDER BY id;
@@ -0,0 +1,67 @@
+-- name: CreateImportJob :one
+INSERT INTO import_jobs (
+        kind,
+        dry_run,
+        location_id,
+        file_key,
+        file_name,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: GetImportJob :one
+SELECT *
+FROM import_jobs
+WHERE id = $1
+LIMIT 1;
+-- name: ListImportJobs :many
+SELECT *
+FROM import_jobs
+WHERE (
+        sqlc.narg(kind)::varchar IS NULL
+        OR kind = sqlc.narg(kind)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: ClaimImportJob :one
+UPDATE import_jobs
+SET status = 'running',
+    started_at = now()
+WHERE id = (
+        SELECT id
+        FROM import_jobs
+        WHERE status = 'pending'
+        ORDER BY id
+        LIMIT 1 FOR UPDATE SKIP LOCKED
+    )
+RETURNING *;
+-- name: FinishImportJob :one
+UPDATE import_jobs
+SET status = sqlc.arg(status),
+    total_rows = sqlc.arg(total_rows),
+    created_rows = sqlc.arg(created_rows),
+    updated_rows = sqlc.arg(updated_rows),
+    error_rows = sqlc.arg(error_rows),
+    error = sqlc.narg(error),
+    finished_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: CreateImportJobError :one
+INSERT INTO import_job_errors (
+        import_job_id,
+        row_number,
+        column_name,
+        message
+    )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: ListImportJobErrors :many
+SELECT *
+FROM import_job_errors
+WHERE import_job_id = $1
+ORDER BY row_number,
+    id;
@@ -0,0 +1,64 @@
+-- name: CreateIncident :one
+INSERT INTO incidents (
+        incident_type,
+        location_id,
+        product_id,
+        cylinder_id,
+        cylinder_state,
+        reason,
+        reported_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: GetIncident :one
+SELECT *
+FROM incidents
+WHERE id = $1
+LIMIT 1;
+-- name: GetIncidentForUpdate :one
+SELECT *
+FROM incidents
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ListIncidents :many
+SELECT *
+FROM incidents
+WHERE reported_at >= sqlc.arg(from_time)
+    AND reported_at < sqlc.arg(to_time)
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+    AND (
+        sqlc.narg(incident_type)::varchar IS NULL
+        OR incident_type = sqlc.narg(incident_type)
+    )
+    AND (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: DisposeIncident :one
+UPDATE incidents
+SET status = $2,
+    disposition_note = $3,
+    disposed_by = $4,
+    disposed_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CreateIncidentPhoto :one
+INSERT INTO incident_photos (incident_id, url, storage_key)
+VALUES ($1, $2, $3)
+RETURNING *;
+-- name: GetIncidentPhotoByKey :one
+SELECT *
+FROM incident_photos
+WHERE incident_id = $1
+    AND storage_key = $2
+LIMIT 1;
+-- name: ListIncidentPhotos :many
+SELECT *
+FROM incident_photos
+WHERE incident_id = $1
+ORDER BY id;
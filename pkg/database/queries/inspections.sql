@@ -0,0 +1,42 @@
+-- name: CreateCylinderInspection :one
+INSERT INTO cylinder_inspections (
+        cylinder_id,
+        test_date,
+        result,
+        next_due_date,
+        inspector,
+        note,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: ListCylinderInspections :many
+SELECT *
+FROM cylinder_inspections
+WHERE cylinder_id = $1
+ORDER BY test_date DESC,
+    id DESC;
+-- name: UpdateCylinderTest :one
+UPDATE cylinders
+SET last_test_date = sqlc.arg(test_date),
+    last_test_result = sqlc.arg(result),
+    next_test_due = sqlc.arg(next_test_due),
+    test_due_flagged_at = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: ListCylindersDueForTest :many
+SELECT *
+FROM cylinders
+WHERE next_test_due <= sqlc.arg(due_before)
+    AND status <> 'condemned'
+ORDER BY next_test_due,
+    serial_number
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: FlagCylindersDueForTest :many
+UPDATE cylinders
+SET test_due_flagged_at = now()
+WHERE next_test_due <= sqlc.arg(due_before)
+    AND status <> 'condemned'
+    AND test_due_flagged_at IS NULL
+RETURNING *;
@@ -0,0 +1,112 @@
+-- name: CreateInvoice :one
+INSERT INTO invoices (
+        invoice_number,
+        sale_id,
+        customer_id,
+        amount,
+        issue_date,
+        due_date,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: GetInvoice :one
+SELECT *
+FROM invoices
+WHERE id = $1
+LIMIT 1;
+-- name: GetInvoiceForUpdate :one
+SELECT *
+FROM invoices
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: GetInvoiceBySaleForUpdate :one
+SELECT *
+FROM invoices
+WHERE sale_id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ListInvoices :many
+SELECT *
+FROM invoices
+WHERE (
+        sqlc.narg(customer_id)::int IS NULL
+        OR customer_id = sqlc.narg(customer_id)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: AddInvoicePayment :one
+UPDATE invoices
+SET paid_amount = paid_amount + sqlc.arg(amount),
+    status = CASE
+        WHEN paid_amount + sqlc.arg(amount) >= amount THEN 'paid'
+        ELSE 'partially_paid'
+    END,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: VoidInvoice :one
+UPDATE invoices
+SET status = 'voided',
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: SumCustomerOutstanding :one
+SELECT COALESCE(sum(amount - paid_amount), 0)::bigint AS outstanding
+FROM invoices
+WHERE customer_id = $1
+    AND status IN ('open', 'partially_paid');
+-- name: CreateInvoicePayment :one
+INSERT INTO invoice_payments (
+        invoice_id,
+        amount,
+        payment_method,
+        reference,
+        received_by,
+        cash_session_id
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: ListInvoicePayments :many
+SELECT *
+FROM invoice_payments
+WHERE invoice_id = $1
+ORDER BY id;
+-- name: GetReceivablesAging :many
+SELECT c.id AS customer_id,
+    c.name AS customer_name,
+    COALESCE(
+        sum(i.amount - i.paid_amount) FILTER (
+            WHERE sqlc.arg(as_of)::date - i.due_date <= 30
+        ),
+        0
+    )::bigint AS days_0_30,
+    COALESCE(
+        sum(i.amount - i.paid_amount) FILTER (
+            WHERE sqlc.arg(as_of)::date - i.due_date BETWEEN 31 AND 60
+        ),
+        0
+    )::bigint AS days_31_60,
+    COALESCE(
+        sum(i.amount - i.paid_amount) FILTER (
+            WHERE sqlc.arg(as_of)::date - i.due_date BETWEEN 61 AND 90
+        ),
+        0
+    )::bigint AS days_61_90,
+    COALESCE(
+        sum(i.amount - i.paid_amount) FILTER (
+            WHERE sqlc.arg(as_of)::date - i.due_date > 90
+        ),
+        0
+    )::bigint AS days_over_90,
+    sum(i.amount - i.paid_amount)::bigint AS total
+FROM invoices i
+    JOIN customers c ON c.id = i.customer_id
+WHERE i.status IN ('open', 'partially_paid')
+    AND i.issue_date <= sqlc.arg(as_of)::date
+GROUP BY c.id,
+    c.name
+ORDER BY total DESC;
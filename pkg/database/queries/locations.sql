@@ -0,0 +1,33 @@
+-- name: CreateLocation :one
+INSERT INTO locations (
+        code,
+        name,
+        location_type,
+        region,
+        latitude,
+        longitude
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: GetLocation :one
+SELECT *
+FROM locations
+WHERE id = $1
+LIMIT 1;
+-- name: ListLocations :many
+SELECT *
+FROM locations
+ORDER BY code;
+-- name: GetLocationByCode :one
+SELECT *
+FROM locations
+WHERE code = $1
+LIMIT 1;
+-- name: UpdateLocation :one
+UPDATE locations
+SET name = $2,
+    region = $3,
+    latitude = $4,
+    longitude = $5
+WHERE id = $1
+RETURNING *;
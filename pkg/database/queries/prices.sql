@@ -0,0 +1,128 @@
+-- name: CreatePriceList :one
+INSERT INTO price_lists (
+        product_id,
+        customer_type,
+        location_id,
+        price,
+        effective_from,
+        effective_to,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: ClosePriceLists :exec
+UPDATE price_lists
+SET effective_to = sqlc.arg(effective_from)::timestamptz
+WHERE product_id = sqlc.arg(product_id)
+    AND customer_type IS NOT DISTINCT FROM sqlc.narg(customer_type)::varchar
+    AND location_id IS NOT DISTINCT FROM sqlc.narg(location_id)::int
+    AND effective_from < sqlc.arg(effective_from)
+    AND (
+        effective_to IS NULL
+        OR effective_to > sqlc.arg(effective_from)
+    );
+-- name: GetNextPriceList :one
+SELECT *
+FROM price_lists
+WHERE product_id = sqlc.arg(product_id)
+    AND customer_type IS NOT DISTINCT FROM sqlc.narg(customer_type)::varchar
+    AND location_id IS NOT DISTINCT FROM sqlc.narg(location_id)::int
+    AND effective_from >= sqlc.arg(effective_from)
+ORDER BY effective_from
+LIMIT 1;
+-- name: ListPriceLists :many
+SELECT *
+FROM price_lists
+WHERE product_id = $1
+ORDER BY effective_from DESC,
+    id DESC;
+-- name: GetEffectivePriceList :one
+SELECT *
+FROM price_lists
+WHERE product_id = sqlc.arg(product_id)
+    AND (
+        customer_type IS NULL
+        OR customer_type = sqlc.narg(customer_type)
+    )
+    AND (
+        location_id IS NULL
+        OR location_id = sqlc.arg(location_id)
+    )
+    AND effective_from <= sqlc.arg(at)
+    AND (
+        effective_to IS NULL
+        OR effective_to > sqlc.arg(at)
+    )
+ORDER BY location_id IS NOT NULL DESC,
+    customer_type IS NOT NULL DESC,
+    effective_from DESC
+LIMIT 1;
+-- name: CreateCeilingPrice :one
+INSERT INTO ceiling_prices (
+        region,
+        product_id,
+        max_price,
+        effective_from,
+        effective_to,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: CloseCeilingPrices :exec
+UPDATE ceiling_prices
+SET effective_to = sqlc.arg(effective_from)::timestamptz
+WHERE region = sqlc.arg(region)
+    AND product_id = sqlc.arg(product_id)
+    AND effective_from < sqlc.arg(effective_from)
+    AND (
+        effective_to IS NULL
+        OR effective_to > sqlc.arg(effective_from)
+    );
+-- name: GetNextCeilingPrice :one
+SELECT *
+FROM ceiling_prices
+WHERE region = sqlc.arg(region)
+    AND product_id = sqlc.arg(product_id)
+    AND effective_from >= sqlc.arg(effective_from)
+ORDER BY effective_from
+LIMIT 1;
+-- name: ListCeilingPrices :many
+SELECT *
+FROM ceiling_prices
+WHERE sqlc.narg(region)::varchar IS NULL
+    OR region = sqlc.narg(region)
+ORDER BY region,
+    product_id,
+    effective_from DESC;
+-- name: GetEffectiveCeilingPrice :one
+SELECT *
+FROM ceiling_prices
+WHERE region = sqlc.arg(region)
+    AND product_id = sqlc.arg(product_id)
+    AND effective_from <= sqlc.arg(at)
+    AND (
+        effective_to IS NULL
+        OR effective_to > sqlc.arg(at)
+    )
+ORDER BY effective_from DESC
+LIMIT 1;
+-- name: CreatePriceOverride :one
+INSERT INTO price_overrides (
+        sale_id,
+        sale_item_id,
+        product_id,
+        location_id,
+        list_price,
+        ceiling_price,
+        charged_price,
+        reason,
+        approved_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+-- name: ListPriceOverrides :many
+SELECT *
+FROM price_overrides
+WHERE created_at >= sqlc.arg(from_time)
+    AND created_at < sqlc.arg(to_time)
+ORDER BY id DESC;
@@ -0,0 +1,50 @@
+-- name: CreateProduct :one
+INSERT INTO products (
+        code,
+        name,
+        net_weight_grams,
+        is_subsidized,
+        price,
+        deposit_amount
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: GetProduct :one
+SELECT *
+FROM products
+WHERE id = $1
+LIMIT 1;
+-- name: ListProducts :many
+SELECT *
+FROM products
+ORDER BY code;
+-- name: UpdateProduct :one
+UPDATE products
+SET name = $2,
+    is_subsidized = $3,
+    price = $4,
+    deposit_amount = $5
+WHERE id = $1
+RETURNING *;
+-- name: GetProductByCode :one
+SELECT *
+FROM products
+WHERE code = $1
+LIMIT 1;
+-- name: UpsertProduct :one
+INSERT INTO products (
+        code,
+        name,
+        net_weight_grams,
+        is_subsidized,
+        price,
+        deposit_amount
+    )
+VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (code) DO
+UPDATE
+SET name = EXCLUDED.name,
+    is_subsidized = EXCLUDED.is_subsidized,
+    price = EXCLUDED.price,
+    deposit_amount = EXCLUDED.deposit_amount
+RETURNING id,
+    (xmax = 0)::boolean AS inserted;
@@ -0,0 +1,69 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+        supplier_id,
+        location_id,
+        expected_date,
+        note,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+-- name: GetPurchaseOrder :one
+SELECT *
+FROM purchase_orders
+WHERE id = $1
+LIMIT 1;
+-- name: GetPurchaseOrderForUpdate :one
+SELECT *
+FROM purchase_orders
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ListPurchaseOrders :many
+SELECT *
+FROM purchase_orders
+WHERE (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+    AND (
+        sqlc.narg(supplier_id)::int IS NULL
+        OR supplier_id = sqlc.narg(supplier_id)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: MarkPurchaseOrderOrdered :one
+UPDATE purchase_orders
+SET status = 'ordered',
+    ordered_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_orders
+SET status = $2
+WHERE id = $1
+RETURNING *;
+-- name: ClosePurchaseOrder :one
+UPDATE purchase_orders
+SET status = 'closed',
+    closed_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (
+        purchase_order_id,
+        product_id,
+        ordered_qty,
+        unit_price
+    )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: ListPurchaseOrderItems :many
+SELECT *
+FROM purchase_order_items
+WHERE purchase_order_id = $1
+ORDER BY id;
+-- name: AddPurchaseOrderItemReceipt :one
+UPDATE purchase_order_items
+SET received_qty = received_qty + sqlc.arg(received_qty)
+WHERE id = sqlc.arg(id)
+RETURNING *;
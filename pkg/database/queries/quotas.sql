@@ -0,0 +1,77 @@
+-- name: CreateQuotaRule :one
+INSERT INTO quota_rules (
+        product_id,
+        customer_type,
+        period,
+        max_qty,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+-- name: UpdateQuotaRule :one
+UPDATE quota_rules
+SET max_qty = $2,
+    is_active = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: ListQuotaRules :many
+SELECT *
+FROM quota_rules
+ORDER BY product_id,
+    customer_type,
+    period;
+-- name: ListActiveQuotaRules :many
+SELECT *
+FROM quota_rules
+WHERE product_id = $1
+    AND customer_type = $2
+    AND is_active
+ORDER BY id;
+-- name: SumQuotaUsage :one
+SELECT COALESCE(SUM(quantity), 0)::int AS used
+FROM quota_usages
+WHERE customer_id = $1
+    AND quota_rule_id = $2
+    AND period_start = $3;
+-- name: CreateQuotaUsage :one
+INSERT INTO quota_usages (
+        customer_id,
+        product_id,
+        quota_rule_id,
+        period_start,
+        quantity,
+        sale_id
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: ListQuotaUsages :many
+SELECT qu.id,
+    qu.customer_id,
+    c.name AS customer_name,
+    c.customer_type,
+    c.national_id,
+    c.business_id,
+    qu.product_id,
+    p.code AS product_code,
+    r.period,
+    qu.period_start,
+    qu.quantity,
+    qu.sale_id,
+    qu.created_at
+FROM quota_usages qu
+    JOIN customers c ON c.id = qu.customer_id
+    JOIN products p ON p.id = qu.product_id
+    JOIN quota_rules r ON r.id = qu.quota_rule_id
+WHERE qu.created_at >= sqlc.arg(from_time)
+    AND qu.created_at < sqlc.arg(to_time)
+    AND (
+        sqlc.narg(customer_id)::int IS NULL
+        OR qu.customer_id = sqlc.narg(customer_id)
+    )
+ORDER BY qu.id;
+-- name: ListSaleQuotaUsages :many
+SELECT *
+FROM quota_usages
+WHERE sale_id = $1
+ORDER BY id;
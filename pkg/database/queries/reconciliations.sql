@@ -0,0 +1,66 @@
+-- name: SumDeliveryOrderCash :one
+SELECT COALESCE(sum(sa.total), 0)::bigint AS cash
+FROM delivery_stops s
+    JOIN sales sa ON sa.id = s.sale_id
+WHERE s.delivery_order_id = $1
+    AND sa.payment_method = 'cash'
+    AND sa.status = 'completed';
+-- name: CreateDeliveryReconciliation :one
+INSERT INTO delivery_reconciliations (
+        delivery_order_id,
+        driver_id,
+        expected_cash,
+        reported_cash,
+        cash_variance,
+        note,
+        reconciled_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: GetDeliveryReconciliation :one
+SELECT *
+FROM delivery_reconciliations
+WHERE delivery_order_id = $1
+LIMIT 1;
+-- name: CreateDeliveryReconciliationItem :one
+INSERT INTO delivery_reconciliation_items (
+        reconciliation_id,
+        product_id,
+        loaded_qty,
+        delivered_qty,
+        reported_full_qty,
+        full_qty_short,
+        empties_collected,
+        reported_empty_qty,
+        empty_qty_short
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+-- name: ListDeliveryReconciliationItems :many
+SELECT *
+FROM delivery_reconciliation_items
+WHERE reconciliation_id = $1
+ORDER BY product_id;
+-- name: CreateDriverShortage :one
+INSERT INTO driver_shortages (
+        driver_id,
+        delivery_order_id,
+        product_id,
+        full_qty_short,
+        empty_qty_short,
+        cash_short,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: ListDeliveryOrderShortages :many
+SELECT *
+FROM driver_shortages
+WHERE delivery_order_id = $1
+ORDER BY id;
+-- name: ListDriverShortages :many
+SELECT *
+FROM driver_shortages
+WHERE driver_id = sqlc.arg(driver_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
@@ -0,0 +1,111 @@
+-- name: ListSalesReport :many
+SELECT date_trunc(sqlc.arg(period)::text, day)::date AS period_start,
+    nullif(CASE WHEN sqlc.arg(by_location)::boolean THEN location_id ELSE 0 END, 0)::int AS location_id,
+    nullif(CASE WHEN sqlc.arg(by_product)::boolean THEN product_id ELSE 0 END, 0)::int AS product_id,
+    sum(quantity)::bigint AS quantity,
+    sum(empties_returned)::bigint AS empties_returned,
+    sum(gross_amount)::bigint AS gross_amount,
+    sum(discount_amount)::bigint AS discount_amount,
+    sum(deposit_amount)::bigint AS deposit_amount,
+    sum(net_amount)::bigint AS net_amount
+FROM daily_sales_summary
+WHERE day >= sqlc.arg(from_date)::date
+    AND day <= sqlc.arg(to_date)::date
+    AND (
+        sqlc.narg(location_filter)::int IS NULL
+        OR location_id = sqlc.narg(location_filter)::int
+    )
+    AND (
+        sqlc.narg(product_filter)::int IS NULL
+        OR product_id = sqlc.narg(product_filter)::int
+    )
+GROUP BY 1, 2, 3
+ORDER BY 1, 2, 3;
+-- name: ListMovementReport :many
+SELECT date_trunc(sqlc.arg(period)::text, day)::date AS period_start,
+    nullif(CASE WHEN sqlc.arg(by_location)::boolean THEN location_id ELSE 0 END, 0)::int AS location_id,
+    nullif(CASE WHEN sqlc.arg(by_product)::boolean THEN product_id ELSE 0 END, 0)::int AS product_id,
+    reason::varchar AS reason,
+    sum(movement_count)::bigint AS movement_count,
+    sum(full_qty_in)::bigint AS full_qty_in,
+    sum(full_qty_out)::bigint AS full_qty_out,
+    sum(empty_qty_in)::bigint AS empty_qty_in,
+    sum(empty_qty_out)::bigint AS empty_qty_out
+FROM daily_movement_summary
+WHERE day >= sqlc.arg(from_date)::date
+    AND day <= sqlc.arg(to_date)::date
+    AND (
+        sqlc.narg(location_filter)::int IS NULL
+        OR location_id = sqlc.narg(location_filter)::int
+    )
+    AND (
+        sqlc.narg(product_filter)::int IS NULL
+        OR product_id = sqlc.narg(product_filter)::int
+    )
+    AND (
+        sqlc.narg(reason_filter)::varchar IS NULL
+        OR reason = sqlc.narg(reason_filter)::varchar
+    )
+GROUP BY 1, 2, 3, 4
+ORDER BY 1, 2, 3, 4;
+-- name: ListTopCustomers :many
+SELECT d.customer_id::int AS customer_id,
+    c.name AS customer_name,
+    sum(d.sale_count)::bigint AS sale_count,
+    sum(d.total_amount)::bigint AS total_amount
+FROM daily_customer_sales d
+    JOIN customers c ON c.id = d.customer_id
+WHERE d.day >= sqlc.arg(from_date)::date
+    AND d.day <= sqlc.arg(to_date)::date
+    AND (
+        sqlc.narg(location_id)::int IS NULL
+        OR d.location_id = sqlc.narg(location_id)::int
+    )
+GROUP BY d.customer_id, c.name
+ORDER BY total_amount DESC, customer_id
+LIMIT sqlc.arg(row_limit)::int;
+-- name: ListEmptiesOutstanding :many
+SELECT e.customer_id,
+    c.name AS customer_name,
+    e.product_id,
+    e.balance,
+    e.updated_at
+FROM empties_balances e
+    JOIN customers c ON c.id = e.customer_id
+WHERE e.balance > 0
+    AND (
+        sqlc.narg(product_id)::int IS NULL
+        OR e.product_id = sqlc.narg(product_id)::int
+    )
+ORDER BY e.balance DESC, e.customer_id, e.product_id
+LIMIT sqlc.arg(page_size)::int OFFSET sqlc.arg(page_offset)::int;
+-- name: SumEmptiesOutstanding :many
+SELECT product_id,
+    count(*) AS customer_count,
+    sum(balance)::bigint AS balance
+FROM empties_balances
+WHERE balance > 0
+GROUP BY product_id
+ORDER BY product_id;
+-- name: ListStockOnHand :many
+SELECT location_id,
+    product_id,
+    full_qty
+FROM stock_balances
+WHERE full_qty > 0
+ORDER BY product_id, location_id;
+-- name: ListReceiptCosts :many
+SELECT gri.product_id,
+    gri.received_qty,
+    poi.unit_price
+FROM goods_receipt_items gri
+    JOIN goods_receipts gr ON gr.id = gri.goods_receipt_id
+    JOIN purchase_order_items poi ON poi.id = gri.purchase_order_item_id
+WHERE gri.received_qty > 0
+ORDER BY gri.product_id, gr.created_at DESC, gri.id DESC;
+-- name: RefreshDailySalesSummary :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY daily_sales_summary;
+-- name: RefreshDailyCustomerSales :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY daily_customer_sales;
+-- name: RefreshDailyMovementSummary :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY daily_movement_summary;
@@ -0,0 +1,51 @@
+-- name: CreateStockReservation :one
+INSERT INTO stock_reservations (
+        location_id,
+        product_id,
+        quantity,
+        reference_type,
+        reference_id,
+        expires_at,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+-- name: ListActiveReservationsForUpdate :many
+SELECT *
+FROM stock_reservations
+WHERE reference_type = $1
+    AND reference_id = $2
+    AND status = 'active'
+ORDER BY id FOR UPDATE;
+-- name: ListExpiredReservationsForUpdate :many
+SELECT *
+FROM stock_reservations
+WHERE status = 'active'
+    AND expires_at <= now()
+ORDER BY id FOR UPDATE SKIP LOCKED;
+-- name: CloseStockReservation :one
+UPDATE stock_reservations
+SET status = $2,
+    closed_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: ListStockReservations :many
+SELECT *
+FROM stock_reservations
+WHERE (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+    AND (
+        sqlc.narg(reference_type)::varchar IS NULL
+        OR (
+            reference_type = sqlc.narg(reference_type)
+            AND reference_id = sqlc.narg(reference_id)::bigint
+        )
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
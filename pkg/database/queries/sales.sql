@@ -0,0 +1,80 @@
+-- name: CreateSale :one
+INSERT INTO sales (
+        location_id,
+        customer_id,
+        payment_method,
+        note,
+        created_by,
+        cash_session_id
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: UpdateSaleTotals :one
+UPDATE sales
+SET subtotal = $2,
+    discount_total = $3,
+    tax_total = $4,
+    deposit_total = $5,
+    total = $6
+WHERE id = $1
+RETURNING *;
+-- name: GetSale :one
+SELECT *
+FROM sales
+WHERE id = $1
+LIMIT 1;
+-- name: GetSaleForUpdate :one
+SELECT *
+FROM sales
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ListSales :many
+SELECT *
+FROM sales
+WHERE created_at >= sqlc.arg(from_time)
+    AND created_at < sqlc.arg(to_time)
+    AND (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+    AND (
+        sqlc.narg(customer_id)::int IS NULL
+        OR customer_id = sqlc.narg(customer_id)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+    AND (
+        sqlc.narg(payment_method)::varchar IS NULL
+        OR payment_method = sqlc.narg(payment_method)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: VoidSale :one
+UPDATE sales
+SET status = 'voided',
+    voided_by = $2,
+    void_reason = $3,
+    voided_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CreateSaleItem :one
+INSERT INTO sale_items (
+        sale_id,
+        product_id,
+        sale_type,
+        quantity,
+        empties_returned,
+        unit_price,
+        discount_amount,
+        deposit_amount,
+        line_total
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+-- name: ListSaleItems :many
+SELECT *
+FROM sale_items
+WHERE sale_id = $1
+ORDER BY id;
@@ -0,0 +1,63 @@
+-- name: AddStockBalance :one
+INSERT INTO stock_balances (
+        location_id,
+        product_id,
+        full_qty,
+        empty_qty,
+        quarantine_qty
+    )
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT (location_id, product_id) DO
+UPDATE
+SET full_qty = stock_balances.full_qty + EXCLUDED.full_qty,
+    empty_qty = stock_balances.empty_qty + EXCLUDED.empty_qty,
+    quarantine_qty = stock_balances.quarantine_qty + EXCLUDED.quarantine_qty,
+    updated_at = now()
+RETURNING *;
+-- name: ListStockBalances :many
+SELECT *
+FROM stock_balances
+WHERE sqlc.narg(location_id)::int IS NULL
+    OR location_id = sqlc.narg(location_id)
+ORDER BY location_id,
+    product_id;
+-- name: CreateStockMovement :one
+INSERT INTO stock_movements (
+        location_id,
+        product_id,
+        full_qty_change,
+        empty_qty_change,
+        reason,
+        reference_type,
+        reference_id,
+        created_by,
+        quarantine_qty_change
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+-- name: ListStockMovements :many
+SELECT *
+FROM stock_movements
+WHERE location_id = sqlc.arg(location_id)
+    AND (
+        sqlc.narg(from_time)::timestamptz IS NULL
+        OR created_at >= sqlc.narg(from_time)::timestamptz
+    )
+    AND (
+        sqlc.narg(to_time)::timestamptz IS NULL
+        OR created_at < sqlc.narg(to_time)::timestamptz
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size)::int OFFSET sqlc.arg(page_offset)::int;
+-- name: GetStockBalanceForUpdate :one
+SELECT *
+FROM stock_balances
+WHERE location_id = $1
+    AND product_id = $2
+LIMIT 1 FOR UPDATE;
+-- name: AddReservedStock :one
+UPDATE stock_balances
+SET reserved_qty = reserved_qty + sqlc.arg(quantity),
+    updated_at = now()
+WHERE location_id = sqlc.arg(location_id)
+    AND product_id = sqlc.arg(product_id)
+RETURNING *;
@@ -0,0 +1,76 @@
+-- name: CreateStockCount :one
+INSERT INTO stock_counts (location_id, note, created_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+-- name: GetStockCount :one
+SELECT *
+FROM stock_counts
+WHERE id = $1
+LIMIT 1;
+-- name: GetStockCountForUpdate :one
+SELECT *
+FROM stock_counts
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ListStockCounts :many
+SELECT *
+FROM stock_counts
+WHERE (
+        sqlc.narg(location_id)::int IS NULL
+        OR location_id = sqlc.narg(location_id)
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)
+    )
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: ApproveStockCount :one
+UPDATE stock_counts
+SET status = 'approved',
+    approved_by = $2,
+    approved_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CancelStockCount :one
+UPDATE stock_counts
+SET status = 'cancelled'
+WHERE id = $1
+RETURNING *;
+-- name: CreateStockCountLine :one
+INSERT INTO stock_count_lines (
+        stock_count_id,
+        product_id,
+        expected_full_qty,
+        expected_empty_qty
+    )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: ListStockCountLines :many
+SELECT *
+FROM stock_count_lines
+WHERE stock_count_id = $1
+ORDER BY product_id;
+-- name: GetStockCountLineForUpdate :one
+SELECT *
+FROM stock_count_lines
+WHERE stock_count_id = $1
+    AND product_id = $2
+LIMIT 1 FOR UPDATE;
+-- name: UpdateStockCountLineCount :one
+UPDATE stock_count_lines
+SET counted_full_qty = $2,
+    counted_empty_qty = $3,
+    counted_by = $4,
+    counted_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CreateStockCountEntry :one
+INSERT INTO stock_count_entries (
+        stock_count_line_id,
+        counted_full_qty,
+        counted_empty_qty,
+        counted_by
+    )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
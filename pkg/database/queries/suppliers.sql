@@ -0,0 +1,13 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (code, name, phone, address)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: GetSupplier :one
+SELECT *
+FROM suppliers
+WHERE id = $1
+LIMIT 1;
+-- name: ListSuppliers :many
+SELECT *
+FROM suppliers
+ORDER BY code;
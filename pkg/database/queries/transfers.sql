@@ -0,0 +1,70 @@
+-- name: CreateTransfer :one
+INSERT INTO transfers (
+        source_location_id,
+        destination_location_id,
+        note,
+        created_by
+    )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: GetTransfer :one
+SELECT *
+FROM transfers
+WHERE id = $1
+LIMIT 1;
+-- name: GetTransferForUpdate :one
+SELECT *
+FROM transfers
+WHERE id = $1
+LIMIT 1 FOR UPDATE;
+-- name: ListTransfers :many
+SELECT *
+FROM transfers
+WHERE sqlc.narg(status)::varchar IS NULL
+    OR status = sqlc.narg(status)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+-- name: DispatchTransfer :one
+UPDATE transfers
+SET status = 'dispatched',
+    dispatched_by = $2,
+    dispatched_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: UpdateTransferReceiptStatus :one
+UPDATE transfers
+SET status = $2,
+    received_at = now()
+WHERE id = $1
+RETURNING *;
+-- name: CreateTransferItem :one
+INSERT INTO transfer_items (transfer_id, product_id, full_qty, empty_qty)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+-- name: ListTransferItems :many
+SELECT *
+FROM transfer_items
+WHERE transfer_id = $1
+ORDER BY id;
+-- name: AddTransferItemReceipt :one
+UPDATE transfer_items
+SET received_full_qty = received_full_qty + sqlc.arg(full_qty),
+    received_empty_qty = received_empty_qty + sqlc.arg(empty_qty)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+-- name: CreateTransferDiscrepancy :one
+INSERT INTO transfer_discrepancies (
+        transfer_id,
+        product_id,
+        full_qty_short,
+        empty_qty_short,
+        note,
+        created_by
+    )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+-- name: ListTransferDiscrepancies :many
+SELECT *
+FROM transfer_discrepancies
+WHERE transfer_id = $1
+ORDER BY id;
@@ -6,4 +6,15 @@ RETURNING *;
 SELECT *
 FROM users
 WHERE email = $1
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+-- name: GetUserByID :one
+SELECT *
+FROM users
+WHERE id = $1
+LIMIT 1;
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = sqlc.arg(role),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
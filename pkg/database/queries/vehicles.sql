@@ -0,0 +1,49 @@
+-- name: CreateVehicle :one
+INSERT INTO vehicles (plate_number, location_id)
+VALUES ($1, $2)
+RETURNING *;
+-- name: GetVehicle :one
+SELECT *
+FROM vehicles
+WHERE id = $1
+LIMIT 1;
+-- name: ListVehicles :many
+SELECT *
+FROM vehicles
+ORDER BY plate_number;
+-- name: UpdateVehicleActive :one
+UPDATE vehicles
+SET is_active = $2
+WHERE id = $1
+RETURNING *;
+-- name: UpsertVehicleCapacity :one
+INSERT INTO vehicle_capacities (vehicle_id, product_id, capacity)
+VALUES ($1, $2, $3) ON CONFLICT (vehicle_id, product_id) DO
+UPDATE
+SET capacity = EXCLUDED.capacity
+RETURNING *;
+-- name: ListVehicleCapacities :many
+SELECT *
+FROM vehicle_capacities
+WHERE vehicle_id = $1
+ORDER BY product_id;
+-- name: CreateDriver :one
+INSERT INTO drivers (user_id, license_number, phone)
+VALUES ($1, $2, $3)
+RETURNING *;
+-- name: GetDriver :one
+SELECT *
+FROM drivers
+WHERE id = $1
+LIMIT 1;
+-- name: ListDrivers :many
+SELECT *
+FROM drivers
+ORDER BY id;
+-- name: UpdateDriver :one
+UPDATE drivers
+SET license_number = $2,
+    phone = $3,
+    is_active = $4
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/blanc08/stok-gas-management-backend/pkg/forecast"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// StockAlertTypeBelowMin is raised while available stock is under min_qty
+	StockAlertTypeBelowMin = "below_min"
+	// StockAlertTypeReorder is raised while available stock is at or under
+	// the reorder point
+	StockAlertTypeReorder = "reorder"
+	// StockAlertTypeAboveMax is raised while available stock is over max_qty
+	StockAlertTypeAboveMax = "above_max"
+)
+
+const (
+	StockAlertStatusOpen     = "open"
+	StockAlertStatusResolved = "resolved"
+)
+
+type EvaluateStockAlertsResult struct {
+	Raised   int64 `json:"raised"`
+	Resolved int64 `json:"resolved"`
+}
+
+// ReorderSuggestion is the quantity to order for a location to get a product
+// back up to its max level, or to the forecast demand when that is higher,
+// counting what is already on order
+type ReorderSuggestion struct {
+	LocationID   int32 `json:"location_id"`
+	ProductID    int32 `json:"product_id"`
+	AvailableQty int32 `json:"available_qty"`
+	ReorderPoint int32 `json:"reorder_point"`
+	MaxQty       int32 `json:"max_qty"`
+	OnOrderQty   int32 `json:"on_order_qty"`
+	// ForecastQty is the demand forecast over the cover days
+	ForecastQty  int32 `json:"forecast_qty"`
+	SuggestedQty int32 `json:"suggested_qty"`
+}
+
+type SuggestReordersParams struct {
+	LocationID pgtype.Int4         `json:"location_id"`
+	Forecaster forecast.Forecaster `json:"-"`
+	// HistoryDays of sales are fitted to forecast the demand of the next
+	// CoverDays
+	HistoryDays int       `json:"history_days"`
+	CoverDays   int       `json:"cover_days"`
+	Today       time.Time `json:"today"`
+}
+
+// levelCheck tells whether the available stock crosses the level of one
+// alert type
+type levelCheck struct {
+	alertType string
+	threshold int32
+	crossed   bool
+}
+
+func crossedLevels(level ListThresholdLevelsRow) []levelCheck {
+	return []levelCheck{
+		{StockAlertTypeBelowMin, level.MinQty, level.AvailableQty < level.MinQty},
+		{StockAlertTypeReorder, level.ReorderPoint, level.AvailableQty <= level.ReorderPoint},
+		{StockAlertTypeAboveMax, level.MaxQty, level.AvailableQty > level.MaxQty},
+	}
+}
+
+// EvaluateStockAlerts compares the available full stock of every threshold
+// with its levels. An alert is raised once when a level is crossed and stays
+// open until the stock is back, alerts of a removed threshold are resolved.
+func (store *SQLStore) EvaluateStockAlerts(ctx context.Context, db DBTX) (EvaluateStockAlertsResult, error) {
+	var result EvaluateStockAlertsResult
+
+	levels, err := store.ListThresholdLevels(ctx, db, pgtype.Int4{})
+	if err != nil {
+		return result, err
+	}
+
+	for _, level := range levels {
+		for _, check := range crossedLevels(level) {
+			var rows int64
+			if check.crossed {
+				rows, err = store.RaiseStockAlert(ctx, db, RaiseStockAlertParams{
+					LocationID:   level.LocationID,
+					ProductID:    level.ProductID,
+					AlertType:    check.alertType,
+					AvailableQty: level.AvailableQty,
+					ThresholdQty: check.threshold,
+				})
+				result.Raised += rows
+			} else {
+				rows, err = store.ResolveStockAlerts(ctx, db, ResolveStockAlertsParams{
+					LocationID: level.LocationID,
+					ProductID:  level.ProductID,
+					AlertType:  check.alertType,
+				})
+				result.Resolved += rows
+			}
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	rows, err := store.ResolveOrphanStockAlerts(ctx, db)
+	result.Resolved += rows
+	return result, err
+}
+
+// SuggestReorders lists every product at or below its reorder point, or
+// expected to fall below its min level within the cover days even with what
+// is on order, with the quantity to order to reach its max level or to cover
+// the forecast demand on top of its min level, whichever is more
+func (store *SQLStore) SuggestReorders(ctx context.Context, db DBTX, arg SuggestReordersParams) ([]ReorderSuggestion, error) {
+	levels, err := store.ListThresholdLevels(ctx, db, arg.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	onOrder, err := store.SumOnOrder(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ locationID, productID int32 }
+	ordered := make(map[key]int32, len(onOrder))
+	for _, row := range onOrder {
+		ordered[key{row.LocationID, row.ProductID}] = int32(row.OnOrderQty)
+	}
+
+	today := forecast.Day(arg.Today)
+	holidays, err := store.listForecastHolidays(ctx, db,
+		today.AddDate(0, 0, -arg.HistoryDays), today.AddDate(0, 0, arg.CoverDays))
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []ReorderSuggestion{}
+	for _, level := range levels {
+		demand, err := store.forecastDemand(ctx, db, ForecastDemandParams{
+			LocationID:  level.LocationID,
+			ProductID:   level.ProductID,
+			Forecaster:  arg.Forecaster,
+			HistoryDays: arg.HistoryDays,
+			HorizonDays: arg.CoverDays,
+			Today:       today,
+		}, holidays)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestion := ReorderSuggestion{
+			LocationID:   level.LocationID,
+			ProductID:    level.ProductID,
+			AvailableQty: level.AvailableQty,
+			ReorderPoint: level.ReorderPoint,
+			MaxQty:       level.MaxQty,
+			OnOrderQty:   ordered[key{level.LocationID, level.ProductID}],
+			ForecastQty:  int32(math.Ceil(demand.Total)),
+		}
+
+		projected := suggestion.AvailableQty + suggestion.OnOrderQty - suggestion.ForecastQty
+		if level.AvailableQty > level.ReorderPoint && projected >= level.MinQty {
+			continue
+		}
+
+		target := max(level.MaxQty, level.MinQty+suggestion.ForecastQty)
+		suggestion.SuggestedQty = target - level.AvailableQty - suggestion.OnOrderQty
+		if suggestion.SuggestedQty <= 0 {
+			continue
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
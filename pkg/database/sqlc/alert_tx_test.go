@@ -0,0 +1,43 @@
+package database
+
+import "testing"
+
+func TestCrossedLevels(t *testing.T) {
+	level := func(available int32) ListThresholdLevelsRow {
+		return ListThresholdLevelsRow{MinQty: 5, ReorderPoint: 10, MaxQty: 40, AvailableQty: available}
+	}
+
+	tests := []struct {
+		name    string
+		level   ListThresholdLevelsRow
+		crossed []string
+	}{
+		{"between reorder point and max", level(20), nil},
+		{"at the max", level(40), nil},
+		{"above the max", level(41), []string{StockAlertTypeAboveMax}},
+		{"at the reorder point", level(10), []string{StockAlertTypeReorder}},
+		{"at the min", level(5), []string{StockAlertTypeReorder}},
+		{"below the min", level(4), []string{StockAlertTypeBelowMin, StockAlertTypeReorder}},
+		{"out of stock", level(0), []string{StockAlertTypeBelowMin, StockAlertTypeReorder}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var crossed []string
+			for _, check := range crossedLevels(tt.level) {
+				if check.crossed {
+					crossed = append(crossed, check.alertType)
+				}
+			}
+
+			if len(crossed) != len(tt.crossed) {
+				t.Fatalf("crossedLevels() crossed %v, want %v", crossed, tt.crossed)
+			}
+			for i := range crossed {
+				if crossed[i] != tt.crossed[i] {
+					t.Errorf("crossedLevels() crossed %v, want %v", crossed, tt.crossed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	CashSessionStatusOpen   = "open"
+	CashSessionStatusClosed = "closed"
+)
+
+const (
+	DailyClosingStatusSubmitted = "submitted"
+	DailyClosingStatusApproved  = "approved"
+)
+
+var (
+	ErrNoCashSession         = errors.New("an open cash session at the location is required to take payment")
+	ErrCashSessionStatus     = errors.New("cash session is not in a valid status for this step")
+	ErrCashSessionsNotClosed = errors.New("every cash session of the day must be closed first")
+	ErrDailyClosingStatus    = errors.New("daily closing is not in a valid status for this step")
+	ErrSalesDayClosed        = errors.New("sales for this date and location are locked by an approved daily closing")
+	ErrDailyClosingChanged   = errors.New("the sessions or sales of the day changed since the closing was submitted")
+)
+
+type OpenCashSessionTxParams struct {
+	LocationID   int32  `json:"location_id"`
+	Cashier      string `json:"cashier"`
+	OpeningFloat int64  `json:"opening_float"`
+	Note         string `json:"note"`
+}
+
+type CloseCashSessionTxParams struct {
+	SessionID   int64  `json:"session_id"`
+	CountedCash int64  `json:"counted_cash"`
+	Note        string `json:"note"`
+	ClosedBy    string `json:"closed_by"`
+}
+
+type SubmitDailyClosingTxParams struct {
+	LocationID   int32       `json:"location_id"`
+	BusinessDate pgtype.Date `json:"business_date"`
+	SubmittedBy  string      `json:"submitted_by"`
+}
+
+type ApproveDailyClosingTxParams struct {
+	DailyClosingID int64  `json:"daily_closing_id"`
+	ApprovedBy     string `json:"approved_by"`
+}
+
+type DailyClosingTxResult struct {
+	DailyClosing DailyClosing  `json:"daily_closing"`
+	Sessions     []CashSession `json:"sessions"`
+}
+
+// cashSessionOf returns the open cash session of a cashier, as long as it is
+// at the given location. A null location accepts a session anywhere.
+func (store *SQLStore) cashSessionOf(ctx context.Context, db DBTX, cashier string, locationID pgtype.Int4) (pgtype.Int8, error) {
+	session, err := store.GetOpenCashSession(ctx, db, cashier)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.Int8{}, nil
+	}
+	if err != nil {
+		return pgtype.Int8{}, err
+	}
+
+	if locationID.Valid && session.LocationID != locationID.Int32 {
+		return pgtype.Int8{}, nil
+	}
+
+	return pgtype.Int8{Int64: session.ID, Valid: true}, nil
+}
+
+// checkSalesDayOpen refuses to touch the sales of a location on a day that
+// has an approved daily closing. The day is the business date of the cash
+// session, or the date of at without one. The closing is held until the
+// transaction ends, so it cannot be approved in the meantime.
+func (store *SQLStore) checkSalesDayOpen(ctx context.Context, db DBTX, locationID int32, sessionID pgtype.Int8, at time.Time) error {
+	status, err := store.GetSalesDayClosingForShare(ctx, db, GetSalesDayClosingForShareParams{
+		LocationID:    locationID,
+		CashSessionID: sessionID,
+		At:            at,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if status == DailyClosingStatusApproved {
+		return ErrSalesDayClosed
+	}
+	return nil
+}
+
+// sumCashSessions adds up the totals of the cash sessions of a day, all of
+// which must be closed
+func sumCashSessions(sessions []CashSession) (SubmitDailyClosingParams, error) {
+	params := SubmitDailyClosingParams{SessionCount: int32(len(sessions))}
+	for _, session := range sessions {
+		if session.Status != CashSessionStatusClosed {
+			return SubmitDailyClosingParams{}, ErrCashSessionsNotClosed
+		}
+
+		params.OpeningFloat += session.OpeningFloat
+		params.CashTotal += session.CashTotal
+		params.TransferTotal += session.TransferTotal
+		params.QrisTotal += session.QrisTotal
+		params.RefundTotal += session.RefundTotal
+		params.ExpectedCash += session.ExpectedCash
+		params.CountedCash += session.CountedCash.Int64
+		params.Variance += session.Variance.Int64
+	}
+
+	return params, nil
+}
+
+// closingChanged tells whether the day totalled again differs from what was
+// submitted for the closing
+func closingChanged(closing DailyClosing, totals SubmitDailyClosingParams) bool {
+	return totals.SessionCount != closing.SessionCount ||
+		totals.OpeningFloat != closing.OpeningFloat ||
+		totals.CashTotal != closing.CashTotal ||
+		totals.TransferTotal != closing.TransferTotal ||
+		totals.QrisTotal != closing.QrisTotal ||
+		totals.RefundTotal != closing.RefundTotal ||
+		totals.CreditTotal != closing.CreditTotal ||
+		totals.CountedCash != closing.CountedCash
+}
+
+// dayClosingTotals adds up the cash sessions of a location on a business
+// date, all of which must be closed, and its credit sales
+func (store *SQLStore) dayClosingTotals(ctx context.Context, db DBTX, locationID int32, businessDate pgtype.Date) (SubmitDailyClosingParams, []CashSession, error) {
+	sessions, err := store.ListDayCashSessions(ctx, db, ListDayCashSessionsParams{
+		LocationID:   locationID,
+		BusinessDate: businessDate,
+	})
+	if err != nil {
+		return SubmitDailyClosingParams{}, nil, err
+	}
+
+	params, err := sumCashSessions(sessions)
+	if err != nil {
+		return SubmitDailyClosingParams{}, nil, err
+	}
+	params.LocationID = locationID
+	params.BusinessDate = businessDate
+
+	params.CreditTotal, err = store.SumDayCreditSales(ctx, db, SumDayCreditSalesParams{
+		LocationID:   locationID,
+		BusinessDate: businessDate,
+	})
+	if err != nil {
+		return SubmitDailyClosingParams{}, nil, err
+	}
+
+	return params, sessions, nil
+}
+
+// OpenCashSessionTx starts a cashier's shift with the float in the drawer. A
+// cashier can only have one open session, which the unique index enforces.
+// The session belongs to the business date it is opened on.
+func (store *SQLStore) OpenCashSessionTx(ctx context.Context, db TxBeginner, arg OpenCashSessionTxParams) (CashSession, error) {
+	var session CashSession
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		err := store.checkSalesDayOpen(ctx, tx, arg.LocationID, pgtype.Int8{}, time.Now())
+		if err != nil {
+			return err
+		}
+
+		session, err = store.CreateCashSession(ctx, tx, CreateCashSessionParams{
+			LocationID:   arg.LocationID,
+			Cashier:      arg.Cashier,
+			OpeningFloat: arg.OpeningFloat,
+			Note:         arg.Note,
+		})
+		return err
+	})
+
+	return session, err
+}
+
+// CloseCashSessionTx ends a shift with the cash counted in the drawer. The
+// drawer should hold the float plus the cash taken, less the deposits refunded
+// in cash; the difference is the over (positive) or short (negative) variance.
+func (store *SQLStore) CloseCashSessionTx(ctx context.Context, db TxBeginner, arg CloseCashSessionTxParams) (CashSession, error) {
+	var session CashSession
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+		session, err = store.GetCashSessionForUpdate(ctx, tx, arg.SessionID)
+		if err != nil {
+			return err
+		}
+
+		if session.Status != CashSessionStatusOpen {
+			return ErrCashSessionStatus
+		}
+
+		sessionID := pgtype.Int8{Int64: session.ID, Valid: true}
+		sales, err := store.SumCashSessionSales(ctx, tx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		payments, err := store.SumCashSessionInvoicePayments(ctx, tx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		totals := make(map[string]int64, 3)
+		for _, row := range sales {
+			totals[row.PaymentMethod] += row.Amount
+		}
+		for _, row := range payments {
+			totals[row.PaymentMethod] += row.Amount
+		}
+
+		refunds, err := store.SumCashSessionRefunds(ctx, tx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		expected := session.OpeningFloat + totals[PaymentMethodCash] - refunds
+		session, err = store.CloseCashSession(ctx, tx, CloseCashSessionParams{
+			ID:            session.ID,
+			CashTotal:     totals[PaymentMethodCash],
+			TransferTotal: totals[PaymentMethodTransfer],
+			QrisTotal:     totals[PaymentMethodQRIS],
+			RefundTotal:   refunds,
+			ExpectedCash:  expected,
+			CountedCash:   pgtype.Int8{Int64: arg.CountedCash, Valid: true},
+			Variance:      pgtype.Int8{Int64: arg.CountedCash - expected, Valid: true},
+			Note:          arg.Note,
+			ClosedBy:      pgtype.Text{String: arg.ClosedBy, Valid: true},
+		})
+		return err
+	})
+
+	return session, err
+}
+
+// SubmitDailyClosingTx totals the cash sessions of a location on a business
+// date, all of which must be closed. A closing can be submitted again until
+// it is approved.
+func (store *SQLStore) SubmitDailyClosingTx(ctx context.Context, db TxBeginner, arg SubmitDailyClosingTxParams) (DailyClosingTxResult, error) {
+	var result DailyClosingTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var params SubmitDailyClosingParams
+		var err error
+		params, result.Sessions, err = store.dayClosingTotals(ctx, tx, arg.LocationID, arg.BusinessDate)
+		if err != nil {
+			return err
+		}
+		params.SubmittedBy = arg.SubmittedBy
+
+		// an approved closing is left as it is and returns no row
+		result.DailyClosing, err = store.SubmitDailyClosing(ctx, tx, params)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrDailyClosingStatus
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// ApproveDailyClosingTx approves a submitted closing, which locks the sales of
+// its location and day. The day is totalled again first: a session opened or
+// a sale made since the closing was submitted means it has to be submitted
+// again.
+func (store *SQLStore) ApproveDailyClosingTx(ctx context.Context, db TxBeginner, arg ApproveDailyClosingTxParams) (DailyClosing, error) {
+	var closing DailyClosing
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+		closing, err = store.GetDailyClosingForUpdate(ctx, tx, arg.DailyClosingID)
+		if err != nil {
+			return err
+		}
+
+		if closing.Status != DailyClosingStatusSubmitted {
+			return ErrDailyClosingStatus
+		}
+
+		totals, _, err := store.dayClosingTotals(ctx, tx, closing.LocationID, closing.BusinessDate)
+		if err != nil {
+			return err
+		}
+
+		if closingChanged(closing, totals) {
+			return ErrDailyClosingChanged
+		}
+
+		closing, err = store.ApproveDailyClosing(ctx, tx, ApproveDailyClosingParams{
+			ID:         closing.ID,
+			ApprovedBy: pgtype.Text{String: arg.ApprovedBy, Valid: true},
+		})
+		return err
+	})
+
+	return closing, err
+}
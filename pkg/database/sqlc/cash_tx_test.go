@@ -0,0 +1,107 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func closedSession(float, cash, refunds, counted int64) CashSession {
+	expected := float + cash - refunds
+	return CashSession{
+		Status:        CashSessionStatusClosed,
+		OpeningFloat:  float,
+		CashTotal:     cash,
+		TransferTotal: 2 * cash,
+		QrisTotal:     cash / 2,
+		RefundTotal:   refunds,
+		ExpectedCash:  expected,
+		CountedCash:   pgtype.Int8{Int64: counted, Valid: true},
+		Variance:      pgtype.Int8{Int64: counted - expected, Valid: true},
+	}
+}
+
+func TestSumCashSessions(t *testing.T) {
+	tests := []struct {
+		name     string
+		sessions []CashSession
+		want     SubmitDailyClosingParams
+		err      error
+	}{
+		{"no sessions", nil, SubmitDailyClosingParams{}, nil},
+		{
+			"one session",
+			[]CashSession{closedSession(100000, 400000, 50000, 440000)},
+			SubmitDailyClosingParams{
+				SessionCount: 1, OpeningFloat: 100000, CashTotal: 400000, TransferTotal: 800000, QrisTotal: 200000,
+				RefundTotal: 50000, ExpectedCash: 450000, CountedCash: 440000, Variance: -10000,
+			},
+			nil,
+		},
+		{
+			"over and short sessions",
+			[]CashSession{closedSession(100000, 400000, 0, 510000), closedSession(50000, 200000, 0, 245000)},
+			SubmitDailyClosingParams{
+				SessionCount: 2, OpeningFloat: 150000, CashTotal: 600000, TransferTotal: 1200000, QrisTotal: 300000,
+				ExpectedCash: 750000, CountedCash: 755000, Variance: 5000,
+			},
+			nil,
+		},
+		{
+			"a session still open",
+			[]CashSession{closedSession(100000, 400000, 0, 500000), {Status: CashSessionStatusOpen, OpeningFloat: 50000}},
+			SubmitDailyClosingParams{},
+			ErrCashSessionsNotClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sumCashSessions(tt.sessions)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("sumCashSessions() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("sumCashSessions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosingChanged(t *testing.T) {
+	closing := DailyClosing{
+		SessionCount: 2, OpeningFloat: 150000, CashTotal: 600000, TransferTotal: 1200000, QrisTotal: 300000,
+		RefundTotal: 10000, CreditTotal: 250000, ExpectedCash: 740000, CountedCash: 745000, Variance: 5000,
+	}
+	submitted := SubmitDailyClosingParams{
+		SessionCount: 2, OpeningFloat: 150000, CashTotal: 600000, TransferTotal: 1200000, QrisTotal: 300000,
+		RefundTotal: 10000, CreditTotal: 250000, ExpectedCash: 740000, CountedCash: 745000, Variance: 5000,
+	}
+
+	tests := []struct {
+		name   string
+		change func(*SubmitDailyClosingParams)
+		want   bool
+	}{
+		{"unchanged", func(*SubmitDailyClosingParams) {}, false},
+		{"session opened", func(p *SubmitDailyClosingParams) { p.SessionCount++ }, true},
+		{"cash sale made", func(p *SubmitDailyClosingParams) { p.CashTotal += 20000 }, true},
+		{"transfer sale made", func(p *SubmitDailyClosingParams) { p.TransferTotal += 20000 }, true},
+		{"qris sale made", func(p *SubmitDailyClosingParams) { p.QrisTotal += 20000 }, true},
+		{"deposit refunded", func(p *SubmitDailyClosingParams) { p.RefundTotal += 20000 }, true},
+		{"credit sale made", func(p *SubmitDailyClosingParams) { p.CreditTotal += 20000 }, true},
+		{"float changed", func(p *SubmitDailyClosingParams) { p.OpeningFloat += 20000 }, true},
+		{"counted again", func(p *SubmitDailyClosingParams) { p.CountedCash -= 20000 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totals := submitted
+			tt.change(&totals)
+			if got := closingChanged(closing, totals); got != tt.want {
+				t.Errorf("closingChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	CylinderStatusFull      = "full"
+	CylinderStatusEmpty     = "empty"
+	CylinderStatusInRepair  = "in_repair"
+	CylinderStatusCondemned = "condemned"
+	// CylinderStatusQuarantined is set by an incident report until the
+	// incident is disposed of
+	CylinderStatusQuarantined = "quarantined"
+	// CylinderStatusReturned cylinders have gone back to the supplier, they
+	// keep the last location they were at
+	CylinderStatusReturned = "returned_to_supplier"
+)
+
+const (
+	CylinderOwnerCompany  = "company"
+	CylinderOwnerCustomer = "customer"
+)
+
+// Reasons recorded on cylinder movements besides the stock movement reasons
+const (
+	CylinderMovementReasonRegistered = "registered"
+	CylinderMovementReasonMove       = "move"
+)
+
+var (
+	ErrCylinderCondemned = errors.New("cylinder is condemned")
+	ErrCylinderPosition  = errors.New("a cylinder must be either at a location or held by a customer")
+	ErrCylinderNotMoved  = errors.New("cylinder is not part of the stock being moved")
+)
+
+// RegisterCylinderTxParams leaves NextTestDue out to have the cylinder due
+// CylinderRetestYears after it was made
+type RegisterCylinderTxParams struct {
+	CreateCylinderParams
+	CreatedBy string `json:"created_by"`
+}
+
+type MoveCylinderTxParams struct {
+	CylinderID int64 `json:"cylinder_id"`
+	// the cylinder goes to either a location or a customer
+	ToLocationID  pgtype.Int4 `json:"to_location_id"`
+	ToCustomerID  pgtype.Int4 `json:"to_customer_id"`
+	Status        string      `json:"status"`
+	Reason        string      `json:"reason"`
+	ReferenceType pgtype.Text `json:"reference_type"`
+	ReferenceID   pgtype.Int8 `json:"reference_id"`
+	CreatedBy     string      `json:"created_by"`
+}
+
+type CylinderTxResult struct {
+	Cylinder Cylinder         `json:"cylinder"`
+	Movement CylinderMovement `json:"movement"`
+}
+
+// cylinderKey counts the tracked cylinders of a product in a status
+type cylinderKey struct {
+	ProductID int32
+	Status    string
+}
+
+type moveSerialsParams struct {
+	// Codes are the serial numbers or QR codes of the cylinders
+	Codes []string
+	// Counts is how many cylinders of each product and status the step moves,
+	// there cannot be more serials than that
+	Counts map[cylinderKey]int32
+	// FromLocationID is where the cylinders are taken from, they keep their
+	// status. Without it they are handed in by a customer and come in empty.
+	FromLocationID pgtype.Int4
+	ToLocationID   pgtype.Int4
+	ToCustomerID   pgtype.Int4
+	Reason         string
+	ReferenceType  string
+	ReferenceID    int64
+	CreatedBy      string
+}
+
+// moveSerials moves the tracked cylinders that go with a stock movement, so
+// their history follows the stock. A cylinder past its re-test date cannot
+// go out full.
+func (store *SQLStore) moveSerials(ctx context.Context, db DBTX, arg moveSerialsParams) ([]Cylinder, error) {
+	counts := make(map[cylinderKey]int32, len(arg.Counts))
+	for key, count := range arg.Counts {
+		counts[key] = count
+	}
+
+	cylinders := make([]Cylinder, 0, len(arg.Codes))
+	for _, code := range arg.Codes {
+		found, err := store.GetCylinderByCode(ctx, db, code)
+		if err != nil {
+			return nil, fmt.Errorf("cylinder %s : %w", code, err)
+		}
+
+		cylinder, err := store.GetCylinderForUpdate(ctx, db, found.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		inService := cylinder.Status == CylinderStatusFull || cylinder.Status == CylinderStatusEmpty
+		status := cylinder.Status
+		if arg.FromLocationID.Valid {
+			inService = inService && cylinder.LocationID == arg.FromLocationID
+		} else {
+			inService = inService && cylinder.HolderCustomerID.Valid
+			status = CylinderStatusEmpty
+		}
+
+		key := cylinderKey{ProductID: cylinder.ProductID, Status: status}
+		if !inService || counts[key] == 0 {
+			return nil, fmt.Errorf("%w : %s", ErrCylinderNotMoved, code)
+		}
+		counts[key]--
+
+		moved, err := store.moveCylinder(ctx, db, cylinder, MoveCylinderTxParams{
+			CylinderID:    cylinder.ID,
+			ToLocationID:  arg.ToLocationID,
+			ToCustomerID:  arg.ToCustomerID,
+			Status:        status,
+			Reason:        arg.Reason,
+			ReferenceType: pgtype.Text{String: arg.ReferenceType, Valid: true},
+			ReferenceID:   pgtype.Int8{Int64: arg.ReferenceID, Valid: true},
+			CreatedBy:     arg.CreatedBy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cylinder %s : %w", code, err)
+		}
+		cylinders = append(cylinders, moved.Cylinder)
+	}
+
+	return cylinders, nil
+}
+
+// returnCylinders moves the tracked cylinders a document moved back where
+// they came from, latest first. A cylinder that has moved on since is left
+// where it is.
+func (store *SQLStore) returnCylinders(ctx context.Context, db DBTX, referenceType string, referenceID int64, reason string, createdBy string) ([]Cylinder, error) {
+	movements, err := store.ListReferenceCylinderMovements(ctx, db, ListReferenceCylinderMovementsParams{
+		ReferenceType: pgtype.Text{String: referenceType, Valid: true},
+		ReferenceID:   pgtype.Int8{Int64: referenceID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cylinders := make([]Cylinder, 0, len(movements))
+	for i := len(movements) - 1; i >= 0; i-- {
+		movement := movements[i]
+		cylinder, err := store.GetCylinderForUpdate(ctx, db, movement.CylinderID)
+		if err != nil {
+			return nil, err
+		}
+
+		if cylinder.LocationID != movement.ToLocationID || cylinder.HolderCustomerID != movement.ToCustomerID ||
+			cylinder.Status != movement.Status {
+			continue
+		}
+
+		moved, err := store.moveCylinder(ctx, db, cylinder, MoveCylinderTxParams{
+			CylinderID:    cylinder.ID,
+			ToLocationID:  movement.FromLocationID,
+			ToCustomerID:  movement.FromCustomerID,
+			Status:        cylinder.Status,
+			Reason:        reason,
+			ReferenceType: movement.ReferenceType,
+			ReferenceID:   movement.ReferenceID,
+			CreatedBy:     createdBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		cylinders = append(cylinders, moved.Cylinder)
+	}
+
+	return cylinders, nil
+}
+
+// moveCylinder puts a locked cylinder at its new place with its new status
+// and appends the change to the cylinder's history. A cylinder past its
+// re-test date cannot go out full.
+func (store *SQLStore) moveCylinder(ctx context.Context, db DBTX, cylinder Cylinder, arg MoveCylinderTxParams) (CylinderTxResult, error) {
+	var result CylinderTxResult
+
+	if arg.ToLocationID.Valid == arg.ToCustomerID.Valid {
+		return result, ErrCylinderPosition
+	}
+
+	if cylinder.Status == CylinderStatusCondemned {
+		return result, ErrCylinderCondemned
+	}
+
+	if testOverdue(cylinder, arg) {
+		return result, ErrCylinderTestOverdue
+	}
+
+	var err error
+	result.Movement, err = store.CreateCylinderMovement(ctx, db, CreateCylinderMovementParams{
+		CylinderID:     cylinder.ID,
+		FromLocationID: cylinder.LocationID,
+		ToLocationID:   arg.ToLocationID,
+		FromCustomerID: cylinder.HolderCustomerID,
+		ToCustomerID:   arg.ToCustomerID,
+		Status:         arg.Status,
+		Reason:         arg.Reason,
+		ReferenceType:  arg.ReferenceType,
+		ReferenceID:    arg.ReferenceID,
+		CreatedBy:      arg.CreatedBy,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Cylinder, err = store.UpdateCylinderPosition(ctx, db, UpdateCylinderPositionParams{
+		ID:               cylinder.ID,
+		Status:           arg.Status,
+		LocationID:       arg.ToLocationID,
+		HolderCustomerID: arg.ToCustomerID,
+	})
+	return result, err
+}
+
+// RegisterCylinderTx adds a cylinder to tracking, its first history entry
+// records where it was registered
+func (store *SQLStore) RegisterCylinderTx(ctx context.Context, db TxBeginner, arg RegisterCylinderTxParams) (CylinderTxResult, error) {
+	var result CylinderTxResult
+
+	if arg.LocationID.Valid == arg.HolderCustomerID.Valid {
+		return result, ErrCylinderPosition
+	}
+
+	if !arg.NextTestDue.Valid {
+		arg.NextTestDue = pgtype.Date{Time: arg.ManufactureDate.Time.AddDate(CylinderRetestYears, 0, 0), Valid: true}
+	}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+
+		result.Cylinder, err = store.CreateCylinder(ctx, tx, arg.CreateCylinderParams)
+		if err != nil {
+			return err
+		}
+
+		result.Movement, err = store.CreateCylinderMovement(ctx, tx, CreateCylinderMovementParams{
+			CylinderID:   result.Cylinder.ID,
+			ToLocationID: result.Cylinder.LocationID,
+			ToCustomerID: result.Cylinder.HolderCustomerID,
+			Status:       result.Cylinder.Status,
+			Reason:       CylinderMovementReasonRegistered,
+			CreatedBy:    arg.CreatedBy,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// MoveCylinderTx records a cylinder changing place, holder or status
+func (store *SQLStore) MoveCylinderTx(ctx context.Context, db TxBeginner, arg MoveCylinderTxParams) (CylinderTxResult, error) {
+	var result CylinderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		cylinder, err := store.GetCylinderForUpdate(ctx, tx, arg.CylinderID)
+		if err != nil {
+			return err
+		}
+
+		// only disposing of its incident takes a cylinder out of quarantine
+		if cylinder.Status == CylinderStatusQuarantined || cylinder.Status == CylinderStatusReturned {
+			return ErrCylinderQuarantined
+		}
+
+		result, err = store.moveCylinder(ctx, tx, cylinder, arg)
+		return err
+	})
+
+	return result, err
+}
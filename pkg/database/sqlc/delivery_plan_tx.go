@@ -0,0 +1,336 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blanc08/stok-gas-management-backend/pkg/routing"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrPlanNoDepotCoordinates = errors.New("source location has no coordinates to plan routes from")
+	ErrPlanIncomplete         = errors.New("the plan must place every stop of the planned orders exactly once")
+)
+
+type PlanDeliveriesParams struct {
+	SourceLocationID int32       `json:"source_location_id"`
+	ScheduledDate    pgtype.Date `json:"scheduled_date"`
+}
+
+type PlannedStop struct {
+	StopID int64 `json:"stop_id"`
+	// DeliveryOrderID is the order the stop currently belongs to
+	DeliveryOrderID int64          `json:"delivery_order_id"`
+	CustomerID      int32          `json:"customer_id"`
+	Point           *routing.Point `json:"point"`
+	Load            routing.Load   `json:"load"`
+}
+
+// DeliveryPlanRoute is the sequence of stops proposed for one of the planned
+// orders, and so for its vehicle and driver
+type DeliveryPlanRoute struct {
+	DeliveryOrderID int64         `json:"delivery_order_id"`
+	VehicleID       int32         `json:"vehicle_id"`
+	DriverID        int32         `json:"driver_id"`
+	Stops           []PlannedStop `json:"stops"`
+	Load            routing.Load  `json:"load"`
+	DistanceKm      float64       `json:"distance_km"`
+}
+
+// DeliveryPlan is a draft, nothing changes until the dispatcher accepts it
+type DeliveryPlan struct {
+	SourceLocationID int32               `json:"source_location_id"`
+	ScheduledDate    pgtype.Date         `json:"scheduled_date"`
+	Routes           []DeliveryPlanRoute `json:"routes"`
+	// Unassigned stops fit on none of the vehicles or their customer has no
+	// coordinates, the dispatcher places them by hand
+	Unassigned []PlannedStop `json:"unassigned"`
+	DistanceKm float64       `json:"distance_km"`
+	// CurrentDistanceKm is the distance of the orders as they are sequenced now
+	CurrentDistanceKm float64 `json:"current_distance_km"`
+}
+
+type AcceptedRouteParams struct {
+	DeliveryOrderID int64   `json:"delivery_order_id"`
+	StopIDs         []int64 `json:"stop_ids"`
+}
+
+type AcceptDeliveryPlanTxParams struct {
+	SourceLocationID int32                 `json:"source_location_id"`
+	ScheduledDate    pgtype.Date           `json:"scheduled_date"`
+	Routes           []AcceptedRouteParams `json:"routes"`
+}
+
+// plannedStops loads the stops of the planned orders with what each one is
+// to receive
+func (store *SQLStore) plannedStops(ctx context.Context, db DBTX, arg PlanDeliveriesParams) ([]PlannedStop, error) {
+	rows, err := store.ListPlannedDeliveryStops(ctx, db, ListPlannedDeliveryStopsParams(arg))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := store.ListPlannedDeliveryStopItems(ctx, db, ListPlannedDeliveryStopItemsParams(arg))
+	if err != nil {
+		return nil, err
+	}
+
+	loads := make(map[int64]routing.Load, len(rows))
+	for _, item := range items {
+		if loads[item.DeliveryStopID] == nil {
+			loads[item.DeliveryStopID] = routing.Load{}
+		}
+		loads[item.DeliveryStopID][item.ProductID] += item.Quantity
+	}
+
+	stops := make([]PlannedStop, 0, len(rows))
+	for _, row := range rows {
+		stop := PlannedStop{
+			StopID:          row.ID,
+			DeliveryOrderID: row.DeliveryOrderID,
+			CustomerID:      row.CustomerID,
+			Load:            loads[row.ID],
+		}
+		if row.Latitude.Valid && row.Longitude.Valid {
+			stop.Point = &routing.Point{Latitude: row.Latitude.Float64, Longitude: row.Longitude.Float64}
+		}
+		stops = append(stops, stop)
+	}
+
+	return stops, nil
+}
+
+// vehicleCapacity returns the capacity of a vehicle per product
+func (store *SQLStore) vehicleCapacity(ctx context.Context, db DBTX, vehicleID int32) (routing.Load, error) {
+	capacities, err := store.ListVehicleCapacities(ctx, db, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := make(routing.Load, len(capacities))
+	for _, row := range capacities {
+		capacity[row.ProductID] = row.Capacity
+	}
+	return capacity, nil
+}
+
+// PlanDeliveries proposes how to spread the stops of the planned orders of a
+// source location and day over their vehicles, and in which order to visit
+// them. Every planned order gets a route, an order left without stops is
+// cancelled if the plan is accepted.
+func (store *SQLStore) PlanDeliveries(ctx context.Context, db DBTX, arg PlanDeliveriesParams) (DeliveryPlan, error) {
+	plan := DeliveryPlan{
+		SourceLocationID: arg.SourceLocationID,
+		ScheduledDate:    arg.ScheduledDate,
+		Routes:           []DeliveryPlanRoute{},
+		Unassigned:       []PlannedStop{},
+	}
+
+	source, err := store.GetLocation(ctx, db, arg.SourceLocationID)
+	if err != nil {
+		return plan, err
+	}
+
+	if !source.Latitude.Valid || !source.Longitude.Valid {
+		return plan, ErrPlanNoDepotCoordinates
+	}
+	depot := routing.Point{Latitude: source.Latitude.Float64, Longitude: source.Longitude.Float64}
+
+	orders, err := store.ListPlannedDeliveryOrders(ctx, db, ListPlannedDeliveryOrdersParams(arg))
+	if err != nil {
+		return plan, err
+	}
+
+	stops, err := store.plannedStops(ctx, db, arg)
+	if err != nil {
+		return plan, err
+	}
+
+	// the orders keep their vehicle, a vehicle with several orders makes a
+	// trip for each of them
+	capacities := make(map[int32]routing.Load)
+	vehicles := make([]routing.Vehicle, 0, len(orders))
+	for _, order := range orders {
+		capacity, ok := capacities[order.VehicleID]
+		if !ok {
+			capacity, err = store.vehicleCapacity(ctx, db, order.VehicleID)
+			if err != nil {
+				return plan, err
+			}
+			capacities[order.VehicleID] = capacity
+		}
+		vehicles = append(vehicles, routing.Vehicle{ID: order.VehicleID, Capacity: capacity})
+	}
+
+	byID := make(map[int64]PlannedStop, len(stops))
+	current := make(map[int64][]routing.Stop)
+	routable := make([]routing.Stop, 0, len(stops))
+	for _, stop := range stops {
+		byID[stop.StopID] = stop
+		if stop.Point == nil {
+			plan.Unassigned = append(plan.Unassigned, stop)
+			continue
+		}
+
+		routingStop := routing.Stop{ID: stop.StopID, Point: *stop.Point, Load: stop.Load}
+		routable = append(routable, routingStop)
+		current[stop.DeliveryOrderID] = append(current[stop.DeliveryOrderID], routingStop)
+	}
+
+	for _, tour := range current {
+		plan.CurrentDistanceKm += routing.TourDistance(depot, tour)
+	}
+
+	// the solver returns a route per vehicle in the order given, so one per
+	// planned order
+	solved := routing.Solve(depot, vehicles, routable)
+	for i, route := range solved.Routes {
+		order := orders[i]
+		planned := DeliveryPlanRoute{
+			DeliveryOrderID: order.ID,
+			VehicleID:       order.VehicleID,
+			DriverID:        order.DriverID,
+			Stops:           make([]PlannedStop, 0, len(route.Stops)),
+			Load:            route.Load,
+			DistanceKm:      route.DistanceKm,
+		}
+		for _, stop := range route.Stops {
+			planned.Stops = append(planned.Stops, byID[stop.ID])
+		}
+		plan.Routes = append(plan.Routes, planned)
+	}
+
+	for _, stop := range solved.Unassigned {
+		plan.Unassigned = append(plan.Unassigned, byID[stop.ID])
+	}
+	plan.DistanceKm = solved.DistanceKm
+
+	return plan, nil
+}
+
+// AcceptDeliveryPlanTx applies a plan, possibly edited by the dispatcher:
+// stops are moved to the order of their route and renumbered in route order.
+// Every stop of the day's planned orders must be placed and every route must
+// fit on its vehicle. An order left without stops is cancelled.
+func (store *SQLStore) AcceptDeliveryPlanTx(ctx context.Context, db TxBeginner, arg AcceptDeliveryPlanTxParams) ([]DeliveryOrderTxResult, error) {
+	var results []DeliveryOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		orders, err := store.ListPlannedDeliveryOrdersForUpdate(ctx, tx, ListPlannedDeliveryOrdersForUpdateParams{
+			SourceLocationID: arg.SourceLocationID,
+			ScheduledDate:    arg.ScheduledDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		planned := make(map[int64]DeliveryOrder, len(orders))
+		for _, order := range orders {
+			planned[order.ID] = order
+		}
+
+		stops, err := store.plannedStops(ctx, tx, PlanDeliveriesParams{
+			SourceLocationID: arg.SourceLocationID,
+			ScheduledDate:    arg.ScheduledDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		unplaced := make(map[int64]PlannedStop, len(stops))
+		for _, stop := range stops {
+			unplaced[stop.StopID] = stop
+		}
+
+		routed := make(map[int64]bool, len(arg.Routes))
+		for _, route := range arg.Routes {
+			order, ok := planned[route.DeliveryOrderID]
+			if !ok || routed[order.ID] {
+				return ErrDeliveryStatus
+			}
+			routed[order.ID] = true
+
+			capacity, err := store.vehicleCapacity(ctx, tx, order.VehicleID)
+			if err != nil {
+				return err
+			}
+
+			load := routing.Load{}
+			for _, stopID := range route.StopIDs {
+				stop, ok := unplaced[stopID]
+				if !ok {
+					return ErrPlanIncomplete
+				}
+				delete(unplaced, stopID)
+
+				for productID, quantity := range stop.Load {
+					load[productID] += quantity
+					if load[productID] > capacity[productID] {
+						return ErrVehicleCapacity
+					}
+				}
+			}
+		}
+
+		if len(unplaced) > 0 {
+			return ErrPlanIncomplete
+		}
+
+		// stops are first parked on a sequence of their own so renumbering
+		// never collides with a stop that has not moved yet
+		for _, stop := range stops {
+			err = store.MoveDeliveryStop(ctx, tx, MoveDeliveryStopParams{
+				ID:              stop.StopID,
+				DeliveryOrderID: stop.DeliveryOrderID,
+				Sequence:        -int32(stop.StopID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, route := range arg.Routes {
+			for i, stopID := range route.StopIDs {
+				err = store.MoveDeliveryStop(ctx, tx, MoveDeliveryStopParams{
+					ID:              stopID,
+					DeliveryOrderID: route.DeliveryOrderID,
+					Sequence:        int32(i + 1),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		results = make([]DeliveryOrderTxResult, 0, len(orders))
+		for _, order := range orders {
+			var route AcceptedRouteParams
+			for _, r := range arg.Routes {
+				if r.DeliveryOrderID == order.ID {
+					route = r
+				}
+			}
+
+			if len(route.StopIDs) == 0 {
+				order, err = store.UpdateDeliveryOrderStatus(ctx, tx, UpdateDeliveryOrderStatusParams{
+					ID:     order.ID,
+					Status: DeliveryStatusCancelled,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			result, err := store.deliveryOrderResult(ctx, tx, order)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+
+		return nil
+	})
+
+	return results, err
+}
@@ -0,0 +1,674 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	DeliveryStatusPlanned    = "planned"
+	DeliveryStatusConfirmed  = "confirmed"
+	DeliveryStatusLoaded     = "loaded"
+	DeliveryStatusEnRoute    = "en_route"
+	DeliveryStatusDelivered  = "delivered"
+	DeliveryStatusReconciled = "reconciled"
+	DeliveryStatusCancelled  = "cancelled"
+)
+
+const (
+	DeliveryStopStatusPending   = "pending"
+	DeliveryStopStatusCompleted = "completed"
+	DeliveryStopStatusFailed    = "failed"
+)
+
+var (
+	ErrDeliveryStatus         = errors.New("delivery order is not in a valid status for this step")
+	ErrDeliveryEmpty          = errors.New("delivery order has no stops or a stop has no items")
+	ErrDeliveryStopStatus     = errors.New("delivery stop is already closed")
+	ErrDeliveryUnknownItem    = errors.New("product is not part of the delivery stop")
+	ErrDeliveryOverDrop       = errors.New("delivered quantity exceeds the quantity planned for the stop")
+	ErrStopEmptiesWithoutDrop = errors.New("empties can only be collected together with a delivery of the product")
+	ErrStopNothingDelivered   = errors.New("nothing was delivered at the stop, fail the stop instead")
+	ErrVehicleCapacity        = errors.New("delivery order exceeds the capacity of the vehicle")
+	ErrVehicleInactive        = errors.New("vehicle is not active")
+	ErrDriverInactive         = errors.New("driver is not active")
+	ErrDeliveryProofStatus    = errors.New("proof of delivery can only be recorded for a completed stop")
+	ErrDeliveryProofRecorded  = errors.New("proof of delivery has already been recorded for the stop")
+)
+
+type DeliveryStopItemParams struct {
+	ProductID        int32 `json:"product_id"`
+	Quantity         int32 `json:"quantity"`
+	EmptiesToCollect int32 `json:"empties_to_collect"`
+}
+
+type DeliveryStopParams struct {
+	CustomerID int32                    `json:"customer_id"`
+	Note       string                   `json:"note"`
+	Items      []DeliveryStopItemParams `json:"items"`
+}
+
+type CreateDeliveryOrderTxParams struct {
+	SourceLocationID int32       `json:"source_location_id"`
+	VehicleID        int32       `json:"vehicle_id"`
+	DriverID         int32       `json:"driver_id"`
+	ScheduledDate    pgtype.Date `json:"scheduled_date"`
+	Note             string      `json:"note"`
+	// Stops are visited in the given order
+	Stops     []DeliveryStopParams `json:"stops"`
+	CreatedBy string               `json:"created_by"`
+}
+
+type ConfirmDeliveryOrderTxParams struct {
+	DeliveryOrderID int64 `json:"delivery_order_id"`
+	// ExpiresAt releases the stock reserved for the order if it has not been
+	// loaded by then
+	ExpiresAt   time.Time `json:"expires_at"`
+	ConfirmedBy string    `json:"confirmed_by"`
+}
+
+type DeliveryOrderStepTxParams struct {
+	DeliveryOrderID int64  `json:"delivery_order_id"`
+	CreatedBy       string `json:"created_by"`
+}
+
+type LoadDeliveryOrderTxParams struct {
+	DeliveryOrderID int64 `json:"delivery_order_id"`
+	// Serials are the serial numbers or QR codes of the tracked cylinders
+	// loaded
+	Serials   []string `json:"serials"`
+	CreatedBy string   `json:"created_by"`
+}
+
+type DeliveredItemParams struct {
+	ProductID        int32 `json:"product_id"`
+	DeliveredQty     int32 `json:"delivered_qty"`
+	EmptiesCollected int32 `json:"empties_collected"`
+	// Serials and EmptySerials are the serial numbers or QR codes of the
+	// tracked cylinders dropped and collected
+	Serials      []string `json:"serials"`
+	EmptySerials []string `json:"empty_serials"`
+}
+
+type CompleteDeliveryStopTxParams struct {
+	DeliveryOrderID int64                 `json:"delivery_order_id"`
+	StopID          int64                 `json:"stop_id"`
+	Items           []DeliveredItemParams `json:"items"`
+	PaymentMethod   string                `json:"payment_method"`
+	TaxBasisPoints  int64                 `json:"tax_basis_points"`
+	Note            string                `json:"note"`
+	CompletedBy     string                `json:"completed_by"`
+}
+
+type FailDeliveryStopTxParams struct {
+	DeliveryOrderID int64  `json:"delivery_order_id"`
+	StopID          int64  `json:"stop_id"`
+	Note            string `json:"note"`
+	CompletedBy     string `json:"completed_by"`
+}
+
+// RecordDeliveryProofTxParams carries the keys of the signature and photo
+// already put in storage
+type RecordDeliveryProofTxParams struct {
+	DeliveryOrderID int64   `json:"delivery_order_id"`
+	StopID          int64   `json:"stop_id"`
+	RecipientName   string  `json:"recipient_name"`
+	SignatureKey    string  `json:"signature_key"`
+	PhotoKey        string  `json:"photo_key"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	CapturedBy      string  `json:"captured_by"`
+}
+
+type DeliveryOrderTxResult struct {
+	DeliveryOrder DeliveryOrder      `json:"delivery_order"`
+	Stops         []DeliveryStop     `json:"stops"`
+	Items         []DeliveryStopItem `json:"items"`
+	// Reservations are the stock reservations changed by the step
+	Reservations []StockReservation `json:"reservations,omitempty"`
+	// Sale is only set when a stop is completed
+	Sale *SaleTxResult `json:"sale,omitempty"`
+	// Cylinders are the tracked cylinders loaded
+	Cylinders []Cylinder `json:"cylinders,omitempty"`
+}
+
+// deliveryOrderResult loads the stops and items of an order
+func (store *SQLStore) deliveryOrderResult(ctx context.Context, db DBTX, order DeliveryOrder) (DeliveryOrderTxResult, error) {
+	result := DeliveryOrderTxResult{DeliveryOrder: order}
+
+	var err error
+	result.Stops, err = store.ListDeliveryStops(ctx, db, order.ID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Items, err = store.ListDeliveryStopItems(ctx, db, order.ID)
+	return result, err
+}
+
+// lockDeliveryOrder locks an order that must be in one of the given statuses
+func (store *SQLStore) lockDeliveryOrder(ctx context.Context, db DBTX, id int64, statuses ...string) (DeliveryOrder, error) {
+	order, err := store.GetDeliveryOrderForUpdate(ctx, db, id)
+	if err != nil {
+		return order, err
+	}
+
+	for _, status := range statuses {
+		if order.Status == status {
+			return order, nil
+		}
+	}
+
+	return order, ErrDeliveryStatus
+}
+
+// deliveryTotals sums what every stop of the order is to receive, by product
+func deliveryTotals(stops []DeliveryStopParams) map[int32]int32 {
+	totals := make(map[int32]int32)
+	for _, stop := range stops {
+		for _, item := range stop.Items {
+			totals[item.ProductID] += item.Quantity
+		}
+	}
+	return totals
+}
+
+// CreateDeliveryOrderTx plans a delivery with its stops. Everything to drop
+// must fit on the vehicle, a product without a capacity cannot be carried.
+func (store *SQLStore) CreateDeliveryOrderTx(ctx context.Context, db TxBeginner, arg CreateDeliveryOrderTxParams) (DeliveryOrderTxResult, error) {
+	var result DeliveryOrderTxResult
+
+	if len(arg.Stops) == 0 {
+		return result, ErrDeliveryEmpty
+	}
+
+	for _, stop := range arg.Stops {
+		if len(stop.Items) == 0 {
+			return result, ErrDeliveryEmpty
+		}
+	}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		vehicle, err := store.GetVehicle(ctx, tx, arg.VehicleID)
+		if err != nil {
+			return err
+		}
+
+		if !vehicle.IsActive {
+			return ErrVehicleInactive
+		}
+
+		driver, err := store.GetDriver(ctx, tx, arg.DriverID)
+		if err != nil {
+			return err
+		}
+
+		if !driver.IsActive {
+			return ErrDriverInactive
+		}
+
+		capacities, err := store.ListVehicleCapacities(ctx, tx, vehicle.ID)
+		if err != nil {
+			return err
+		}
+
+		capacity := make(map[int32]int32, len(capacities))
+		for _, row := range capacities {
+			capacity[row.ProductID] = row.Capacity
+		}
+
+		for productID, quantity := range deliveryTotals(arg.Stops) {
+			if quantity > capacity[productID] {
+				return ErrVehicleCapacity
+			}
+		}
+
+		order, err := store.CreateDeliveryOrder(ctx, tx, CreateDeliveryOrderParams{
+			SourceLocationID: arg.SourceLocationID,
+			VehicleID:        vehicle.ID,
+			DriverID:         driver.ID,
+			ScheduledDate:    arg.ScheduledDate,
+			Note:             arg.Note,
+			CreatedBy:        arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, stop := range arg.Stops {
+			created, err := store.CreateDeliveryStop(ctx, tx, CreateDeliveryStopParams{
+				DeliveryOrderID: order.ID,
+				Sequence:        int32(i + 1),
+				CustomerID:      stop.CustomerID,
+				Note:            stop.Note,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, item := range stop.Items {
+				_, err = store.CreateDeliveryStopItem(ctx, tx, CreateDeliveryStopItemParams{
+					DeliveryStopID:   created.ID,
+					ProductID:        item.ProductID,
+					Quantity:         item.Quantity,
+					EmptiesToCollect: item.EmptiesToCollect,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		result, err = store.deliveryOrderResult(ctx, tx, order)
+		return err
+	})
+
+	return result, err
+}
+
+// ConfirmDeliveryOrderTx commits to a planned delivery, the cylinders to drop
+// are reserved at the source location so the counter cannot sell them
+func (store *SQLStore) ConfirmDeliveryOrderTx(ctx context.Context, db TxBeginner, arg ConfirmDeliveryOrderTxParams) (DeliveryOrderTxResult, error) {
+	var result DeliveryOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.lockDeliveryOrder(ctx, tx, arg.DeliveryOrderID, DeliveryStatusPlanned)
+		if err != nil {
+			return err
+		}
+
+		totals, err := store.SumDeliveryOrderItems(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		items := make([]ReservationItemParams, 0, len(totals))
+		for _, total := range totals {
+			items = append(items, ReservationItemParams{
+				ProductID: total.ProductID,
+				Quantity:  total.Quantity,
+			})
+		}
+
+		reserved, err := store.reserveStock(ctx, tx, ReserveStockParams{
+			LocationID:    order.SourceLocationID,
+			Items:         items,
+			ReferenceType: ReferenceTypeDeliveryOrder,
+			ReferenceID:   order.ID,
+			ExpiresAt:     arg.ExpiresAt,
+			CreatedBy:     arg.ConfirmedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		order, err = store.UpdateDeliveryOrderStatus(ctx, tx, UpdateDeliveryOrderStatusParams{
+			ID:     order.ID,
+			Status: DeliveryStatusConfirmed,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = store.deliveryOrderResult(ctx, tx, order)
+		result.Reservations = reserved.Reservations
+		return err
+	})
+
+	return result, err
+}
+
+// LoadDeliveryOrderTx puts the cylinders of a confirmed order on the vehicle,
+// its reservation is fulfilled and the stock moves from the source location
+// to the vehicle's location. Tracked cylinders loaded go with it, none of
+// them may be overdue for its re-test.
+func (store *SQLStore) LoadDeliveryOrderTx(ctx context.Context, db TxBeginner, arg LoadDeliveryOrderTxParams) (DeliveryOrderTxResult, error) {
+	var result DeliveryOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.lockDeliveryOrder(ctx, tx, arg.DeliveryOrderID, DeliveryStatusConfirmed)
+		if err != nil {
+			return err
+		}
+
+		vehicle, err := store.GetVehicle(ctx, tx, order.VehicleID)
+		if err != nil {
+			return err
+		}
+
+		released, err := store.releaseReservations(ctx, tx, ReferenceTypeDeliveryOrder, order.ID, ReservationStatusFulfilled)
+		if err != nil {
+			return err
+		}
+
+		totals, err := store.SumDeliveryOrderItems(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		counts := make(map[cylinderKey]int32, len(totals))
+		for _, total := range totals {
+			counts[cylinderKey{ProductID: total.ProductID, Status: CylinderStatusFull}] += total.Quantity
+
+			err = store.moveStock(ctx, tx, moveStockParams{
+				FromLocationID: order.SourceLocationID,
+				ToLocationID:   vehicle.LocationID,
+				ProductID:      total.ProductID,
+				FullQty:        total.Quantity,
+				Reason:         MovementReasonDeliveryLoad,
+				ReferenceType:  ReferenceTypeDeliveryOrder,
+				ReferenceID:    order.ID,
+				CreatedBy:      arg.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		cylinders, err := store.moveSerials(ctx, tx, moveSerialsParams{
+			Codes:          arg.Serials,
+			Counts:         counts,
+			FromLocationID: pgtype.Int4{Int32: order.SourceLocationID, Valid: true},
+			ToLocationID:   pgtype.Int4{Int32: vehicle.LocationID, Valid: true},
+			Reason:         MovementReasonDeliveryLoad,
+			ReferenceType:  ReferenceTypeDeliveryOrder,
+			ReferenceID:    order.ID,
+			CreatedBy:      arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		order, err = store.UpdateDeliveryOrderStatus(ctx, tx, UpdateDeliveryOrderStatusParams{
+			ID:     order.ID,
+			Status: DeliveryStatusLoaded,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = store.deliveryOrderResult(ctx, tx, order)
+		result.Reservations = released.Reservations
+		result.Cylinders = cylinders
+		return err
+	})
+
+	return result, err
+}
+
+// DepartDeliveryOrderTx sends a loaded vehicle on its way
+func (store *SQLStore) DepartDeliveryOrderTx(ctx context.Context, db TxBeginner, arg DeliveryOrderStepTxParams) (DeliveryOrderTxResult, error) {
+	var result DeliveryOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.lockDeliveryOrder(ctx, tx, arg.DeliveryOrderID, DeliveryStatusLoaded)
+		if err != nil {
+			return err
+		}
+
+		order, err = store.UpdateDeliveryOrderStatus(ctx, tx, UpdateDeliveryOrderStatusParams{
+			ID:     order.ID,
+			Status: DeliveryStatusEnRoute,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = store.deliveryOrderResult(ctx, tx, order)
+		return err
+	})
+
+	return result, err
+}
+
+// closeDeliveryStop closes a pending stop of an order en route, the order is
+// delivered once its last stop is closed
+func (store *SQLStore) closeDeliveryStop(ctx context.Context, tx pgx.Tx, order DeliveryOrder, arg CloseDeliveryStopParams) (DeliveryOrder, error) {
+	_, err := store.CloseDeliveryStop(ctx, tx, arg)
+	if err != nil {
+		return order, err
+	}
+
+	pending, err := store.CountPendingDeliveryStops(ctx, tx, order.ID)
+	if err != nil || pending > 0 {
+		return order, err
+	}
+
+	return store.UpdateDeliveryOrderStatus(ctx, tx, UpdateDeliveryOrderStatusParams{
+		ID:     order.ID,
+		Status: DeliveryStatusDelivered,
+	})
+}
+
+// CompleteDeliveryStopTx records what was dropped at a stop and the empties
+// picked up there. The exchange is posted as a sale to the stop's customer
+// out of the vehicle's stock, priced as at the depot the delivery set out
+// from so the ceiling price of its region applies. Tracked cylinders dropped
+// go to the customer and those collected onto the vehicle.
+func (store *SQLStore) CompleteDeliveryStopTx(ctx context.Context, db TxBeginner, arg CompleteDeliveryStopTxParams) (DeliveryOrderTxResult, error) {
+	var result DeliveryOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.lockDeliveryOrder(ctx, tx, arg.DeliveryOrderID, DeliveryStatusEnRoute)
+		if err != nil {
+			return err
+		}
+
+		stop, err := store.GetDeliveryStopForUpdate(ctx, tx, GetDeliveryStopForUpdateParams{
+			ID:              arg.StopID,
+			DeliveryOrderID: order.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if stop.Status != DeliveryStopStatusPending {
+			return ErrDeliveryStopStatus
+		}
+
+		vehicle, err := store.GetVehicle(ctx, tx, order.VehicleID)
+		if err != nil {
+			return err
+		}
+
+		orderItems, err := store.ListDeliveryStopItems(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		planned := make(map[int32]DeliveryStopItem)
+		for _, item := range orderItems {
+			if item.DeliveryStopID == stop.ID {
+				planned[item.ProductID] = item
+			}
+		}
+
+		saleItems := make([]SaleItemParams, 0, len(arg.Items))
+		for _, delivered := range arg.Items {
+			item, ok := planned[delivered.ProductID]
+			if !ok {
+				return ErrDeliveryUnknownItem
+			}
+
+			if delivered.DeliveredQty > item.Quantity {
+				return ErrDeliveryOverDrop
+			}
+
+			if delivered.DeliveredQty == 0 {
+				if delivered.EmptiesCollected > 0 {
+					return ErrStopEmptiesWithoutDrop
+				}
+				continue
+			}
+
+			_, err = store.UpdateDeliveryStopItemResult(ctx, tx, UpdateDeliveryStopItemResultParams{
+				ID:               item.ID,
+				DeliveredQty:     delivered.DeliveredQty,
+				EmptiesCollected: delivered.EmptiesCollected,
+			})
+			if err != nil {
+				return err
+			}
+
+			saleItems = append(saleItems, SaleItemParams{
+				ProductID:       delivered.ProductID,
+				SaleType:        SaleTypeExchange,
+				Quantity:        delivered.DeliveredQty,
+				EmptiesReturned: delivered.EmptiesCollected,
+				Serials:         delivered.Serials,
+				EmptySerials:    delivered.EmptySerials,
+			})
+		}
+
+		// a stop where nothing was dropped is failed with a reason, it is not
+		// an empty sale
+		if len(saleItems) == 0 {
+			return ErrStopNothingDelivered
+		}
+
+		sale, err := store.CreateSaleTx(ctx, tx, CreateSaleTxParams{
+			LocationID:      vehicle.LocationID,
+			PriceLocationID: order.SourceLocationID,
+			CustomerID:      pgtype.Int4{Int32: stop.CustomerID, Valid: true},
+			PaymentMethod:   arg.PaymentMethod,
+			TaxBasisPoints:  arg.TaxBasisPoints,
+			Note:            fmt.Sprintf("delivery order %d stop %d", order.ID, stop.Sequence),
+			Items:           saleItems,
+			CreatedBy:       arg.CompletedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		order, err = store.closeDeliveryStop(ctx, tx, order, CloseDeliveryStopParams{
+			ID:          stop.ID,
+			Status:      DeliveryStopStatusCompleted,
+			SaleID:      pgtype.Int8{Int64: sale.Sale.ID, Valid: true},
+			Note:        arg.Note,
+			CompletedBy: pgtype.Text{String: arg.CompletedBy, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = store.deliveryOrderResult(ctx, tx, order)
+		result.Sale = &sale
+		return err
+	})
+
+	return result, err
+}
+
+// FailDeliveryStopTx closes a stop where nothing could be delivered, its
+// cylinders stay on the vehicle
+func (store *SQLStore) FailDeliveryStopTx(ctx context.Context, db TxBeginner, arg FailDeliveryStopTxParams) (DeliveryOrderTxResult, error) {
+	var result DeliveryOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.lockDeliveryOrder(ctx, tx, arg.DeliveryOrderID, DeliveryStatusEnRoute)
+		if err != nil {
+			return err
+		}
+
+		stop, err := store.GetDeliveryStopForUpdate(ctx, tx, GetDeliveryStopForUpdateParams{
+			ID:              arg.StopID,
+			DeliveryOrderID: order.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if stop.Status != DeliveryStopStatusPending {
+			return ErrDeliveryStopStatus
+		}
+
+		order, err = store.closeDeliveryStop(ctx, tx, order, CloseDeliveryStopParams{
+			ID:          stop.ID,
+			Status:      DeliveryStopStatusFailed,
+			Note:        arg.Note,
+			CompletedBy: pgtype.Text{String: arg.CompletedBy, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = store.deliveryOrderResult(ctx, tx, order)
+		return err
+	})
+
+	return result, err
+}
+
+// CancelDeliveryOrderTx drops an order that has not been loaded yet, any
+// stock reserved for it is released
+func (store *SQLStore) CancelDeliveryOrderTx(ctx context.Context, db TxBeginner, arg DeliveryOrderStepTxParams) (DeliveryOrderTxResult, error) {
+	var result DeliveryOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.lockDeliveryOrder(ctx, tx, arg.DeliveryOrderID, DeliveryStatusPlanned, DeliveryStatusConfirmed)
+		if err != nil {
+			return err
+		}
+
+		released, err := store.releaseReservations(ctx, tx, ReferenceTypeDeliveryOrder, order.ID, ReservationStatusReleased)
+		if err != nil {
+			return err
+		}
+
+		order, err = store.UpdateDeliveryOrderStatus(ctx, tx, UpdateDeliveryOrderStatusParams{
+			ID:     order.ID,
+			Status: DeliveryStatusCancelled,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = store.deliveryOrderResult(ctx, tx, order)
+		result.Reservations = released.Reservations
+		return err
+	})
+
+	return result, err
+}
+
+// RecordDeliveryProofTx links the proof captured at a completed stop to it.
+// A proof is kept as the evidence of the delivery, it cannot be replaced.
+func (store *SQLStore) RecordDeliveryProofTx(ctx context.Context, db TxBeginner, arg RecordDeliveryProofTxParams) (DeliveryStop, error) {
+	var stop DeliveryStop
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		current, err := store.GetDeliveryStopForUpdate(ctx, tx, GetDeliveryStopForUpdateParams{
+			ID:              arg.StopID,
+			DeliveryOrderID: arg.DeliveryOrderID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if current.Status != DeliveryStopStatusCompleted {
+			return ErrDeliveryProofStatus
+		}
+
+		if current.ProofCapturedAt.Valid {
+			return ErrDeliveryProofRecorded
+		}
+
+		stop, err = store.RecordDeliveryProof(ctx, tx, RecordDeliveryProofParams{
+			ID:              current.ID,
+			RecipientName:   arg.RecipientName,
+			SignatureKey:    arg.SignatureKey,
+			PhotoKey:        arg.PhotoKey,
+			Latitude:        arg.Latitude,
+			Longitude:       arg.Longitude,
+			ProofCapturedBy: arg.CapturedBy,
+		})
+		return err
+	})
+
+	return stop, err
+}
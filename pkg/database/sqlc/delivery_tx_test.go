@@ -0,0 +1,34 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeliveryTotals(t *testing.T) {
+	stop := func(items ...DeliveryStopItemParams) DeliveryStopParams {
+		return DeliveryStopParams{Items: items}
+	}
+	item := func(productID, quantity int32) DeliveryStopItemParams {
+		return DeliveryStopItemParams{ProductID: productID, Quantity: quantity, EmptiesToCollect: quantity}
+	}
+
+	tests := []struct {
+		name  string
+		stops []DeliveryStopParams
+		want  map[int32]int32
+	}{
+		{"no stops", nil, map[int32]int32{}},
+		{"one stop", []DeliveryStopParams{stop(item(1, 3), item(2, 1))}, map[int32]int32{1: 3, 2: 1}},
+		{"product on several stops", []DeliveryStopParams{stop(item(1, 3)), stop(item(1, 2), item(2, 4))}, map[int32]int32{1: 5, 2: 4}},
+		{"product twice on a stop", []DeliveryStopParams{stop(item(1, 3), item(1, 1))}, map[int32]int32{1: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deliveryTotals(tt.stops); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deliveryTotals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	DepositStatusHeld              = "held"
+	DepositStatusPartiallyRefunded = "partially_refunded"
+	DepositStatusRefunded          = "refunded"
+	DepositStatusVoided            = "voided"
+)
+
+var (
+	ErrDepositRefundExceedsHeld = errors.New("refund quantity exceeds the cylinders still held on deposit")
+	ErrDepositAlreadyRefunded   = errors.New("deposit has already been refunded")
+)
+
+type RefundDepositTxParams struct {
+	// a refund is taken from the given deposit receipt, or else from the
+	// customer's deposits for the product, oldest first
+	DepositID  pgtype.Int8 `json:"deposit_id"`
+	CustomerID pgtype.Int4 `json:"customer_id"`
+	ProductID  int32       `json:"product_id"`
+	LocationID int32       `json:"location_id"`
+	Quantity   int32       `json:"quantity"`
+	RefundedBy string      `json:"refunded_by"`
+}
+
+type RefundDepositTxResult struct {
+	Deposits     []CylinderDeposit `json:"deposits"`
+	Refunds      []DepositRefund   `json:"refunds"`
+	Amount       int64             `json:"amount"`
+	StockBalance StockBalance      `json:"stock_balance"`
+}
+
+// DepositReconciliation compares the deposit records of a location and
+// product with the sales and stock movements they stand for
+type DepositReconciliation struct {
+	LocationID int32 `json:"location_id"`
+	ProductID  int32 `json:"product_id"`
+	// DepositedQty is held on deposit records, SoldQty was sold as new cylinders
+	DepositedQty int64 `json:"deposited_qty"`
+	SoldQty      int64 `json:"sold_qty"`
+	// RefundedQty is refunded on deposit records, ReturnedQty was posted to
+	// the stock ledger as returned empties
+	RefundedQty int64 `json:"refunded_qty"`
+	ReturnedQty int64 `json:"returned_qty"`
+	Balanced    bool  `json:"balanced"`
+}
+
+// voidSaleDeposits cancels the deposits taken by a voided sale, cylinders
+// already refunded must be sold back before the sale can be voided
+func (store *SQLStore) voidSaleDeposits(ctx context.Context, db DBTX, saleID int64) ([]CylinderDeposit, error) {
+	deposits, err := store.ListSaleDepositsForUpdate(ctx, db, pgtype.Int8{Int64: saleID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, deposit := range deposits {
+		if deposit.RefundedQty > 0 {
+			return nil, ErrDepositAlreadyRefunded
+		}
+
+		deposits[i], err = store.VoidCylinderDeposit(ctx, db, deposit.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return deposits, nil
+}
+
+// RefundDepositTx pays back the deposit on empties the customer hands in.
+// Each refund is posted to the location's stock as returned empties, at the
+// amount the deposit was taken for.
+func (store *SQLStore) RefundDepositTx(ctx context.Context, db TxBeginner, arg RefundDepositTxParams) (RefundDepositTxResult, error) {
+	var result RefundDepositTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		deposits, err := store.ListRefundableDepositsForUpdate(ctx, tx, ListRefundableDepositsForUpdateParams{
+			ProductID:  arg.ProductID,
+			DepositID:  arg.DepositID,
+			CustomerID: arg.CustomerID,
+		})
+		if err != nil {
+			return err
+		}
+
+		var held int32
+		for _, deposit := range deposits {
+			held += deposit.Quantity - deposit.RefundedQty
+		}
+
+		if arg.Quantity > held {
+			return ErrDepositRefundExceedsHeld
+		}
+
+		sessionID, err := store.cashSessionOf(ctx, tx, arg.RefundedBy, pgtype.Int4{Int32: arg.LocationID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		remaining := arg.Quantity
+		result.Deposits = []CylinderDeposit{}
+		result.Refunds = []DepositRefund{}
+		for _, deposit := range deposits {
+			if remaining == 0 {
+				break
+			}
+
+			quantity := min(remaining, deposit.Quantity-deposit.RefundedQty)
+			remaining -= quantity
+
+			refund, err := store.CreateDepositRefund(ctx, tx, CreateDepositRefundParams{
+				DepositID:     deposit.ID,
+				LocationID:    arg.LocationID,
+				Quantity:      quantity,
+				Amount:        int64(quantity) * deposit.AmountPerUnit,
+				RefundedBy:    arg.RefundedBy,
+				CashSessionID: sessionID,
+			})
+			if err != nil {
+				return err
+			}
+			result.Refunds = append(result.Refunds, refund)
+			result.Amount += refund.Amount
+
+			deposit, err = store.AddDepositRefund(ctx, tx, AddDepositRefundParams{
+				Quantity: quantity,
+				ID:       deposit.ID,
+			})
+			if err != nil {
+				return err
+			}
+			result.Deposits = append(result.Deposits, deposit)
+
+			result.StockBalance, err = store.postStockMovement(ctx, tx, CreateStockMovementParams{
+				LocationID:     arg.LocationID,
+				ProductID:      arg.ProductID,
+				EmptyQtyChange: quantity,
+				Reason:         MovementReasonDepositRefund,
+				ReferenceType:  ReferenceTypeDepositRefund,
+				ReferenceID:    refund.ID,
+				CreatedBy:      arg.RefundedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ReconcileDeposits lists, per location and product, whether the deposits
+// held match the new cylinders sold and whether the refunds match the
+// empties posted to the stock ledger
+func (store *SQLStore) ReconcileDeposits(ctx context.Context, db DBTX) ([]DepositReconciliation, error) {
+	type key struct {
+		locationID int32
+		productID  int32
+	}
+
+	rows := map[key]*DepositReconciliation{}
+	keys := []key{}
+	row := func(locationID int32, productID int32) *DepositReconciliation {
+		k := key{locationID, productID}
+		if rows[k] == nil {
+			rows[k] = &DepositReconciliation{LocationID: locationID, ProductID: productID}
+			keys = append(keys, k)
+		}
+		return rows[k]
+	}
+
+	liabilities, err := store.SumDepositLiabilities(ctx, db, pgtype.Int4{})
+	if err != nil {
+		return nil, err
+	}
+	for _, liability := range liabilities {
+		row(liability.LocationID, liability.ProductID).DepositedQty = liability.DepositedQty
+	}
+
+	sales, err := store.SumNewCylinderSales(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, sale := range sales {
+		row(sale.LocationID, sale.ProductID).SoldQty = sale.Quantity
+	}
+
+	refunds, err := store.SumDepositRefundsByLocation(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, refund := range refunds {
+		row(refund.LocationID, refund.ProductID).RefundedQty = refund.Quantity
+	}
+
+	movements, err := store.SumDepositRefundMovements(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, movement := range movements {
+		row(movement.LocationID, movement.ProductID).ReturnedQty = movement.Quantity
+	}
+
+	result := make([]DepositReconciliation, 0, len(keys))
+	for _, k := range keys {
+		r := rows[k]
+		r.Balanced = r.DepositedQty == r.SoldQty && r.RefundedQty == r.ReturnedQty
+		result = append(result, *r)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/blanc08/stok-gas-management-backend/pkg/forecast"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ForecastDemandParams struct {
+	LocationID int32               `json:"location_id"`
+	ProductID  int32               `json:"product_id"`
+	Forecaster forecast.Forecaster `json:"-"`
+	// HistoryDays of sales up to yesterday are fitted, HorizonDays are
+	// forecast from today on
+	HistoryDays int       `json:"history_days"`
+	HorizonDays int       `json:"horizon_days"`
+	Today       time.Time `json:"today"`
+}
+
+type DemandForecast struct {
+	LocationID int32            `json:"location_id"`
+	ProductID  int32            `json:"product_id"`
+	Daily      []forecast.Point `json:"daily"`
+	Weekly     []forecast.Point `json:"weekly"`
+	Total      float64          `json:"total"`
+}
+
+func dateOf(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: forecast.Day(t), Valid: true}
+}
+
+// listForecastHolidays returns the holidays touching the days from from to to
+func (store *SQLStore) listForecastHolidays(ctx context.Context, db DBTX, from time.Time, to time.Time) ([]forecast.Holiday, error) {
+	rows, err := store.ListHolidays(ctx, db, ListHolidaysParams{
+		FromDate: dateOf(from),
+		ToDate:   dateOf(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make([]forecast.Holiday, 0, len(rows))
+	for _, row := range rows {
+		holidays = append(holidays, forecast.Holiday{
+			Start:      row.StartsOn.Time,
+			End:        row.EndsOn.Time,
+			Multiplier: row.DemandMultiplier,
+		})
+	}
+	return holidays, nil
+}
+
+// forecastDemand fits the daily sales of a product at a location, taken from
+// the stock ledger net of voided sales, with days without sales counted as 0
+func (store *SQLStore) forecastDemand(ctx context.Context, db DBTX, arg ForecastDemandParams, holidays []forecast.Holiday) (DemandForecast, error) {
+	result := DemandForecast{LocationID: arg.LocationID, ProductID: arg.ProductID}
+
+	today := forecast.Day(arg.Today)
+	from := today.AddDate(0, 0, -arg.HistoryDays)
+	rows, err := store.ListDailyDemand(ctx, db, ListDailyDemandParams{
+		LocationID: arg.LocationID,
+		ProductID:  arg.ProductID,
+		FromDate:   dateOf(from),
+		ToDate:     dateOf(today),
+	})
+	if err != nil {
+		return result, err
+	}
+
+	history := make([]float64, arg.HistoryDays)
+	for _, row := range rows {
+		day := int(row.Day.Time.Sub(from).Hours() / 24)
+		if day >= 0 && day < len(history) {
+			history[day] = float64(row.Quantity)
+		}
+	}
+
+	result.Daily, err = forecast.Daily(arg.Forecaster, from, history, arg.HorizonDays, holidays)
+	if err != nil {
+		return result, err
+	}
+
+	result.Weekly = forecast.Weekly(result.Daily)
+	for _, point := range result.Daily {
+		result.Total += point.Quantity
+	}
+	return result, nil
+}
+
+// ForecastDemand forecasts the daily and weekly demand of a product at a
+// location, holidays ahead raise the forecast by their demand multiplier
+func (store *SQLStore) ForecastDemand(ctx context.Context, db DBTX, arg ForecastDemandParams) (DemandForecast, error) {
+	today := forecast.Day(arg.Today)
+	holidays, err := store.listForecastHolidays(ctx, db,
+		today.AddDate(0, 0, -arg.HistoryDays), today.AddDate(0, 0, arg.HorizonDays))
+	if err != nil {
+		return DemandForecast{}, err
+	}
+
+	return store.forecastDemand(ctx, db, arg, holidays)
+}
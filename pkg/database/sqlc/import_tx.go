@@ -0,0 +1,355 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blanc08/stok-gas-management-backend/pkg/importer"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// An import job is pending until the worker runs it. It is then completed
+// when its rows were imported, validated when a dry run found no errors,
+// rejected when any row had errors, and failed when the file could not be
+// imported at all.
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusValidated = "validated"
+	ImportStatusRejected  = "rejected"
+	ImportStatusFailed    = "failed"
+)
+
+var ErrImportKind = errors.New("unknown import kind")
+
+// errImportRolledBack undoes an import that was only checked or had errors
+var errImportRolledBack = errors.New("import rolled back")
+
+// ImportResult counts the records an import created and updated, or would
+// have for a dry run or an import refused for its errors
+type ImportResult struct {
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Errors  []importer.RowError `json:"errors"`
+}
+
+type ImportProductsTxParams struct {
+	Rows   []importer.ProductRow `json:"rows"`
+	DryRun bool                  `json:"dry_run"`
+}
+
+type ImportCustomersTxParams struct {
+	Rows   []importer.CustomerRow `json:"rows"`
+	DryRun bool                   `json:"dry_run"`
+}
+
+type ImportOpeningStockTxParams struct {
+	ImportJobID int64                      `json:"import_job_id"`
+	LocationID  int32                      `json:"location_id"`
+	Rows        []importer.OpeningStockRow `json:"rows"`
+	DryRun      bool                       `json:"dry_run"`
+	CreatedBy   string                     `json:"created_by"`
+}
+
+type FinishImportJobTxParams struct {
+	ImportJob ImportJob
+	Total     int
+	Result    ImportResult
+	// Err is why the file could not be imported at all
+	Err error
+}
+
+// importRow applies one row of a file, it reports whether a record was
+// created rather than updated
+type importRow struct {
+	line  int
+	apply func(tx pgx.Tx) (bool, error)
+}
+
+// importRows applies every row under its own savepoint, so a row the
+// database refuses is reported by its line while the others go on. The
+// import lands as a whole or not at all, it is rolled back when it is a dry
+// run or any row failed.
+func (store *SQLStore) importRows(ctx context.Context, db TxBeginner, dryRun bool, rows []importRow) (ImportResult, error) {
+	result := ImportResult{Errors: []importer.RowError{}}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		for _, row := range rows {
+			var created bool
+			err := store.execTx(ctx, tx, func(tx pgx.Tx) error {
+				var err error
+				created, err = row.apply(tx)
+				return err
+			})
+
+			if rowErr, ok := importRowError(err); ok {
+				rowErr.Line = row.line
+				result.Errors = append(result.Errors, rowErr)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if created {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+		}
+
+		if dryRun || len(result.Errors) > 0 {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	if errors.Is(err, errImportRolledBack) {
+		err = nil
+	}
+
+	return result, err
+}
+
+// importRowError tells the errors caused by the content of a row from those
+// that stop the whole import
+func importRowError(err error) (importer.RowError, bool) {
+	var rowErr importer.RowError
+	if errors.As(err, &rowErr) {
+		return rowErr, true
+	}
+
+	// data exceptions and integrity constraint violations
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		message := pgErr.Message
+		if pgErr.Detail != "" {
+			message = pgErr.Detail
+		}
+		return importer.RowError{Column: pgErr.ColumnName, Message: message}, true
+	}
+
+	if errors.Is(err, ErrInsufficientStock) || errors.Is(err, ErrStockReserved) {
+		return importer.RowError{Message: err.Error()}, true
+	}
+
+	return importer.RowError{}, false
+}
+
+// ImportProductsTx creates the products of a file and updates those whose
+// code is already known
+func (store *SQLStore) ImportProductsTx(ctx context.Context, db TxBeginner, arg ImportProductsTxParams) (ImportResult, error) {
+	rows := make([]importRow, len(arg.Rows))
+	for i, row := range arg.Rows {
+		rows[i] = importRow{line: row.Line, apply: func(tx pgx.Tx) (bool, error) {
+			product, err := store.UpsertProduct(ctx, tx, UpsertProductParams{
+				Code:           row.Code,
+				Name:           row.Name,
+				NetWeightGrams: row.NetWeightGrams,
+				IsSubsidized:   row.IsSubsidized,
+				Price:          row.Price,
+				DepositAmount:  row.DepositAmount,
+			})
+			return product.Inserted, err
+		}}
+	}
+
+	return store.importRows(ctx, db, arg.DryRun, rows)
+}
+
+// ImportCustomersTx registers the customers of a file and updates those
+// already registered under one of their phone number, NIK or NIB. An empty
+// cell keeps what the customer already has.
+func (store *SQLStore) ImportCustomersTx(ctx context.Context, db TxBeginner, arg ImportCustomersTxParams) (ImportResult, error) {
+	rows := make([]importRow, len(arg.Rows))
+	for i, row := range arg.Rows {
+		rows[i] = importRow{line: row.Line, apply: func(tx pgx.Tx) (bool, error) {
+			return store.importCustomer(ctx, tx, row)
+		}}
+	}
+
+	return store.importRows(ctx, db, arg.DryRun, rows)
+}
+
+func (store *SQLStore) importCustomer(ctx context.Context, tx pgx.Tx, row importer.CustomerRow) (bool, error) {
+	phone := pgtype.Text{String: row.Phone, Valid: row.Phone != ""}
+	nationalID := pgtype.Text{String: row.NationalID, Valid: row.NationalID != ""}
+	businessID := pgtype.Text{String: row.BusinessID, Valid: row.BusinessID != ""}
+	address := pgtype.Text{String: row.Address, Valid: row.Address != ""}
+
+	matches, err := store.FindDuplicateCustomers(ctx, tx, FindDuplicateCustomersParams{
+		Phone:      phone,
+		NationalID: nationalID,
+		BusinessID: businessID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if len(matches) > 1 {
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = fmt.Sprint(match.ID)
+		}
+		return false, importer.RowError{Message: "matches more than one customer : " + strings.Join(ids, ", ")}
+	}
+
+	if len(matches) == 0 {
+		paymentTermDays := int32(30)
+		if row.PaymentTermDays != nil {
+			paymentTermDays = *row.PaymentTermDays
+		}
+
+		_, err = store.CreateCustomer(ctx, tx, CreateCustomerParams{
+			Name:            row.Name,
+			CustomerType:    row.CustomerType,
+			Phone:           phone,
+			NationalID:      nationalID,
+			BusinessID:      businessID,
+			Address:         address,
+			CreditLimit:     row.CreditLimit,
+			PaymentTermDays: paymentTermDays,
+		})
+		return true, err
+	}
+
+	customer := matches[0]
+	if !phone.Valid {
+		phone = customer.Phone
+	}
+	if !nationalID.Valid {
+		nationalID = customer.NationalID
+	}
+	if !businessID.Valid {
+		businessID = customer.BusinessID
+	}
+	if !address.Valid {
+		address = customer.Address
+	}
+	paymentTermDays := customer.PaymentTermDays
+	if row.PaymentTermDays != nil {
+		paymentTermDays = *row.PaymentTermDays
+	}
+
+	_, err = store.UpdateCustomer(ctx, tx, UpdateCustomerParams{
+		ID:              customer.ID,
+		Name:            row.Name,
+		CustomerType:    row.CustomerType,
+		Phone:           phone,
+		NationalID:      nationalID,
+		BusinessID:      businessID,
+		Address:         address,
+		CreditLimit:     row.CreditLimit,
+		PaymentTermDays: paymentTermDays,
+		Latitude:        customer.Latitude,
+		Longitude:       customer.Longitude,
+	})
+	return false, err
+}
+
+// ImportOpeningStockTx brings the balance of every product of a file at the
+// location to the quantities given, posting the difference as an opening
+// movement. Importing the same file again posts nothing.
+func (store *SQLStore) ImportOpeningStockTx(ctx context.Context, db TxBeginner, arg ImportOpeningStockTxParams) (ImportResult, error) {
+	rows := make([]importRow, len(arg.Rows))
+	for i, row := range arg.Rows {
+		rows[i] = importRow{line: row.Line, apply: func(tx pgx.Tx) (bool, error) {
+			return store.importOpeningStock(ctx, tx, arg, row)
+		}}
+	}
+
+	return store.importRows(ctx, db, arg.DryRun, rows)
+}
+
+func (store *SQLStore) importOpeningStock(ctx context.Context, tx pgx.Tx, arg ImportOpeningStockTxParams, row importer.OpeningStockRow) (bool, error) {
+	product, err := store.GetProductByCode(ctx, tx, row.ProductCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, importer.RowError{Column: "product_code", Message: "no product has this code"}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	created := false
+	balance, err := store.GetStockBalanceForUpdate(ctx, tx, GetStockBalanceForUpdateParams{
+		LocationID: arg.LocationID,
+		ProductID:  product.ID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		created, err = true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	fullChange := row.FullQty - balance.FullQty
+	emptyChange := row.EmptyQty - balance.EmptyQty
+	if fullChange == 0 && emptyChange == 0 {
+		return created, nil
+	}
+
+	// reserved stock cannot be taken away by an import either
+	_, err = store.postUnreservedStockMovement(ctx, tx, CreateStockMovementParams{
+		LocationID:     arg.LocationID,
+		ProductID:      product.ID,
+		FullQtyChange:  fullChange,
+		EmptyQtyChange: emptyChange,
+		Reason:         MovementReasonOpening,
+		ReferenceType:  ReferenceTypeImportJob,
+		ReferenceID:    arg.ImportJobID,
+		CreatedBy:      arg.CreatedBy,
+	})
+	return created, err
+}
+
+// FinishImportJobTx records the outcome of an import job along with the
+// errors of its rows
+func (store *SQLStore) FinishImportJobTx(ctx context.Context, db TxBeginner, arg FinishImportJobTxParams) (ImportJob, error) {
+	var job ImportJob
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		params := FinishImportJobParams{
+			ID:          arg.ImportJob.ID,
+			Status:      ImportStatusCompleted,
+			TotalRows:   int32(arg.Total),
+			CreatedRows: int32(arg.Result.Created),
+			UpdatedRows: int32(arg.Result.Updated),
+		}
+		switch {
+		case arg.Err != nil:
+			params.Status = ImportStatusFailed
+			params.Error = pgtype.Text{String: arg.Err.Error(), Valid: true}
+		case len(arg.Result.Errors) > 0:
+			params.Status = ImportStatusRejected
+		case arg.ImportJob.DryRun:
+			params.Status = ImportStatusValidated
+		}
+
+		// a row can have more than one error
+		lines := make(map[int]bool)
+		for _, rowErr := range arg.Result.Errors {
+			lines[rowErr.Line] = true
+
+			_, err := store.CreateImportJobError(ctx, tx, CreateImportJobErrorParams{
+				ImportJobID: arg.ImportJob.ID,
+				RowNumber:   int32(rowErr.Line),
+				ColumnName:  rowErr.Column,
+				Message:     rowErr.Message,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		params.ErrorRows = int32(len(lines))
+
+		var err error
+		job, err = store.FinishImportJob(ctx, tx, params)
+		return err
+	})
+
+	return job, err
+}
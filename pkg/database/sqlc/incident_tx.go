@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	IncidentTypeDamaged = "damaged"
+	IncidentTypeLeaking = "leaking"
+)
+
+const (
+	IncidentStatusQuarantined        = "quarantined"
+	IncidentStatusRepaired           = "repaired"
+	IncidentStatusReturnedToSupplier = "returned_to_supplier"
+	IncidentStatusScrapped           = "scrapped"
+)
+
+// Dispositions closing a quarantined incident
+const (
+	IncidentDispositionRepair           = "repair"
+	IncidentDispositionReturnToSupplier = "return_to_supplier"
+	IncidentDispositionScrap            = "scrap"
+)
+
+var (
+	ErrIncidentStatus       = errors.New("incident is not in a valid status for this step")
+	ErrInvalidDisposition   = errors.New("invalid incident disposition")
+	ErrCylinderQuarantined  = errors.New("cylinder is quarantined until its incident is disposed of")
+	ErrCylinderNotInService = errors.New("only a full or empty cylinder can be quarantined")
+)
+
+type ReportIncidentTxParams struct {
+	IncidentType string `json:"incident_type"`
+	LocationID   int32  `json:"location_id"`
+	ProductID    int32  `json:"product_id"`
+	// CylinderID is set for a serialized cylinder, its status then tells
+	// whether it was counted as full or empty
+	CylinderID    pgtype.Int8 `json:"cylinder_id"`
+	CylinderState string      `json:"cylinder_state"`
+	Reason        string      `json:"reason"`
+	ReportedBy    string      `json:"reported_by"`
+}
+
+type DisposeIncidentTxParams struct {
+	IncidentID  int64  `json:"incident_id"`
+	Disposition string `json:"disposition"`
+	Note        string `json:"note"`
+	DisposedBy  string `json:"disposed_by"`
+}
+
+type IncidentTxResult struct {
+	Incident     Incident        `json:"incident"`
+	Photos       []IncidentPhoto `json:"photos"`
+	StockBalance StockBalance    `json:"stock_balance"`
+	// Cylinder is only set for a serialized cylinder
+	Cylinder *Cylinder `json:"cylinder,omitempty"`
+}
+
+// ReportIncidentTx records a damaged or leaking cylinder and moves it out of
+// the location's full or empty stock into quarantine. Stock reserved at the
+// location cannot be quarantined, the reservation has to be released first.
+// Photos are uploaded to the incident once it is reported.
+func (store *SQLStore) ReportIncidentTx(ctx context.Context, db TxBeginner, arg ReportIncidentTxParams) (IncidentTxResult, error) {
+	var result IncidentTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var cylinder Cylinder
+		if arg.CylinderID.Valid {
+			var err error
+			cylinder, err = store.GetCylinderForUpdate(ctx, tx, arg.CylinderID.Int64)
+			if err != nil {
+				return err
+			}
+
+			if cylinder.Status != CylinderStatusFull && cylinder.Status != CylinderStatusEmpty {
+				return ErrCylinderNotInService
+			}
+
+			arg.ProductID = cylinder.ProductID
+			arg.CylinderState = cylinder.Status
+		}
+
+		var err error
+		result.Incident, err = store.CreateIncident(ctx, tx, CreateIncidentParams{
+			IncidentType:  arg.IncidentType,
+			LocationID:    arg.LocationID,
+			ProductID:     arg.ProductID,
+			CylinderID:    arg.CylinderID,
+			CylinderState: arg.CylinderState,
+			Reason:        arg.Reason,
+			ReportedBy:    arg.ReportedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		movement := CreateStockMovementParams{
+			LocationID:          arg.LocationID,
+			ProductID:           arg.ProductID,
+			QuarantineQtyChange: 1,
+			Reason:              MovementReasonQuarantine,
+			ReferenceType:       ReferenceTypeIncident,
+			ReferenceID:         result.Incident.ID,
+			CreatedBy:           arg.ReportedBy,
+		}
+		if arg.CylinderState == CylinderStatusFull {
+			movement.FullQtyChange = -1
+		} else {
+			movement.EmptyQtyChange = -1
+		}
+
+		result.StockBalance, err = store.postUnreservedStockMovement(ctx, tx, movement)
+		if err != nil {
+			return err
+		}
+
+		if arg.CylinderID.Valid {
+			moved, err := store.moveCylinder(ctx, tx, cylinder, MoveCylinderTxParams{
+				CylinderID:    cylinder.ID,
+				ToLocationID:  pgtype.Int4{Int32: arg.LocationID, Valid: true},
+				Status:        CylinderStatusQuarantined,
+				Reason:        MovementReasonQuarantine,
+				ReferenceType: pgtype.Text{String: ReferenceTypeIncident, Valid: true},
+				ReferenceID:   pgtype.Int8{Int64: result.Incident.ID, Valid: true},
+				CreatedBy:     arg.ReportedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Cylinder = &moved.Cylinder
+		}
+
+		result.Photos = []IncidentPhoto{}
+		return nil
+	})
+
+	return result, err
+}
+
+// DisposeIncidentTx takes a cylinder out of quarantine. A repaired cylinder
+// goes back into the location's empty stock, one returned to the supplier or
+// scrapped leaves the stock altogether. The incident itself is kept.
+func (store *SQLStore) DisposeIncidentTx(ctx context.Context, db TxBeginner, arg DisposeIncidentTxParams) (IncidentTxResult, error) {
+	var result IncidentTxResult
+
+	movement := CreateStockMovementParams{
+		QuarantineQtyChange: -1,
+		ReferenceType:       ReferenceTypeIncident,
+		ReferenceID:         arg.IncidentID,
+		CreatedBy:           arg.DisposedBy,
+	}
+
+	var status, cylinderStatus string
+	switch arg.Disposition {
+	case IncidentDispositionRepair:
+		status, cylinderStatus = IncidentStatusRepaired, CylinderStatusEmpty
+		movement.EmptyQtyChange = 1
+		movement.Reason = MovementReasonRepaired
+	case IncidentDispositionReturnToSupplier:
+		status, cylinderStatus = IncidentStatusReturnedToSupplier, CylinderStatusReturned
+		movement.Reason = MovementReasonSupplierReturn
+	case IncidentDispositionScrap:
+		status, cylinderStatus = IncidentStatusScrapped, CylinderStatusCondemned
+		movement.Reason = MovementReasonScrapped
+	default:
+		return result, ErrInvalidDisposition
+	}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		incident, err := store.GetIncidentForUpdate(ctx, tx, arg.IncidentID)
+		if err != nil {
+			return err
+		}
+
+		if incident.Status != IncidentStatusQuarantined {
+			return ErrIncidentStatus
+		}
+
+		movement.LocationID = incident.LocationID
+		movement.ProductID = incident.ProductID
+		result.StockBalance, err = store.postStockMovement(ctx, tx, movement)
+		if err != nil {
+			return err
+		}
+
+		if incident.CylinderID.Valid {
+			cylinder, err := store.GetCylinderForUpdate(ctx, tx, incident.CylinderID.Int64)
+			if err != nil {
+				return err
+			}
+
+			moved, err := store.moveCylinder(ctx, tx, cylinder, MoveCylinderTxParams{
+				CylinderID:    cylinder.ID,
+				ToLocationID:  pgtype.Int4{Int32: incident.LocationID, Valid: true},
+				Status:        cylinderStatus,
+				Reason:        movement.Reason,
+				ReferenceType: pgtype.Text{String: ReferenceTypeIncident, Valid: true},
+				ReferenceID:   pgtype.Int8{Int64: incident.ID, Valid: true},
+				CreatedBy:     arg.DisposedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Cylinder = &moved.Cylinder
+		}
+
+		result.Incident, err = store.DisposeIncident(ctx, tx, DisposeIncidentParams{
+			ID:              incident.ID,
+			Status:          status,
+			DispositionNote: pgtype.Text{String: arg.Note, Valid: arg.Note != ""},
+			DisposedBy:      pgtype.Text{String: arg.DisposedBy, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Photos, err = store.ListIncidentPhotos(ctx, tx, incident.ID)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	InspectionResultPass = "pass"
+	InspectionResultFail = "fail"
+)
+
+// CylinderRetestYears is how long a passed cylinder stays in service before
+// it must be re-tested, unless the inspection sets its own next due date
+const CylinderRetestYears = 5
+
+const CylinderMovementReasonInspectionFailed = "inspection_failed"
+
+var ErrCylinderTestOverdue = errors.New("cylinder is overdue for its pressure re-test and cannot be dispatched full")
+
+type RecordInspectionTxParams struct {
+	CylinderID int64       `json:"cylinder_id"`
+	TestDate   pgtype.Date `json:"test_date"`
+	Result     string      `json:"result"`
+	// NextDueDate defaults to CylinderRetestYears after the test date
+	NextDueDate pgtype.Date `json:"next_due_date"`
+	Inspector   string      `json:"inspector"`
+	Note        string      `json:"note"`
+	CreatedBy   string      `json:"created_by"`
+}
+
+type InspectionTxResult struct {
+	Cylinder   Cylinder           `json:"cylinder"`
+	Inspection CylinderInspection `json:"inspection"`
+	// StockBalance is only set when a failed cylinder is written off the
+	// stock of the location it was at
+	StockBalance *StockBalance `json:"stock_balance,omitempty"`
+}
+
+// Today returns the current date the way date columns store it
+func Today() pgtype.Date {
+	year, month, day := time.Now().Date()
+	return pgtype.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+// testOverdue tells whether moving the cylinder full to somewhere else would
+// put a cylinder past its re-test date back into circulation. One coming back
+// from a customer is taken out of circulation.
+func testOverdue(cylinder Cylinder, arg MoveCylinderTxParams) bool {
+	if arg.Status != CylinderStatusFull {
+		return false
+	}
+
+	if arg.ToLocationID == cylinder.LocationID && arg.ToCustomerID == cylinder.HolderCustomerID {
+		return false
+	}
+
+	if cylinder.HolderCustomerID.Valid && arg.ToLocationID.Valid {
+		return false
+	}
+
+	return cylinder.NextTestDue.Time.Before(Today().Time)
+}
+
+// RecordInspectionTx records a pressure re-test. A failed cylinder is
+// condemned where it stands so it can never be filled again, and written off
+// the full or empty stock of the location it was at.
+func (store *SQLStore) RecordInspectionTx(ctx context.Context, db TxBeginner, arg RecordInspectionTxParams) (InspectionTxResult, error) {
+	var result InspectionTxResult
+
+	nextDue := arg.NextDueDate
+	if !nextDue.Valid {
+		nextDue = pgtype.Date{Time: arg.TestDate.Time.AddDate(CylinderRetestYears, 0, 0), Valid: true}
+	}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		cylinder, err := store.GetCylinderForUpdate(ctx, tx, arg.CylinderID)
+		if err != nil {
+			return err
+		}
+
+		if cylinder.Status == CylinderStatusCondemned {
+			return ErrCylinderCondemned
+		}
+
+		// a quarantined cylinder is tested as part of disposing of its
+		// incident, condemning it here would leave the incident open for good
+		if cylinder.Status == CylinderStatusQuarantined || cylinder.Status == CylinderStatusReturned {
+			return ErrCylinderQuarantined
+		}
+
+		result.Inspection, err = store.CreateCylinderInspection(ctx, tx, CreateCylinderInspectionParams{
+			CylinderID:  cylinder.ID,
+			TestDate:    arg.TestDate,
+			Result:      arg.Result,
+			NextDueDate: nextDue,
+			Inspector:   arg.Inspector,
+			Note:        arg.Note,
+			CreatedBy:   arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Cylinder, err = store.UpdateCylinderTest(ctx, tx, UpdateCylinderTestParams{
+			TestDate:    arg.TestDate,
+			Result:      pgtype.Text{String: arg.Result, Valid: true},
+			NextTestDue: nextDue,
+			ID:          cylinder.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.Result == InspectionResultFail {
+			moved, err := store.moveCylinder(ctx, tx, result.Cylinder, MoveCylinderTxParams{
+				CylinderID:    cylinder.ID,
+				ToLocationID:  result.Cylinder.LocationID,
+				ToCustomerID:  result.Cylinder.HolderCustomerID,
+				Status:        CylinderStatusCondemned,
+				Reason:        CylinderMovementReasonInspectionFailed,
+				ReferenceType: pgtype.Text{String: ReferenceTypeInspection, Valid: true},
+				ReferenceID:   pgtype.Int8{Int64: result.Inspection.ID, Valid: true},
+				CreatedBy:     arg.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Cylinder = moved.Cylinder
+
+			if !cylinder.LocationID.Valid {
+				return nil
+			}
+
+			movement := CreateStockMovementParams{
+				LocationID:    cylinder.LocationID.Int32,
+				ProductID:     cylinder.ProductID,
+				Reason:        MovementReasonCondemned,
+				ReferenceType: ReferenceTypeInspection,
+				ReferenceID:   result.Inspection.ID,
+				CreatedBy:     arg.CreatedBy,
+			}
+			switch cylinder.Status {
+			case CylinderStatusFull:
+				movement.FullQtyChange = -1
+			case CylinderStatusEmpty:
+				movement.EmptyQtyChange = -1
+			default:
+				// a cylinder in repair is not counted in the stock
+				return nil
+			}
+
+			balance, err := store.postStockMovement(ctx, tx, movement)
+			if err != nil {
+				return err
+			}
+			result.StockBalance = &balance
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestTestOverdue(t *testing.T) {
+	today := Today().Time
+	due := func(days int) pgtype.Date {
+		return pgtype.Date{Time: today.AddDate(0, 0, days), Valid: true}
+	}
+	depot := pgtype.Int4{Int32: 1, Valid: true}
+	outlet := pgtype.Int4{Int32: 2, Valid: true}
+	customer := pgtype.Int4{Int32: 7, Valid: true}
+
+	tests := []struct {
+		name     string
+		cylinder Cylinder
+		move     MoveCylinderTxParams
+		want     bool
+	}{
+		{
+			name:     "overdue going out full",
+			cylinder: Cylinder{Status: CylinderStatusFull, LocationID: depot, NextTestDue: due(-1)},
+			move:     MoveCylinderTxParams{ToLocationID: outlet, Status: CylinderStatusFull},
+			want:     true,
+		},
+		{
+			name:     "overdue handed to a customer full",
+			cylinder: Cylinder{Status: CylinderStatusFull, LocationID: outlet, NextTestDue: due(-30)},
+			move:     MoveCylinderTxParams{ToCustomerID: customer, Status: CylinderStatusFull},
+			want:     true,
+		},
+		{
+			name:     "due today is still in date",
+			cylinder: Cylinder{Status: CylinderStatusFull, LocationID: depot, NextTestDue: due(0)},
+			move:     MoveCylinderTxParams{ToLocationID: outlet, Status: CylinderStatusFull},
+		},
+		{
+			name:     "due later",
+			cylinder: Cylinder{Status: CylinderStatusFull, LocationID: depot, NextTestDue: due(1)},
+			move:     MoveCylinderTxParams{ToLocationID: outlet, Status: CylinderStatusFull},
+		},
+		{
+			name:     "overdue going out empty",
+			cylinder: Cylinder{Status: CylinderStatusEmpty, LocationID: depot, NextTestDue: due(-1)},
+			move:     MoveCylinderTxParams{ToLocationID: outlet, Status: CylinderStatusEmpty},
+		},
+		{
+			name:     "overdue staying where it is",
+			cylinder: Cylinder{Status: CylinderStatusEmpty, LocationID: depot, NextTestDue: due(-1)},
+			move:     MoveCylinderTxParams{ToLocationID: depot, Status: CylinderStatusFull},
+		},
+		{
+			name:     "overdue coming back from a customer",
+			cylinder: Cylinder{Status: CylinderStatusFull, HolderCustomerID: customer, NextTestDue: due(-1)},
+			move:     MoveCylinderTxParams{ToLocationID: depot, Status: CylinderStatusEmpty},
+		},
+		{
+			name:     "overdue coming back full from a customer",
+			cylinder: Cylinder{Status: CylinderStatusFull, HolderCustomerID: customer, NextTestDue: due(-1)},
+			move:     MoveCylinderTxParams{ToLocationID: depot, Status: CylinderStatusFull},
+		},
+		{
+			name:     "overdue passed on to another customer",
+			cylinder: Cylinder{Status: CylinderStatusFull, HolderCustomerID: customer, NextTestDue: due(-1)},
+			move:     MoveCylinderTxParams{ToCustomerID: pgtype.Int4{Int32: 8, Valid: true}, Status: CylinderStatusFull},
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testOverdue(tt.cylinder, tt.move); got != tt.want {
+				t.Errorf("testOverdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
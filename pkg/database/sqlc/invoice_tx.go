@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	InvoiceStatusOpen          = "open"
+	InvoiceStatusPartiallyPaid = "partially_paid"
+	InvoiceStatusPaid          = "paid"
+	InvoiceStatusVoided        = "voided"
+)
+
+var (
+	ErrCreditSaleNeedsCustomer = errors.New("a credit sale requires a registered customer")
+	ErrCreditLimitExceeded     = errors.New("sale would exceed the customer's credit limit")
+	ErrInvoiceStatus           = errors.New("invoice is not in a valid status for this step")
+	ErrInvoiceOverpayment      = errors.New("payment exceeds the amount still owed on the invoice")
+	ErrInvoiceHasPayments      = errors.New("invoice already has payments")
+)
+
+type RecordInvoicePaymentTxParams struct {
+	InvoiceID     int64  `json:"invoice_id"`
+	Amount        int64  `json:"amount"`
+	PaymentMethod string `json:"payment_method"`
+	Reference     string `json:"reference"`
+	ReceivedBy    string `json:"received_by"`
+}
+
+type InvoiceTxResult struct {
+	Invoice  Invoice          `json:"invoice"`
+	Payments []InvoicePayment `json:"payments"`
+}
+
+// issueInvoice bills a credit sale to its customer, due after the customer's
+// payment terms. The customer row is locked first so two credit sales cannot
+// both fit under the credit limit.
+func (store *SQLStore) issueInvoice(ctx context.Context, db DBTX, sale Sale) (Invoice, error) {
+	if !sale.CustomerID.Valid {
+		return Invoice{}, ErrCreditSaleNeedsCustomer
+	}
+
+	customer, err := store.GetCustomerForUpdate(ctx, db, sale.CustomerID.Int32)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	outstanding, err := store.SumCustomerOutstanding(ctx, db, customer.ID)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	if outstanding+sale.Total > customer.CreditLimit {
+		return Invoice{}, fmt.Errorf("%w : limit is %d, outstanding %d, sale %d",
+			ErrCreditLimitExceeded, customer.CreditLimit, outstanding, sale.Total)
+	}
+
+	issued := sale.CreatedAt
+	issueDate := time.Date(issued.Year(), issued.Month(), issued.Day(), 0, 0, 0, 0, time.UTC)
+	return store.CreateInvoice(ctx, db, CreateInvoiceParams{
+		InvoiceNumber: fmt.Sprintf("INV/%s/%06d", issueDate.Format("200601"), sale.ID),
+		SaleID:        sale.ID,
+		CustomerID:    customer.ID,
+		Amount:        sale.Total,
+		IssueDate:     pgtype.Date{Time: issueDate, Valid: true},
+		DueDate:       pgtype.Date{Time: issueDate.AddDate(0, 0, int(customer.PaymentTermDays)), Valid: true},
+		CreatedBy:     sale.CreatedBy,
+	})
+}
+
+// voidSaleInvoice cancels the invoice of a voided credit sale, an invoice the
+// customer has already paid into must be settled before the sale is voided
+func (store *SQLStore) voidSaleInvoice(ctx context.Context, db DBTX, saleID int64) error {
+	invoice, err := store.GetInvoiceBySaleForUpdate(ctx, db, saleID)
+	if err != nil {
+		return err
+	}
+
+	if invoice.PaidAmount > 0 {
+		return ErrInvoiceHasPayments
+	}
+
+	_, err = store.VoidInvoice(ctx, db, invoice.ID)
+	return err
+}
+
+// RecordInvoicePaymentTx applies a full or partial payment to an invoice
+func (store *SQLStore) RecordInvoicePaymentTx(ctx context.Context, db TxBeginner, arg RecordInvoicePaymentTxParams) (InvoiceTxResult, error) {
+	var result InvoiceTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		invoice, err := store.GetInvoiceForUpdate(ctx, tx, arg.InvoiceID)
+		if err != nil {
+			return err
+		}
+
+		if invoice.Status != InvoiceStatusOpen && invoice.Status != InvoiceStatusPartiallyPaid {
+			return ErrInvoiceStatus
+		}
+
+		if arg.Amount > invoice.Amount-invoice.PaidAmount {
+			return ErrInvoiceOverpayment
+		}
+
+		// the payment counts towards the cashier's open session, wherever it is
+		sessionID, err := store.cashSessionOf(ctx, tx, arg.ReceivedBy, pgtype.Int4{})
+		if err != nil {
+			return err
+		}
+
+		_, err = store.CreateInvoicePayment(ctx, tx, CreateInvoicePaymentParams{
+			InvoiceID:     arg.InvoiceID,
+			Amount:        arg.Amount,
+			PaymentMethod: arg.PaymentMethod,
+			Reference:     arg.Reference,
+			ReceivedBy:    arg.ReceivedBy,
+			CashSessionID: sessionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Invoice, err = store.AddInvoicePayment(ctx, tx, AddInvoicePaymentParams{
+			Amount: arg.Amount,
+			ID:     invoice.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Payments, err = store.ListInvoicePayments(ctx, tx, invoice.ID)
+		return err
+	})
+
+	return result, err
+}
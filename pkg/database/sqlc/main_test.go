@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testPool is the migrated database the store tests run against, named by
+// DB_SOURCE. Without one the tests that need it are skipped.
+var testPool *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	if source := os.Getenv("DB_SOURCE"); source != "" {
+		pool, err := pgxpool.New(context.Background(), source)
+		if err == nil {
+			err = pool.Ping(context.Background())
+		}
+		if err != nil {
+			log.Fatal("cannot connect to the test database: ", err)
+		}
+		testPool = pool
+	}
+
+	code := m.Run()
+	if testPool != nil {
+		testPool.Close()
+	}
+	os.Exit(code)
+}
+
+// testTx begins a transaction on the test database that is rolled back when
+// the test ends, so tests leave nothing behind
+func testTx(t *testing.T) pgx.Tx {
+	t.Helper()
+	if testPool == nil {
+		t.Skip("DB_SOURCE is not set")
+	}
+
+	tx, err := testPool.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		tx.Rollback(context.Background())
+	})
+	return tx
+}
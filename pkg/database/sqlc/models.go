@@ -11,6 +11,482 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CashSession struct {
+	ID            int64              `json:"id"`
+	LocationID    int32              `json:"location_id"`
+	Cashier       string             `json:"cashier"`
+	Status        string             `json:"status"`
+	OpeningFloat  int64              `json:"opening_float"`
+	CashTotal     int64              `json:"cash_total"`
+	TransferTotal int64              `json:"transfer_total"`
+	QrisTotal     int64              `json:"qris_total"`
+	RefundTotal   int64              `json:"refund_total"`
+	ExpectedCash  int64              `json:"expected_cash"`
+	CountedCash   pgtype.Int8        `json:"counted_cash"`
+	Variance      pgtype.Int8        `json:"variance"`
+	Note          string             `json:"note"`
+	OpenedAt      time.Time          `json:"opened_at"`
+	ClosedBy      pgtype.Text        `json:"closed_by"`
+	ClosedAt      pgtype.Timestamptz `json:"closed_at"`
+	BusinessDate  pgtype.Date        `json:"business_date"`
+}
+
+type CeilingPrice struct {
+	ID            int64              `json:"id"`
+	Region        string             `json:"region"`
+	ProductID     int32              `json:"product_id"`
+	MaxPrice      int64              `json:"max_price"`
+	EffectiveFrom time.Time          `json:"effective_from"`
+	EffectiveTo   pgtype.Timestamptz `json:"effective_to"`
+	CreatedBy     string             `json:"created_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+type Customer struct {
+	ID              int32         `json:"id"`
+	Name            string        `json:"name"`
+	CustomerType    string        `json:"customer_type"`
+	Phone           pgtype.Text   `json:"phone"`
+	CreatedAt       time.Time     `json:"created_at"`
+	NationalID      pgtype.Text   `json:"national_id"`
+	BusinessID      pgtype.Text   `json:"business_id"`
+	Address         pgtype.Text   `json:"address"`
+	CreditLimit     int64         `json:"credit_limit"`
+	IsActive        bool          `json:"is_active"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	PaymentTermDays int32         `json:"payment_term_days"`
+	Latitude        pgtype.Float8 `json:"latitude"`
+	Longitude       pgtype.Float8 `json:"longitude"`
+}
+
+type Cylinder struct {
+	ID               int64              `json:"id"`
+	SerialNumber     string             `json:"serial_number"`
+	QrCode           pgtype.Text        `json:"qr_code"`
+	ProductID        int32              `json:"product_id"`
+	ManufactureDate  pgtype.Date        `json:"manufacture_date"`
+	TareWeightGrams  int32              `json:"tare_weight_grams"`
+	OwnerType        string             `json:"owner_type"`
+	OwnerCustomerID  pgtype.Int4        `json:"owner_customer_id"`
+	Status           string             `json:"status"`
+	LocationID       pgtype.Int4        `json:"location_id"`
+	HolderCustomerID pgtype.Int4        `json:"holder_customer_id"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	LastTestDate     pgtype.Date        `json:"last_test_date"`
+	LastTestResult   pgtype.Text        `json:"last_test_result"`
+	NextTestDue      pgtype.Date        `json:"next_test_due"`
+	TestDueFlaggedAt pgtype.Timestamptz `json:"test_due_flagged_at"`
+}
+
+type CylinderDeposit struct {
+	ID            int64       `json:"id"`
+	CustomerID    pgtype.Int4 `json:"customer_id"`
+	ProductID     int32       `json:"product_id"`
+	LocationID    int32       `json:"location_id"`
+	SaleID        pgtype.Int8 `json:"sale_id"`
+	Quantity      int32       `json:"quantity"`
+	AmountPerUnit int64       `json:"amount_per_unit"`
+	RefundedQty   int32       `json:"refunded_qty"`
+	Status        string      `json:"status"`
+	CreatedBy     string      `json:"created_by"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+type CylinderInspection struct {
+	ID          int64       `json:"id"`
+	CylinderID  int64       `json:"cylinder_id"`
+	TestDate    pgtype.Date `json:"test_date"`
+	Result      string      `json:"result"`
+	NextDueDate pgtype.Date `json:"next_due_date"`
+	Inspector   string      `json:"inspector"`
+	Note        string      `json:"note"`
+	CreatedBy   string      `json:"created_by"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type CylinderMovement struct {
+	ID             int64       `json:"id"`
+	CylinderID     int64       `json:"cylinder_id"`
+	FromLocationID pgtype.Int4 `json:"from_location_id"`
+	ToLocationID   pgtype.Int4 `json:"to_location_id"`
+	FromCustomerID pgtype.Int4 `json:"from_customer_id"`
+	ToCustomerID   pgtype.Int4 `json:"to_customer_id"`
+	Status         string      `json:"status"`
+	Reason         string      `json:"reason"`
+	ReferenceType  pgtype.Text `json:"reference_type"`
+	ReferenceID    pgtype.Int8 `json:"reference_id"`
+	CreatedBy      string      `json:"created_by"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+type DailyClosing struct {
+	ID            int64              `json:"id"`
+	LocationID    int32              `json:"location_id"`
+	BusinessDate  pgtype.Date        `json:"business_date"`
+	Status        string             `json:"status"`
+	SessionCount  int32              `json:"session_count"`
+	OpeningFloat  int64              `json:"opening_float"`
+	CashTotal     int64              `json:"cash_total"`
+	TransferTotal int64              `json:"transfer_total"`
+	QrisTotal     int64              `json:"qris_total"`
+	RefundTotal   int64              `json:"refund_total"`
+	CreditTotal   int64              `json:"credit_total"`
+	ExpectedCash  int64              `json:"expected_cash"`
+	CountedCash   int64              `json:"counted_cash"`
+	Variance      int64              `json:"variance"`
+	SubmittedBy   string             `json:"submitted_by"`
+	ApprovedBy    pgtype.Text        `json:"approved_by"`
+	ApprovedAt    pgtype.Timestamptz `json:"approved_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+type DeliveryOrder struct {
+	ID               int64              `json:"id"`
+	SourceLocationID int32              `json:"source_location_id"`
+	VehicleID        int32              `json:"vehicle_id"`
+	DriverID         int32              `json:"driver_id"`
+	ScheduledDate    pgtype.Date        `json:"scheduled_date"`
+	Status           string             `json:"status"`
+	Note             string             `json:"note"`
+	CreatedBy        string             `json:"created_by"`
+	ConfirmedAt      pgtype.Timestamptz `json:"confirmed_at"`
+	LoadedAt         pgtype.Timestamptz `json:"loaded_at"`
+	DepartedAt       pgtype.Timestamptz `json:"departed_at"`
+	DeliveredAt      pgtype.Timestamptz `json:"delivered_at"`
+	ReconciledAt     pgtype.Timestamptz `json:"reconciled_at"`
+	CancelledAt      pgtype.Timestamptz `json:"cancelled_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+type DeliveryReconciliation struct {
+	ID              int64     `json:"id"`
+	DeliveryOrderID int64     `json:"delivery_order_id"`
+	DriverID        int32     `json:"driver_id"`
+	ExpectedCash    int64     `json:"expected_cash"`
+	ReportedCash    int64     `json:"reported_cash"`
+	CashVariance    int64     `json:"cash_variance"`
+	Note            string    `json:"note"`
+	ReconciledBy    string    `json:"reconciled_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type DeliveryReconciliationItem struct {
+	ID               int64 `json:"id"`
+	ReconciliationID int64 `json:"reconciliation_id"`
+	ProductID        int32 `json:"product_id"`
+	LoadedQty        int32 `json:"loaded_qty"`
+	DeliveredQty     int32 `json:"delivered_qty"`
+	ReportedFullQty  int32 `json:"reported_full_qty"`
+	FullQtyShort     int32 `json:"full_qty_short"`
+	EmptiesCollected int32 `json:"empties_collected"`
+	ReportedEmptyQty int32 `json:"reported_empty_qty"`
+	EmptyQtyShort    int32 `json:"empty_qty_short"`
+}
+
+type DeliveryStop struct {
+	ID              int64              `json:"id"`
+	DeliveryOrderID int64              `json:"delivery_order_id"`
+	Sequence        int32              `json:"sequence"`
+	CustomerID      int32              `json:"customer_id"`
+	Status          string             `json:"status"`
+	SaleID          pgtype.Int8        `json:"sale_id"`
+	Note            string             `json:"note"`
+	CompletedBy     pgtype.Text        `json:"completed_by"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	RecipientName   pgtype.Text        `json:"recipient_name"`
+	SignatureKey    pgtype.Text        `json:"signature_key"`
+	PhotoKey        pgtype.Text        `json:"photo_key"`
+	Latitude        pgtype.Float8      `json:"latitude"`
+	Longitude       pgtype.Float8      `json:"longitude"`
+	ProofCapturedBy pgtype.Text        `json:"proof_captured_by"`
+	ProofCapturedAt pgtype.Timestamptz `json:"proof_captured_at"`
+}
+
+type DeliveryStopItem struct {
+	ID               int64 `json:"id"`
+	DeliveryStopID   int64 `json:"delivery_stop_id"`
+	ProductID        int32 `json:"product_id"`
+	Quantity         int32 `json:"quantity"`
+	EmptiesToCollect int32 `json:"empties_to_collect"`
+	DeliveredQty     int32 `json:"delivered_qty"`
+	EmptiesCollected int32 `json:"empties_collected"`
+}
+
+type DepositRefund struct {
+	ID            int64       `json:"id"`
+	DepositID     int64       `json:"deposit_id"`
+	LocationID    int32       `json:"location_id"`
+	Quantity      int32       `json:"quantity"`
+	Amount        int64       `json:"amount"`
+	RefundedBy    string      `json:"refunded_by"`
+	CreatedAt     time.Time   `json:"created_at"`
+	CashSessionID pgtype.Int8 `json:"cash_session_id"`
+}
+
+type Driver struct {
+	ID            int32     `json:"id"`
+	UserID        int32     `json:"user_id"`
+	LicenseNumber string    `json:"license_number"`
+	Phone         string    `json:"phone"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type DriverShortage struct {
+	ID              int64       `json:"id"`
+	DriverID        int32       `json:"driver_id"`
+	DeliveryOrderID int64       `json:"delivery_order_id"`
+	ProductID       pgtype.Int4 `json:"product_id"`
+	FullQtyShort    int32       `json:"full_qty_short"`
+	EmptyQtyShort   int32       `json:"empty_qty_short"`
+	CashShort       int64       `json:"cash_short"`
+	CreatedBy       string      `json:"created_by"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+type EmptiesBalance struct {
+	CustomerID int32     `json:"customer_id"`
+	ProductID  int32     `json:"product_id"`
+	Balance    int32     `json:"balance"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type GoodsReceipt struct {
+	ID              int64     `json:"id"`
+	PurchaseOrderID int64     `json:"purchase_order_id"`
+	LocationID      int32     `json:"location_id"`
+	Note            string    `json:"note"`
+	ReceivedBy      string    `json:"received_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type GoodsReceiptItem struct {
+	ID                  int64 `json:"id"`
+	GoodsReceiptID      int64 `json:"goods_receipt_id"`
+	PurchaseOrderItemID int64 `json:"purchase_order_item_id"`
+	ProductID           int32 `json:"product_id"`
+	ExpectedQty         int32 `json:"expected_qty"`
+	ReceivedQty         int32 `json:"received_qty"`
+	VarianceQty         int32 `json:"variance_qty"`
+	EmptiesReturned     int32 `json:"empties_returned"`
+}
+
+type Holiday struct {
+	ID               int32       `json:"id"`
+	Name             string      `json:"name"`
+	StartsOn         pgtype.Date `json:"starts_on"`
+	EndsOn           pgtype.Date `json:"ends_on"`
+	DemandMultiplier float64     `json:"demand_multiplier"`
+	CreatedBy        string      `json:"created_by"`
+	CreatedAt        time.Time   `json:"created_at"`
+}
+
+type ImportJob struct {
+	ID          int64              `json:"id"`
+	Kind        string             `json:"kind"`
+	Status      string             `json:"status"`
+	DryRun      bool               `json:"dry_run"`
+	LocationID  pgtype.Int4        `json:"location_id"`
+	FileKey     string             `json:"file_key"`
+	FileName    string             `json:"file_name"`
+	TotalRows   int32              `json:"total_rows"`
+	CreatedRows int32              `json:"created_rows"`
+	UpdatedRows int32              `json:"updated_rows"`
+	ErrorRows   int32              `json:"error_rows"`
+	Error       pgtype.Text        `json:"error"`
+	CreatedBy   string             `json:"created_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	StartedAt   pgtype.Timestamptz `json:"started_at"`
+	FinishedAt  pgtype.Timestamptz `json:"finished_at"`
+}
+
+type ImportJobError struct {
+	ID          int64  `json:"id"`
+	ImportJobID int64  `json:"import_job_id"`
+	RowNumber   int32  `json:"row_number"`
+	ColumnName  string `json:"column_name"`
+	Message     string `json:"message"`
+}
+
+type Incident struct {
+	ID              int64              `json:"id"`
+	IncidentType    string             `json:"incident_type"`
+	LocationID      int32              `json:"location_id"`
+	ProductID       int32              `json:"product_id"`
+	CylinderID      pgtype.Int8        `json:"cylinder_id"`
+	CylinderState   string             `json:"cylinder_state"`
+	Reason          string             `json:"reason"`
+	Status          string             `json:"status"`
+	ReportedBy      string             `json:"reported_by"`
+	ReportedAt      time.Time          `json:"reported_at"`
+	DispositionNote pgtype.Text        `json:"disposition_note"`
+	DisposedBy      pgtype.Text        `json:"disposed_by"`
+	DisposedAt      pgtype.Timestamptz `json:"disposed_at"`
+}
+
+type IncidentPhoto struct {
+	ID         int64       `json:"id"`
+	IncidentID int64       `json:"incident_id"`
+	Url        string      `json:"url"`
+	CreatedAt  time.Time   `json:"created_at"`
+	StorageKey pgtype.Text `json:"storage_key"`
+}
+
+type Invoice struct {
+	ID            int64       `json:"id"`
+	InvoiceNumber string      `json:"invoice_number"`
+	SaleID        int64       `json:"sale_id"`
+	CustomerID    int32       `json:"customer_id"`
+	Status        string      `json:"status"`
+	Amount        int64       `json:"amount"`
+	PaidAmount    int64       `json:"paid_amount"`
+	IssueDate     pgtype.Date `json:"issue_date"`
+	DueDate       pgtype.Date `json:"due_date"`
+	CreatedBy     string      `json:"created_by"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+type InvoicePayment struct {
+	ID            int64       `json:"id"`
+	InvoiceID     int64       `json:"invoice_id"`
+	Amount        int64       `json:"amount"`
+	PaymentMethod string      `json:"payment_method"`
+	Reference     string      `json:"reference"`
+	ReceivedBy    string      `json:"received_by"`
+	PaidAt        time.Time   `json:"paid_at"`
+	CashSessionID pgtype.Int8 `json:"cash_session_id"`
+}
+
+type Location struct {
+	ID           int32         `json:"id"`
+	Code         string        `json:"code"`
+	Name         string        `json:"name"`
+	LocationType string        `json:"location_type"`
+	CreatedAt    time.Time     `json:"created_at"`
+	Region       pgtype.Text   `json:"region"`
+	Latitude     pgtype.Float8 `json:"latitude"`
+	Longitude    pgtype.Float8 `json:"longitude"`
+}
+
+type PriceList struct {
+	ID            int64              `json:"id"`
+	ProductID     int32              `json:"product_id"`
+	CustomerType  pgtype.Text        `json:"customer_type"`
+	LocationID    pgtype.Int4        `json:"location_id"`
+	Price         int64              `json:"price"`
+	EffectiveFrom time.Time          `json:"effective_from"`
+	EffectiveTo   pgtype.Timestamptz `json:"effective_to"`
+	CreatedBy     string             `json:"created_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+type PriceOverride struct {
+	ID           int64       `json:"id"`
+	SaleID       int64       `json:"sale_id"`
+	SaleItemID   int64       `json:"sale_item_id"`
+	ProductID    int32       `json:"product_id"`
+	LocationID   int32       `json:"location_id"`
+	CeilingPrice pgtype.Int8 `json:"ceiling_price"`
+	ChargedPrice int64       `json:"charged_price"`
+	Reason       string      `json:"reason"`
+	ApprovedBy   string      `json:"approved_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	ListPrice    pgtype.Int8 `json:"list_price"`
+}
+
+type Product struct {
+	ID             int32     `json:"id"`
+	Code           string    `json:"code"`
+	Name           string    `json:"name"`
+	NetWeightGrams int32     `json:"net_weight_grams"`
+	IsSubsidized   bool      `json:"is_subsidized"`
+	CreatedAt      time.Time `json:"created_at"`
+	Price          int64     `json:"price"`
+	DepositAmount  int64     `json:"deposit_amount"`
+}
+
+type PurchaseOrder struct {
+	ID           int64              `json:"id"`
+	SupplierID   int32              `json:"supplier_id"`
+	LocationID   int32              `json:"location_id"`
+	Status       string             `json:"status"`
+	ExpectedDate pgtype.Date        `json:"expected_date"`
+	Note         string             `json:"note"`
+	CreatedBy    string             `json:"created_by"`
+	OrderedAt    pgtype.Timestamptz `json:"ordered_at"`
+	ClosedAt     pgtype.Timestamptz `json:"closed_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+type PurchaseOrderItem struct {
+	ID              int64 `json:"id"`
+	PurchaseOrderID int64 `json:"purchase_order_id"`
+	ProductID       int32 `json:"product_id"`
+	OrderedQty      int32 `json:"ordered_qty"`
+	UnitPrice       int64 `json:"unit_price"`
+	ReceivedQty     int32 `json:"received_qty"`
+}
+
+type QuotaRule struct {
+	ID           int32     `json:"id"`
+	ProductID    int32     `json:"product_id"`
+	CustomerType string    `json:"customer_type"`
+	Period       string    `json:"period"`
+	MaxQty       int32     `json:"max_qty"`
+	IsActive     bool      `json:"is_active"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type QuotaUsage struct {
+	ID          int64       `json:"id"`
+	CustomerID  int32       `json:"customer_id"`
+	ProductID   int32       `json:"product_id"`
+	QuotaRuleID int32       `json:"quota_rule_id"`
+	PeriodStart pgtype.Date `json:"period_start"`
+	Quantity    int32       `json:"quantity"`
+	SaleID      int64       `json:"sale_id"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type Sale struct {
+	ID            int64              `json:"id"`
+	LocationID    int32              `json:"location_id"`
+	CustomerID    pgtype.Int4        `json:"customer_id"`
+	CreatedBy     string             `json:"created_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	Status        string             `json:"status"`
+	PaymentMethod string             `json:"payment_method"`
+	Subtotal      int64              `json:"subtotal"`
+	DiscountTotal int64              `json:"discount_total"`
+	TaxTotal      int64              `json:"tax_total"`
+	DepositTotal  int64              `json:"deposit_total"`
+	Total         int64              `json:"total"`
+	Note          string             `json:"note"`
+	VoidedBy      pgtype.Text        `json:"voided_by"`
+	VoidReason    pgtype.Text        `json:"void_reason"`
+	VoidedAt      pgtype.Timestamptz `json:"voided_at"`
+	CashSessionID pgtype.Int8        `json:"cash_session_id"`
+}
+
+type SaleItem struct {
+	ID              int64  `json:"id"`
+	SaleID          int64  `json:"sale_id"`
+	ProductID       int32  `json:"product_id"`
+	SaleType        string `json:"sale_type"`
+	Quantity        int32  `json:"quantity"`
+	EmptiesReturned int32  `json:"empties_returned"`
+	UnitPrice       int64  `json:"unit_price"`
+	DiscountAmount  int64  `json:"discount_amount"`
+	DepositAmount   int64  `json:"deposit_amount"`
+	LineTotal       int64  `json:"line_total"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	Email        string    `json:"email"`
@@ -22,6 +498,142 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type StockAlert struct {
+	ID           int64              `json:"id"`
+	LocationID   int32              `json:"location_id"`
+	ProductID    int32              `json:"product_id"`
+	AlertType    string             `json:"alert_type"`
+	Status       string             `json:"status"`
+	AvailableQty int32              `json:"available_qty"`
+	ThresholdQty int32              `json:"threshold_qty"`
+	RaisedAt     time.Time          `json:"raised_at"`
+	ResolvedAt   pgtype.Timestamptz `json:"resolved_at"`
+}
+
+type StockBalance struct {
+	LocationID    int32     `json:"location_id"`
+	ProductID     int32     `json:"product_id"`
+	FullQty       int32     `json:"full_qty"`
+	EmptyQty      int32     `json:"empty_qty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	QuarantineQty int32     `json:"quarantine_qty"`
+	ReservedQty   int32     `json:"reserved_qty"`
+	AvailableQty  int32     `json:"available_qty"`
+}
+
+type StockCount struct {
+	ID         int64              `json:"id"`
+	LocationID int32              `json:"location_id"`
+	Status     string             `json:"status"`
+	Note       string             `json:"note"`
+	CreatedBy  string             `json:"created_by"`
+	CreatedAt  time.Time          `json:"created_at"`
+	ApprovedBy pgtype.Text        `json:"approved_by"`
+	ApprovedAt pgtype.Timestamptz `json:"approved_at"`
+}
+
+type StockCountEntry struct {
+	ID               int64     `json:"id"`
+	StockCountLineID int64     `json:"stock_count_line_id"`
+	CountedFullQty   int32     `json:"counted_full_qty"`
+	CountedEmptyQty  int32     `json:"counted_empty_qty"`
+	CountedBy        string    `json:"counted_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type StockCountLine struct {
+	ID               int64              `json:"id"`
+	StockCountID     int64              `json:"stock_count_id"`
+	ProductID        int32              `json:"product_id"`
+	ExpectedFullQty  int32              `json:"expected_full_qty"`
+	ExpectedEmptyQty int32              `json:"expected_empty_qty"`
+	CountedFullQty   pgtype.Int4        `json:"counted_full_qty"`
+	CountedEmptyQty  pgtype.Int4        `json:"counted_empty_qty"`
+	CountedBy        pgtype.Text        `json:"counted_by"`
+	CountedAt        pgtype.Timestamptz `json:"counted_at"`
+}
+
+type StockMovement struct {
+	ID                  int64     `json:"id"`
+	LocationID          int32     `json:"location_id"`
+	ProductID           int32     `json:"product_id"`
+	FullQtyChange       int32     `json:"full_qty_change"`
+	EmptyQtyChange      int32     `json:"empty_qty_change"`
+	Reason              string    `json:"reason"`
+	ReferenceType       string    `json:"reference_type"`
+	ReferenceID         int64     `json:"reference_id"`
+	CreatedBy           string    `json:"created_by"`
+	CreatedAt           time.Time `json:"created_at"`
+	QuarantineQtyChange int32     `json:"quarantine_qty_change"`
+}
+
+type StockReservation struct {
+	ID            int64              `json:"id"`
+	LocationID    int32              `json:"location_id"`
+	ProductID     int32              `json:"product_id"`
+	Quantity      int32              `json:"quantity"`
+	ReferenceType string             `json:"reference_type"`
+	ReferenceID   int64              `json:"reference_id"`
+	Status        string             `json:"status"`
+	ExpiresAt     time.Time          `json:"expires_at"`
+	CreatedBy     string             `json:"created_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	ClosedAt      pgtype.Timestamptz `json:"closed_at"`
+}
+
+type StockThreshold struct {
+	LocationID   int32     `json:"location_id"`
+	ProductID    int32     `json:"product_id"`
+	MinQty       int32     `json:"min_qty"`
+	ReorderPoint int32     `json:"reorder_point"`
+	MaxQty       int32     `json:"max_qty"`
+	UpdatedBy    string    `json:"updated_by"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type Supplier struct {
+	ID        int32       `json:"id"`
+	Code      string      `json:"code"`
+	Name      string      `json:"name"`
+	Phone     pgtype.Text `json:"phone"`
+	Address   pgtype.Text `json:"address"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type Transfer struct {
+	ID                    int64              `json:"id"`
+	SourceLocationID      int32              `json:"source_location_id"`
+	DestinationLocationID int32              `json:"destination_location_id"`
+	Status                string             `json:"status"`
+	Note                  string             `json:"note"`
+	CreatedBy             string             `json:"created_by"`
+	DispatchedBy          pgtype.Text        `json:"dispatched_by"`
+	DispatchedAt          pgtype.Timestamptz `json:"dispatched_at"`
+	ReceivedAt            pgtype.Timestamptz `json:"received_at"`
+	CreatedAt             time.Time          `json:"created_at"`
+}
+
+type TransferDiscrepancy struct {
+	ID            int64     `json:"id"`
+	TransferID    int64     `json:"transfer_id"`
+	ProductID     int32     `json:"product_id"`
+	FullQtyShort  int32     `json:"full_qty_short"`
+	EmptyQtyShort int32     `json:"empty_qty_short"`
+	Note          string    `json:"note"`
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TransferItem struct {
+	ID               int64 `json:"id"`
+	TransferID       int64 `json:"transfer_id"`
+	ProductID        int32 `json:"product_id"`
+	FullQty          int32 `json:"full_qty"`
+	EmptyQty         int32 `json:"empty_qty"`
+	ReceivedFullQty  int32 `json:"received_full_qty"`
+	ReceivedEmptyQty int32 `json:"received_empty_qty"`
+}
+
 type User struct {
 	ID        int32              `json:"id"`
 	FirstName string             `json:"firstName"`
@@ -31,4 +643,19 @@ type User struct {
 	IsActive  bool               `json:"isActive"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Role      string             `json:"role"`
+}
+
+type Vehicle struct {
+	ID          int32     `json:"id"`
+	PlateNumber string    `json:"plate_number"`
+	LocationID  int32     `json:"location_id"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type VehicleCapacity struct {
+	VehicleID int32 `json:"vehicle_id"`
+	ProductID int32 `json:"product_id"`
+	Capacity  int32 `json:"capacity"`
 }
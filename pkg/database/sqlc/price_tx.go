@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	UserRoleStaff = "staff"
+	UserRoleAdmin = "admin"
+)
+
+var (
+	ErrPriceAboveCeiling = errors.New("price is above the regulated ceiling price")
+	ErrPriceNotApproved  = errors.New("a price other than the price list requires an admin's approval")
+	ErrPriceExists       = errors.New("a price already takes effect at that time")
+)
+
+type ResolvePriceParams struct {
+	ProductID    int32       `json:"product_id"`
+	LocationID   int32       `json:"location_id"`
+	CustomerType pgtype.Text `json:"customer_type"`
+	At           time.Time   `json:"at"`
+}
+
+// ResolvedPrice is the price a product sells for, in rupiah. PriceListID is
+// null when no price list applies and the product's own price is used, and
+// CeilingPrice is null when the location's region has no ceiling price.
+type ResolvedPrice struct {
+	ProductID    int32       `json:"product_id"`
+	Price        int64       `json:"price"`
+	PriceListID  pgtype.Int8 `json:"price_list_id"`
+	CeilingPrice pgtype.Int8 `json:"ceiling_price"`
+}
+
+type CreatePriceListTxParams struct {
+	ProductID     int32              `json:"product_id"`
+	CustomerType  pgtype.Text        `json:"customer_type"`
+	LocationID    pgtype.Int4        `json:"location_id"`
+	Price         int64              `json:"price"`
+	EffectiveFrom time.Time          `json:"effective_from"`
+	EffectiveTo   pgtype.Timestamptz `json:"effective_to"`
+	CreatedBy     string             `json:"created_by"`
+}
+
+type CreateCeilingPriceTxParams struct {
+	Region        string             `json:"region"`
+	ProductID     int32              `json:"product_id"`
+	MaxPrice      int64              `json:"max_price"`
+	EffectiveFrom time.Time          `json:"effective_from"`
+	EffectiveTo   pgtype.Timestamptz `json:"effective_to"`
+	CreatedBy     string             `json:"created_by"`
+}
+
+// ResolvePrice looks up the price effective at arg.At. A price list for the
+// location wins over one for every location, and one for the customer type
+// wins over one for every customer type.
+func (store *SQLStore) ResolvePrice(ctx context.Context, db DBTX, arg ResolvePriceParams) (ResolvedPrice, error) {
+	result := ResolvedPrice{ProductID: arg.ProductID}
+
+	product, err := store.GetProduct(ctx, db, arg.ProductID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Price = product.Price
+	priceList, err := store.GetEffectivePriceList(ctx, db, GetEffectivePriceListParams{
+		ProductID:    arg.ProductID,
+		CustomerType: arg.CustomerType,
+		LocationID:   pgtype.Int4{Int32: arg.LocationID, Valid: true},
+		At:           arg.At,
+	})
+	switch {
+	case err == nil:
+		result.Price = priceList.Price
+		result.PriceListID = pgtype.Int8{Int64: priceList.ID, Valid: true}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return result, err
+	}
+
+	location, err := store.GetLocation(ctx, db, arg.LocationID)
+	if err != nil {
+		return result, err
+	}
+
+	if !location.Region.Valid {
+		return result, nil
+	}
+
+	ceiling, err := store.GetEffectiveCeilingPrice(ctx, db, GetEffectiveCeilingPriceParams{
+		Region:    location.Region.String,
+		ProductID: arg.ProductID,
+		At:        arg.At,
+	})
+	switch {
+	case err == nil:
+		result.CeilingPrice = pgtype.Int8{Int64: ceiling.MaxPrice, Valid: true}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return result, err
+	}
+
+	return result, nil
+}
+
+// endBeforeNext ends a price being added no later than the next price already
+// in its history takes effect, so no two prices of a history overlap
+func endBeforeNext(from time.Time, to pgtype.Timestamptz, next time.Time) (pgtype.Timestamptz, error) {
+	if !next.After(from) {
+		return to, ErrPriceExists
+	}
+
+	if to.Valid && !to.Time.After(next) {
+		return to, nil
+	}
+	return pgtype.Timestamptz{Time: next, Valid: true}, nil
+}
+
+// CreatePriceListTx adds a price to the history of its product, customer type
+// and location. The price it replaces is closed at the new effective date,
+// and a price added before one already scheduled ends when that one starts.
+func (store *SQLStore) CreatePriceListTx(ctx context.Context, db TxBeginner, arg CreatePriceListTxParams) (PriceList, error) {
+	var priceList PriceList
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		next, err := store.GetNextPriceList(ctx, tx, GetNextPriceListParams{
+			ProductID:     arg.ProductID,
+			CustomerType:  arg.CustomerType,
+			LocationID:    arg.LocationID,
+			EffectiveFrom: arg.EffectiveFrom,
+		})
+		switch {
+		case err == nil:
+			arg.EffectiveTo, err = endBeforeNext(arg.EffectiveFrom, arg.EffectiveTo, next.EffectiveFrom)
+			if err != nil {
+				return err
+			}
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+
+		err = store.ClosePriceLists(ctx, tx, ClosePriceListsParams{
+			EffectiveFrom: arg.EffectiveFrom,
+			ProductID:     arg.ProductID,
+			CustomerType:  arg.CustomerType,
+			LocationID:    arg.LocationID,
+		})
+		if err != nil {
+			return err
+		}
+
+		priceList, err = store.CreatePriceList(ctx, tx, CreatePriceListParams(arg))
+		return err
+	})
+
+	return priceList, err
+}
+
+// CreateCeilingPriceTx adds a ceiling price to the history of its region and
+// product. The ceiling price it replaces is closed at the new effective date,
+// and one added before one already scheduled ends when that one starts.
+func (store *SQLStore) CreateCeilingPriceTx(ctx context.Context, db TxBeginner, arg CreateCeilingPriceTxParams) (CeilingPrice, error) {
+	var ceilingPrice CeilingPrice
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		next, err := store.GetNextCeilingPrice(ctx, tx, GetNextCeilingPriceParams{
+			Region:        arg.Region,
+			ProductID:     arg.ProductID,
+			EffectiveFrom: arg.EffectiveFrom,
+		})
+		switch {
+		case err == nil:
+			arg.EffectiveTo, err = endBeforeNext(arg.EffectiveFrom, arg.EffectiveTo, next.EffectiveFrom)
+			if err != nil {
+				return err
+			}
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+
+		err = store.CloseCeilingPrices(ctx, tx, CloseCeilingPricesParams{
+			EffectiveFrom: arg.EffectiveFrom,
+			Region:        arg.Region,
+			ProductID:     arg.ProductID,
+		})
+		if err != nil {
+			return err
+		}
+
+		ceilingPrice, err = store.CreateCeilingPrice(ctx, tx, CreateCeilingPriceParams(arg))
+		return err
+	})
+
+	return ceilingPrice, err
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestEndBeforeNext(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	until := func(d int) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: day(d), Valid: true}
+	}
+	open := pgtype.Timestamptz{}
+
+	tests := []struct {
+		name string
+		from time.Time
+		to   pgtype.Timestamptz
+		next time.Time
+		want pgtype.Timestamptz
+		err  error
+	}{
+		{"open ended is closed at the next", day(1), open, day(10), until(10), nil},
+		{"ending after the next is cut short", day(1), until(20), day(10), until(10), nil},
+		{"ending when the next starts is kept", day(1), until(10), day(10), until(10), nil},
+		{"ending before the next is kept", day(1), until(5), day(10), until(5), nil},
+		{"starting with the next", day(10), open, day(10), open, ErrPriceExists},
+		{"starting after the next", day(12), until(20), day(10), until(20), ErrPriceExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := endBeforeNext(tt.from, tt.to, tt.next)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("endBeforeNext() error = %v, want %v", err, tt.err)
+			}
+			if got.Valid != tt.want.Valid || !got.Time.Equal(tt.want.Time) {
+				t.Errorf("endBeforeNext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
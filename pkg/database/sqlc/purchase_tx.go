@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusOrdered           = "ordered"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusClosed            = "closed"
+)
+
+var (
+	ErrPurchaseOrderStatus      = errors.New("purchase order is not in a valid status for this step")
+	ErrPurchaseOrderEmpty       = errors.New("purchase order has no items")
+	ErrPurchaseOrderUnknownItem = errors.New("product is not part of the purchase order")
+)
+
+type PurchaseOrderItemParams struct {
+	ProductID  int32 `json:"product_id"`
+	OrderedQty int32 `json:"ordered_qty"`
+	UnitPrice  int64 `json:"unit_price"`
+}
+
+type CreatePurchaseOrderTxParams struct {
+	SupplierID   int32                     `json:"supplier_id"`
+	LocationID   int32                     `json:"location_id"`
+	ExpectedDate pgtype.Date               `json:"expected_date"`
+	Note         string                    `json:"note"`
+	Items        []PurchaseOrderItemParams `json:"items"`
+	CreatedBy    string                    `json:"created_by"`
+}
+
+type PurchaseOrderTxResult struct {
+	PurchaseOrder PurchaseOrder       `json:"purchase_order"`
+	Items         []PurchaseOrderItem `json:"items"`
+}
+
+type GoodsReceiptItemParams struct {
+	ProductID       int32 `json:"product_id"`
+	ReceivedQty     int32 `json:"received_qty"`
+	EmptiesReturned int32 `json:"empties_returned"`
+}
+
+type ReceivePurchaseOrderTxParams struct {
+	PurchaseOrderID int64                    `json:"purchase_order_id"`
+	Items           []GoodsReceiptItemParams `json:"items"`
+	Note            string                   `json:"note"`
+	ReceivedBy      string                   `json:"received_by"`
+}
+
+type ReceivePurchaseOrderTxResult struct {
+	PurchaseOrder PurchaseOrder       `json:"purchase_order"`
+	Items         []PurchaseOrderItem `json:"items"`
+	GoodsReceipt  GoodsReceipt        `json:"goods_receipt"`
+	ReceiptItems  []GoodsReceiptItem  `json:"receipt_items"`
+	// Variances lists the receipt lines where the received quantity differs
+	// from what was still outstanding on the order
+	Variances []GoodsReceiptItem `json:"variances"`
+}
+
+// CreatePurchaseOrderTx creates a draft purchase order together with its items
+func (store *SQLStore) CreatePurchaseOrderTx(ctx context.Context, db TxBeginner, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	if len(arg.Items) == 0 {
+		return result, ErrPurchaseOrderEmpty
+	}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+
+		result.PurchaseOrder, err = store.CreatePurchaseOrder(ctx, tx, CreatePurchaseOrderParams{
+			SupplierID:   arg.SupplierID,
+			LocationID:   arg.LocationID,
+			ExpectedDate: arg.ExpectedDate,
+			Note:         arg.Note,
+			CreatedBy:    arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]PurchaseOrderItem, 0, len(arg.Items))
+		for _, item := range arg.Items {
+			orderItem, err := store.CreatePurchaseOrderItem(ctx, tx, CreatePurchaseOrderItemParams{
+				PurchaseOrderID: result.PurchaseOrder.ID,
+				ProductID:       item.ProductID,
+				OrderedQty:      item.OrderedQty,
+				UnitPrice:       item.UnitPrice,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, orderItem)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// OrderPurchaseOrderTx sends a draft purchase order to the supplier
+func (store *SQLStore) OrderPurchaseOrderTx(ctx context.Context, db TxBeginner, id int64) (PurchaseOrder, error) {
+	var order PurchaseOrder
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		current, err := store.GetPurchaseOrderForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if current.Status != PurchaseOrderStatusDraft {
+			return ErrPurchaseOrderStatus
+		}
+
+		order, err = store.MarkPurchaseOrderOrdered(ctx, tx, id)
+		return err
+	})
+
+	return order, err
+}
+
+// ClosePurchaseOrderTx closes a purchase order once nothing more is expected from it
+func (store *SQLStore) ClosePurchaseOrderTx(ctx context.Context, db TxBeginner, id int64) (PurchaseOrder, error) {
+	var order PurchaseOrder
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		current, err := store.GetPurchaseOrderForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if current.Status != PurchaseOrderStatusPartiallyReceived && current.Status != PurchaseOrderStatusReceived {
+			return ErrPurchaseOrderStatus
+		}
+
+		order, err = store.ClosePurchaseOrder(ctx, tx, id)
+		return err
+	})
+
+	return order, err
+}
+
+// ReceivePurchaseOrderTx records a goods receipt note against a purchase
+// order. The full cylinders received are posted into the receiving
+// location's stock and the empties handed back to the supplier are taken out.
+func (store *SQLStore) ReceivePurchaseOrderTx(ctx context.Context, db TxBeginner, arg ReceivePurchaseOrderTxParams) (ReceivePurchaseOrderTxResult, error) {
+	var result ReceivePurchaseOrderTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.GetPurchaseOrderForUpdate(ctx, tx, arg.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != PurchaseOrderStatusOrdered && order.Status != PurchaseOrderStatusPartiallyReceived {
+			return ErrPurchaseOrderStatus
+		}
+
+		items, err := store.ListPurchaseOrderItems(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		itemsByProduct := make(map[int32]PurchaseOrderItem, len(items))
+		for _, item := range items {
+			itemsByProduct[item.ProductID] = item
+		}
+
+		result.GoodsReceipt, err = store.CreateGoodsReceipt(ctx, tx, CreateGoodsReceiptParams{
+			PurchaseOrderID: order.ID,
+			LocationID:      order.LocationID,
+			Note:            arg.Note,
+			ReceivedBy:      arg.ReceivedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ReceiptItems = make([]GoodsReceiptItem, 0, len(arg.Items))
+		result.Variances = []GoodsReceiptItem{}
+		for _, received := range arg.Items {
+			item, ok := itemsByProduct[received.ProductID]
+			if !ok {
+				return ErrPurchaseOrderUnknownItem
+			}
+
+			expected := max(item.OrderedQty-item.ReceivedQty, 0)
+			receiptItem, err := store.CreateGoodsReceiptItem(ctx, tx, CreateGoodsReceiptItemParams{
+				GoodsReceiptID:      result.GoodsReceipt.ID,
+				PurchaseOrderItemID: item.ID,
+				ProductID:           item.ProductID,
+				ExpectedQty:         expected,
+				ReceivedQty:         received.ReceivedQty,
+				VarianceQty:         received.ReceivedQty - expected,
+				EmptiesReturned:     received.EmptiesReturned,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.ReceiptItems = append(result.ReceiptItems, receiptItem)
+			if receiptItem.VarianceQty != 0 {
+				result.Variances = append(result.Variances, receiptItem)
+			}
+
+			itemsByProduct[item.ProductID], err = store.AddPurchaseOrderItemReceipt(ctx, tx, AddPurchaseOrderItemReceiptParams{
+				ID:          item.ID,
+				ReceivedQty: received.ReceivedQty,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = store.postStockMovement(ctx, tx, CreateStockMovementParams{
+				LocationID:     order.LocationID,
+				ProductID:      item.ProductID,
+				FullQtyChange:  received.ReceivedQty,
+				EmptyQtyChange: -received.EmptiesReturned,
+				Reason:         MovementReasonGoodsReceipt,
+				ReferenceType:  ReferenceTypeGoodsReceipt,
+				ReferenceID:    result.GoodsReceipt.ID,
+				CreatedBy:      arg.ReceivedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		status := PurchaseOrderStatusReceived
+		for i, item := range items {
+			items[i] = itemsByProduct[item.ProductID]
+			if items[i].ReceivedQty < items[i].OrderedQty {
+				status = PurchaseOrderStatusPartiallyReceived
+			}
+		}
+		result.Items = items
+
+		result.PurchaseOrder, err = store.UpdatePurchaseOrderStatus(ctx, tx, UpdatePurchaseOrderStatusParams{
+			ID:     order.ID,
+			Status: status,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -8,13 +8,247 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AddDepositRefund(ctx context.Context, db DBTX, arg AddDepositRefundParams) (CylinderDeposit, error)
+	AddEmptiesBalance(ctx context.Context, db DBTX, arg AddEmptiesBalanceParams) (EmptiesBalance, error)
+	AddInvoicePayment(ctx context.Context, db DBTX, arg AddInvoicePaymentParams) (Invoice, error)
+	AddPurchaseOrderItemReceipt(ctx context.Context, db DBTX, arg AddPurchaseOrderItemReceiptParams) (PurchaseOrderItem, error)
+	AddReservedStock(ctx context.Context, db DBTX, arg AddReservedStockParams) (StockBalance, error)
+	AddStockBalance(ctx context.Context, db DBTX, arg AddStockBalanceParams) (StockBalance, error)
+	AddTransferItemReceipt(ctx context.Context, db DBTX, arg AddTransferItemReceiptParams) (TransferItem, error)
+	ApproveDailyClosing(ctx context.Context, db DBTX, arg ApproveDailyClosingParams) (DailyClosing, error)
+	ApproveStockCount(ctx context.Context, db DBTX, arg ApproveStockCountParams) (StockCount, error)
+	CancelStockCount(ctx context.Context, db DBTX, id int64) (StockCount, error)
+	ClaimImportJob(ctx context.Context, db DBTX) (ImportJob, error)
+	CloseCashSession(ctx context.Context, db DBTX, arg CloseCashSessionParams) (CashSession, error)
+	CloseCeilingPrices(ctx context.Context, db DBTX, arg CloseCeilingPricesParams) error
+	CloseDeliveryStop(ctx context.Context, db DBTX, arg CloseDeliveryStopParams) (DeliveryStop, error)
+	ClosePriceLists(ctx context.Context, db DBTX, arg ClosePriceListsParams) error
+	ClosePurchaseOrder(ctx context.Context, db DBTX, id int64) (PurchaseOrder, error)
+	CloseStockReservation(ctx context.Context, db DBTX, arg CloseStockReservationParams) (StockReservation, error)
+	CountPendingDeliveryStops(ctx context.Context, db DBTX, deliveryOrderID int64) (int64, error)
+	CreateCashSession(ctx context.Context, db DBTX, arg CreateCashSessionParams) (CashSession, error)
+	CreateCeilingPrice(ctx context.Context, db DBTX, arg CreateCeilingPriceParams) (CeilingPrice, error)
+	CreateCustomer(ctx context.Context, db DBTX, arg CreateCustomerParams) (Customer, error)
+	CreateCylinder(ctx context.Context, db DBTX, arg CreateCylinderParams) (Cylinder, error)
+	CreateCylinderDeposit(ctx context.Context, db DBTX, arg CreateCylinderDepositParams) (CylinderDeposit, error)
+	CreateCylinderInspection(ctx context.Context, db DBTX, arg CreateCylinderInspectionParams) (CylinderInspection, error)
+	CreateCylinderMovement(ctx context.Context, db DBTX, arg CreateCylinderMovementParams) (CylinderMovement, error)
+	CreateDeliveryOrder(ctx context.Context, db DBTX, arg CreateDeliveryOrderParams) (DeliveryOrder, error)
+	CreateDeliveryReconciliation(ctx context.Context, db DBTX, arg CreateDeliveryReconciliationParams) (DeliveryReconciliation, error)
+	CreateDeliveryReconciliationItem(ctx context.Context, db DBTX, arg CreateDeliveryReconciliationItemParams) (DeliveryReconciliationItem, error)
+	CreateDeliveryStop(ctx context.Context, db DBTX, arg CreateDeliveryStopParams) (DeliveryStop, error)
+	CreateDeliveryStopItem(ctx context.Context, db DBTX, arg CreateDeliveryStopItemParams) (DeliveryStopItem, error)
+	CreateDepositRefund(ctx context.Context, db DBTX, arg CreateDepositRefundParams) (DepositRefund, error)
+	CreateDriver(ctx context.Context, db DBTX, arg CreateDriverParams) (Driver, error)
+	CreateDriverShortage(ctx context.Context, db DBTX, arg CreateDriverShortageParams) (DriverShortage, error)
+	CreateGoodsReceipt(ctx context.Context, db DBTX, arg CreateGoodsReceiptParams) (GoodsReceipt, error)
+	CreateGoodsReceiptItem(ctx context.Context, db DBTX, arg CreateGoodsReceiptItemParams) (GoodsReceiptItem, error)
+	CreateHoliday(ctx context.Context, db DBTX, arg CreateHolidayParams) (Holiday, error)
+	CreateImportJob(ctx context.Context, db DBTX, arg CreateImportJobParams) (ImportJob, error)
+	CreateImportJobError(ctx context.Context, db DBTX, arg CreateImportJobErrorParams) (ImportJobError, error)
+	CreateIncident(ctx context.Context, db DBTX, arg CreateIncidentParams) (Incident, error)
+	CreateIncidentPhoto(ctx context.Context, db DBTX, arg CreateIncidentPhotoParams) (IncidentPhoto, error)
+	CreateInvoice(ctx context.Context, db DBTX, arg CreateInvoiceParams) (Invoice, error)
+	CreateInvoicePayment(ctx context.Context, db DBTX, arg CreateInvoicePaymentParams) (InvoicePayment, error)
+	CreateLocation(ctx context.Context, db DBTX, arg CreateLocationParams) (Location, error)
+	CreatePriceList(ctx context.Context, db DBTX, arg CreatePriceListParams) (PriceList, error)
+	CreatePriceOverride(ctx context.Context, db DBTX, arg CreatePriceOverrideParams) (PriceOverride, error)
+	CreateProduct(ctx context.Context, db DBTX, arg CreateProductParams) (Product, error)
+	CreatePurchaseOrder(ctx context.Context, db DBTX, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderItem(ctx context.Context, db DBTX, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	CreateQuotaRule(ctx context.Context, db DBTX, arg CreateQuotaRuleParams) (QuotaRule, error)
+	CreateQuotaUsage(ctx context.Context, db DBTX, arg CreateQuotaUsageParams) (QuotaUsage, error)
+	CreateSale(ctx context.Context, db DBTX, arg CreateSaleParams) (Sale, error)
+	CreateSaleItem(ctx context.Context, db DBTX, arg CreateSaleItemParams) (SaleItem, error)
 	CreateSession(ctx context.Context, db DBTX, arg CreateSessionParams) (Session, error)
+	CreateStockCount(ctx context.Context, db DBTX, arg CreateStockCountParams) (StockCount, error)
+	CreateStockCountEntry(ctx context.Context, db DBTX, arg CreateStockCountEntryParams) (StockCountEntry, error)
+	CreateStockCountLine(ctx context.Context, db DBTX, arg CreateStockCountLineParams) (StockCountLine, error)
+	CreateStockMovement(ctx context.Context, db DBTX, arg CreateStockMovementParams) (StockMovement, error)
+	CreateStockReservation(ctx context.Context, db DBTX, arg CreateStockReservationParams) (StockReservation, error)
+	CreateSupplier(ctx context.Context, db DBTX, arg CreateSupplierParams) (Supplier, error)
+	CreateTransfer(ctx context.Context, db DBTX, arg CreateTransferParams) (Transfer, error)
+	CreateTransferDiscrepancy(ctx context.Context, db DBTX, arg CreateTransferDiscrepancyParams) (TransferDiscrepancy, error)
+	CreateTransferItem(ctx context.Context, db DBTX, arg CreateTransferItemParams) (TransferItem, error)
 	CreateUser(ctx context.Context, db DBTX, arg CreateUserParams) (User, error)
+	CreateVehicle(ctx context.Context, db DBTX, arg CreateVehicleParams) (Vehicle, error)
+	DeactivateCustomer(ctx context.Context, db DBTX, id int32) (Customer, error)
+	DeleteHoliday(ctx context.Context, db DBTX, id int32) error
+	DeleteStockThreshold(ctx context.Context, db DBTX, arg DeleteStockThresholdParams) error
+	DispatchTransfer(ctx context.Context, db DBTX, arg DispatchTransferParams) (Transfer, error)
+	DisposeIncident(ctx context.Context, db DBTX, arg DisposeIncidentParams) (Incident, error)
+	FindDuplicateCustomers(ctx context.Context, db DBTX, arg FindDuplicateCustomersParams) ([]Customer, error)
+	FinishImportJob(ctx context.Context, db DBTX, arg FinishImportJobParams) (ImportJob, error)
+	FlagCylindersDueForTest(ctx context.Context, db DBTX, dueBefore pgtype.Date) ([]Cylinder, error)
+	GetCashSession(ctx context.Context, db DBTX, id int64) (CashSession, error)
+	GetCashSessionForUpdate(ctx context.Context, db DBTX, id int64) (CashSession, error)
+	GetCustomer(ctx context.Context, db DBTX, id int32) (Customer, error)
+	GetCustomerForUpdate(ctx context.Context, db DBTX, id int32) (Customer, error)
+	GetCylinder(ctx context.Context, db DBTX, id int64) (Cylinder, error)
+	GetCylinderByCode(ctx context.Context, db DBTX, code string) (Cylinder, error)
+	GetCylinderDeposit(ctx context.Context, db DBTX, id int64) (CylinderDeposit, error)
+	GetCylinderForUpdate(ctx context.Context, db DBTX, id int64) (Cylinder, error)
+	GetDailyClosing(ctx context.Context, db DBTX, id int64) (DailyClosing, error)
+	GetDailyClosingForUpdate(ctx context.Context, db DBTX, id int64) (DailyClosing, error)
+	GetDeliveryOrder(ctx context.Context, db DBTX, id int64) (DeliveryOrder, error)
+	GetDeliveryOrderForUpdate(ctx context.Context, db DBTX, id int64) (DeliveryOrder, error)
+	GetDeliveryReconciliation(ctx context.Context, db DBTX, deliveryOrderID int64) (DeliveryReconciliation, error)
+	GetDeliveryStop(ctx context.Context, db DBTX, arg GetDeliveryStopParams) (DeliveryStop, error)
+	GetDeliveryStopForUpdate(ctx context.Context, db DBTX, arg GetDeliveryStopForUpdateParams) (DeliveryStop, error)
+	GetDriver(ctx context.Context, db DBTX, id int32) (Driver, error)
+	GetEffectiveCeilingPrice(ctx context.Context, db DBTX, arg GetEffectiveCeilingPriceParams) (CeilingPrice, error)
+	GetEffectivePriceList(ctx context.Context, db DBTX, arg GetEffectivePriceListParams) (PriceList, error)
+	GetGoodsReceipt(ctx context.Context, db DBTX, id int64) (GoodsReceipt, error)
+	GetImportJob(ctx context.Context, db DBTX, id int64) (ImportJob, error)
+	GetIncident(ctx context.Context, db DBTX, id int64) (Incident, error)
+	GetIncidentForUpdate(ctx context.Context, db DBTX, id int64) (Incident, error)
+	GetIncidentPhotoByKey(ctx context.Context, db DBTX, arg GetIncidentPhotoByKeyParams) (IncidentPhoto, error)
+	GetInvoice(ctx context.Context, db DBTX, id int64) (Invoice, error)
+	GetInvoiceBySaleForUpdate(ctx context.Context, db DBTX, saleID int64) (Invoice, error)
+	GetInvoiceForUpdate(ctx context.Context, db DBTX, id int64) (Invoice, error)
+	GetLocation(ctx context.Context, db DBTX, id int32) (Location, error)
+	GetLocationByCode(ctx context.Context, db DBTX, code string) (Location, error)
+	GetNextCeilingPrice(ctx context.Context, db DBTX, arg GetNextCeilingPriceParams) (CeilingPrice, error)
+	GetNextPriceList(ctx context.Context, db DBTX, arg GetNextPriceListParams) (PriceList, error)
+	GetOpenCashSession(ctx context.Context, db DBTX, cashier string) (CashSession, error)
+	GetProduct(ctx context.Context, db DBTX, id int32) (Product, error)
+	GetProductByCode(ctx context.Context, db DBTX, code string) (Product, error)
+	GetPurchaseOrder(ctx context.Context, db DBTX, id int64) (PurchaseOrder, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, db DBTX, id int64) (PurchaseOrder, error)
+	GetReceivablesAging(ctx context.Context, db DBTX, asOf pgtype.Date) ([]GetReceivablesAgingRow, error)
+	GetSale(ctx context.Context, db DBTX, id int64) (Sale, error)
+	GetSaleForUpdate(ctx context.Context, db DBTX, id int64) (Sale, error)
+	GetSalesDayClosingForShare(ctx context.Context, db DBTX, arg GetSalesDayClosingForShareParams) (string, error)
 	GetSession(ctx context.Context, db DBTX, id uuid.UUID) (Session, error)
+	GetStockBalanceForUpdate(ctx context.Context, db DBTX, arg GetStockBalanceForUpdateParams) (StockBalance, error)
+	GetStockCount(ctx context.Context, db DBTX, id int64) (StockCount, error)
+	GetStockCountForUpdate(ctx context.Context, db DBTX, id int64) (StockCount, error)
+	GetStockCountLineForUpdate(ctx context.Context, db DBTX, arg GetStockCountLineForUpdateParams) (StockCountLine, error)
+	GetSupplier(ctx context.Context, db DBTX, id int32) (Supplier, error)
+	GetTransfer(ctx context.Context, db DBTX, id int64) (Transfer, error)
+	GetTransferForUpdate(ctx context.Context, db DBTX, id int64) (Transfer, error)
 	GetUser(ctx context.Context, db DBTX, email string) (User, error)
+	GetUserByID(ctx context.Context, db DBTX, id int32) (User, error)
+	GetVehicle(ctx context.Context, db DBTX, id int32) (Vehicle, error)
+	ListActiveQuotaRules(ctx context.Context, db DBTX, arg ListActiveQuotaRulesParams) ([]QuotaRule, error)
+	ListActiveReservationsForUpdate(ctx context.Context, db DBTX, arg ListActiveReservationsForUpdateParams) ([]StockReservation, error)
+	ListCashSessions(ctx context.Context, db DBTX, arg ListCashSessionsParams) ([]CashSession, error)
+	ListCeilingPrices(ctx context.Context, db DBTX, region pgtype.Text) ([]CeilingPrice, error)
+	ListCustomers(ctx context.Context, db DBTX, arg ListCustomersParams) ([]Customer, error)
+	ListCylinderDeposits(ctx context.Context, db DBTX, arg ListCylinderDepositsParams) ([]CylinderDeposit, error)
+	ListCylinderInspections(ctx context.Context, db DBTX, cylinderID int64) ([]CylinderInspection, error)
+	ListCylinderMovements(ctx context.Context, db DBTX, cylinderID int64) ([]CylinderMovement, error)
+	ListCylinders(ctx context.Context, db DBTX, arg ListCylindersParams) ([]Cylinder, error)
+	ListCylindersDueForTest(ctx context.Context, db DBTX, arg ListCylindersDueForTestParams) ([]Cylinder, error)
+	ListDailyClosings(ctx context.Context, db DBTX, arg ListDailyClosingsParams) ([]DailyClosing, error)
+	ListDailyDemand(ctx context.Context, db DBTX, arg ListDailyDemandParams) ([]ListDailyDemandRow, error)
+	ListDayCashSessions(ctx context.Context, db DBTX, arg ListDayCashSessionsParams) ([]CashSession, error)
+	ListDeliveryOrderShortages(ctx context.Context, db DBTX, deliveryOrderID int64) ([]DriverShortage, error)
+	ListDeliveryOrders(ctx context.Context, db DBTX, arg ListDeliveryOrdersParams) ([]DeliveryOrder, error)
+	ListDeliveryReconciliationItems(ctx context.Context, db DBTX, reconciliationID int64) ([]DeliveryReconciliationItem, error)
+	ListDeliveryStopItems(ctx context.Context, db DBTX, deliveryOrderID int64) ([]DeliveryStopItem, error)
+	ListDeliveryStops(ctx context.Context, db DBTX, deliveryOrderID int64) ([]DeliveryStop, error)
+	ListDepositRefunds(ctx context.Context, db DBTX, depositID int64) ([]DepositRefund, error)
+	ListDriverShortages(ctx context.Context, db DBTX, arg ListDriverShortagesParams) ([]DriverShortage, error)
+	ListDrivers(ctx context.Context, db DBTX) ([]Driver, error)
+	ListEmptiesBalances(ctx context.Context, db DBTX, customerID int32) ([]EmptiesBalance, error)
+	ListEmptiesOutstanding(ctx context.Context, db DBTX, arg ListEmptiesOutstandingParams) ([]ListEmptiesOutstandingRow, error)
+	ListExpiredReservationsForUpdate(ctx context.Context, db DBTX) ([]StockReservation, error)
+	ListGoodsReceiptItems(ctx context.Context, db DBTX, goodsReceiptID int64) ([]GoodsReceiptItem, error)
+	ListGoodsReceipts(ctx context.Context, db DBTX, purchaseOrderID int64) ([]GoodsReceipt, error)
+	ListHolidays(ctx context.Context, db DBTX, arg ListHolidaysParams) ([]Holiday, error)
+	ListImportJobErrors(ctx context.Context, db DBTX, importJobID int64) ([]ImportJobError, error)
+	ListImportJobs(ctx context.Context, db DBTX, arg ListImportJobsParams) ([]ImportJob, error)
+	ListIncidentPhotos(ctx context.Context, db DBTX, incidentID int64) ([]IncidentPhoto, error)
+	ListIncidents(ctx context.Context, db DBTX, arg ListIncidentsParams) ([]Incident, error)
+	ListInvoicePayments(ctx context.Context, db DBTX, invoiceID int64) ([]InvoicePayment, error)
+	ListInvoices(ctx context.Context, db DBTX, arg ListInvoicesParams) ([]Invoice, error)
+	ListLocations(ctx context.Context, db DBTX) ([]Location, error)
+	ListMovementReport(ctx context.Context, db DBTX, arg ListMovementReportParams) ([]ListMovementReportRow, error)
+	ListPlannedDeliveryOrders(ctx context.Context, db DBTX, arg ListPlannedDeliveryOrdersParams) ([]DeliveryOrder, error)
+	ListPlannedDeliveryOrdersForUpdate(ctx context.Context, db DBTX, arg ListPlannedDeliveryOrdersForUpdateParams) ([]DeliveryOrder, error)
+	ListPlannedDeliveryStopItems(ctx context.Context, db DBTX, arg ListPlannedDeliveryStopItemsParams) ([]DeliveryStopItem, error)
+	ListPlannedDeliveryStops(ctx context.Context, db DBTX, arg ListPlannedDeliveryStopsParams) ([]ListPlannedDeliveryStopsRow, error)
+	ListPriceLists(ctx context.Context, db DBTX, productID int32) ([]PriceList, error)
+	ListPriceOverrides(ctx context.Context, db DBTX, arg ListPriceOverridesParams) ([]PriceOverride, error)
+	ListProducts(ctx context.Context, db DBTX) ([]Product, error)
+	ListPurchaseOrderItems(ctx context.Context, db DBTX, purchaseOrderID int64) ([]PurchaseOrderItem, error)
+	ListPurchaseOrders(ctx context.Context, db DBTX, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error)
+	ListQuotaRules(ctx context.Context, db DBTX) ([]QuotaRule, error)
+	ListQuotaUsages(ctx context.Context, db DBTX, arg ListQuotaUsagesParams) ([]ListQuotaUsagesRow, error)
+	ListReceiptCosts(ctx context.Context, db DBTX) ([]ListReceiptCostsRow, error)
+	ListReferenceCylinderMovements(ctx context.Context, db DBTX, arg ListReferenceCylinderMovementsParams) ([]CylinderMovement, error)
+	ListRefundableDepositsForUpdate(ctx context.Context, db DBTX, arg ListRefundableDepositsForUpdateParams) ([]CylinderDeposit, error)
+	ListSaleDepositsForUpdate(ctx context.Context, db DBTX, saleID pgtype.Int8) ([]CylinderDeposit, error)
+	ListSaleItems(ctx context.Context, db DBTX, saleID int64) ([]SaleItem, error)
+	ListSaleQuotaUsages(ctx context.Context, db DBTX, saleID int64) ([]QuotaUsage, error)
+	ListSales(ctx context.Context, db DBTX, arg ListSalesParams) ([]Sale, error)
+	ListSalesReport(ctx context.Context, db DBTX, arg ListSalesReportParams) ([]ListSalesReportRow, error)
+	ListStockAlerts(ctx context.Context, db DBTX, arg ListStockAlertsParams) ([]StockAlert, error)
+	ListStockBalances(ctx context.Context, db DBTX, locationID pgtype.Int4) ([]StockBalance, error)
+	ListStockCountLines(ctx context.Context, db DBTX, stockCountID int64) ([]StockCountLine, error)
+	ListStockCounts(ctx context.Context, db DBTX, arg ListStockCountsParams) ([]StockCount, error)
+	ListStockMovements(ctx context.Context, db DBTX, arg ListStockMovementsParams) ([]StockMovement, error)
+	ListStockOnHand(ctx context.Context, db DBTX) ([]ListStockOnHandRow, error)
+	ListStockReservations(ctx context.Context, db DBTX, arg ListStockReservationsParams) ([]StockReservation, error)
+	ListStockThresholds(ctx context.Context, db DBTX, locationID pgtype.Int4) ([]StockThreshold, error)
+	ListSuppliers(ctx context.Context, db DBTX) ([]Supplier, error)
+	ListThresholdLevels(ctx context.Context, db DBTX, locationID pgtype.Int4) ([]ListThresholdLevelsRow, error)
+	ListTopCustomers(ctx context.Context, db DBTX, arg ListTopCustomersParams) ([]ListTopCustomersRow, error)
+	ListTransferDiscrepancies(ctx context.Context, db DBTX, transferID int64) ([]TransferDiscrepancy, error)
+	ListTransferItems(ctx context.Context, db DBTX, transferID int64) ([]TransferItem, error)
+	ListTransfers(ctx context.Context, db DBTX, arg ListTransfersParams) ([]Transfer, error)
+	ListVehicleCapacities(ctx context.Context, db DBTX, vehicleID int32) ([]VehicleCapacity, error)
+	ListVehicles(ctx context.Context, db DBTX) ([]Vehicle, error)
+	MarkPurchaseOrderOrdered(ctx context.Context, db DBTX, id int64) (PurchaseOrder, error)
+	MoveDeliveryStop(ctx context.Context, db DBTX, arg MoveDeliveryStopParams) error
+	RaiseStockAlert(ctx context.Context, db DBTX, arg RaiseStockAlertParams) (int64, error)
+	RecordDeliveryProof(ctx context.Context, db DBTX, arg RecordDeliveryProofParams) (DeliveryStop, error)
+	RefreshDailyCustomerSales(ctx context.Context, db DBTX) error
+	RefreshDailyMovementSummary(ctx context.Context, db DBTX) error
+	RefreshDailySalesSummary(ctx context.Context, db DBTX) error
+	ResolveOrphanStockAlerts(ctx context.Context, db DBTX) (int64, error)
+	ResolveStockAlerts(ctx context.Context, db DBTX, arg ResolveStockAlertsParams) (int64, error)
+	SubmitDailyClosing(ctx context.Context, db DBTX, arg SubmitDailyClosingParams) (DailyClosing, error)
+	SumCashSessionInvoicePayments(ctx context.Context, db DBTX, cashSessionID pgtype.Int8) ([]SumCashSessionInvoicePaymentsRow, error)
+	SumCashSessionRefunds(ctx context.Context, db DBTX, cashSessionID pgtype.Int8) (int64, error)
+	SumCashSessionSales(ctx context.Context, db DBTX, cashSessionID pgtype.Int8) ([]SumCashSessionSalesRow, error)
+	SumCustomerOutstanding(ctx context.Context, db DBTX, customerID int32) (int64, error)
+	SumDayCreditSales(ctx context.Context, db DBTX, arg SumDayCreditSalesParams) (int64, error)
+	SumDeliveryOrderCash(ctx context.Context, db DBTX, deliveryOrderID int64) (int64, error)
+	SumDeliveryOrderItems(ctx context.Context, db DBTX, deliveryOrderID int64) ([]SumDeliveryOrderItemsRow, error)
+	SumDepositLiabilities(ctx context.Context, db DBTX, locationID pgtype.Int4) ([]SumDepositLiabilitiesRow, error)
+	SumDepositRefundMovements(ctx context.Context, db DBTX) ([]SumDepositRefundMovementsRow, error)
+	SumDepositRefundsByLocation(ctx context.Context, db DBTX) ([]SumDepositRefundsByLocationRow, error)
+	SumEmptiesOutstanding(ctx context.Context, db DBTX) ([]SumEmptiesOutstandingRow, error)
+	SumNewCylinderSales(ctx context.Context, db DBTX) ([]SumNewCylinderSalesRow, error)
+	SumOnOrder(ctx context.Context, db DBTX) ([]SumOnOrderRow, error)
+	SumQuotaUsage(ctx context.Context, db DBTX, arg SumQuotaUsageParams) (int32, error)
+	UpdateCustomer(ctx context.Context, db DBTX, arg UpdateCustomerParams) (Customer, error)
+	UpdateCylinderPosition(ctx context.Context, db DBTX, arg UpdateCylinderPositionParams) (Cylinder, error)
+	UpdateCylinderTest(ctx context.Context, db DBTX, arg UpdateCylinderTestParams) (Cylinder, error)
+	UpdateDeliveryOrderStatus(ctx context.Context, db DBTX, arg UpdateDeliveryOrderStatusParams) (DeliveryOrder, error)
+	UpdateDeliveryStopItemResult(ctx context.Context, db DBTX, arg UpdateDeliveryStopItemResultParams) (DeliveryStopItem, error)
+	UpdateDriver(ctx context.Context, db DBTX, arg UpdateDriverParams) (Driver, error)
+	UpdateLocation(ctx context.Context, db DBTX, arg UpdateLocationParams) (Location, error)
+	UpdateProduct(ctx context.Context, db DBTX, arg UpdateProductParams) (Product, error)
+	UpdatePurchaseOrderStatus(ctx context.Context, db DBTX, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error)
+	UpdateQuotaRule(ctx context.Context, db DBTX, arg UpdateQuotaRuleParams) (QuotaRule, error)
+	UpdateSaleTotals(ctx context.Context, db DBTX, arg UpdateSaleTotalsParams) (Sale, error)
+	UpdateStockCountLineCount(ctx context.Context, db DBTX, arg UpdateStockCountLineCountParams) (StockCountLine, error)
+	UpdateTransferReceiptStatus(ctx context.Context, db DBTX, arg UpdateTransferReceiptStatusParams) (Transfer, error)
+	UpdateUserRole(ctx context.Context, db DBTX, arg UpdateUserRoleParams) (User, error)
+	UpdateVehicleActive(ctx context.Context, db DBTX, arg UpdateVehicleActiveParams) (Vehicle, error)
+	UpsertProduct(ctx context.Context, db DBTX, arg UpsertProductParams) (UpsertProductRow, error)
+	UpsertStockThreshold(ctx context.Context, db DBTX, arg UpsertStockThresholdParams) (StockThreshold, error)
+	UpsertVehicleCapacity(ctx context.Context, db DBTX, arg UpsertVehicleCapacityParams) (VehicleCapacity, error)
+	VoidCylinderDeposit(ctx context.Context, db DBTX, id int64) (CylinderDeposit, error)
+	VoidInvoice(ctx context.Context, db DBTX, id int64) (Invoice, error)
+	VoidSale(ctx context.Context, db DBTX, arg VoidSaleParams) (Sale, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	QuotaPeriodDaily   = "daily"
+	QuotaPeriodWeekly  = "weekly"
+	QuotaPeriodMonthly = "monthly"
+)
+
+var (
+	ErrQuotaExceeded               = errors.New("quota exceeded")
+	ErrNotEligibleForSubsidy       = errors.New("customer type is not eligible for subsidized products")
+	ErrSubsidizedSaleNeedsCustomer = errors.New("a subsidized sale requires a registered customer")
+)
+
+// QuotaExceededError tells the client which rule refused the sale
+type QuotaExceededError struct {
+	Period    string
+	MaxQty    int32
+	Used      int32
+	Requested int32
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s : %s limit is %d, already used %d, requested %d",
+		ErrQuotaExceeded, e.Period, e.MaxQty, e.Used, e.Requested)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// QuotaPeriodStart returns the first day of the period containing at in the
+// zone of at, weeks start on monday
+func QuotaPeriodStart(period string, at time.Time) time.Time {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+
+	switch period {
+	case QuotaPeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case QuotaPeriodMonthly:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// consumeQuota checks every active quota rule for the customer's type and
+// records the sale against each of them. The customer row is locked first so
+// two sales to the same customer cannot both pass the check.
+func (store *SQLStore) consumeQuota(ctx context.Context, db DBTX, customerID int32, productID int32, quantity int32, saleID int64) error {
+	customer, err := store.GetCustomerForUpdate(ctx, db, customerID)
+	if err != nil {
+		return err
+	}
+
+	rules, err := store.ListActiveQuotaRules(ctx, db, ListActiveQuotaRulesParams{
+		ProductID:    productID,
+		CustomerType: customer.CustomerType,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		return ErrNotEligibleForSubsidy
+	}
+
+	now := time.Now().In(store.timeZone)
+	for _, rule := range rules {
+		periodStart := pgtype.Date{Time: QuotaPeriodStart(rule.Period, now), Valid: true}
+
+		used, err := store.SumQuotaUsage(ctx, db, SumQuotaUsageParams{
+			CustomerID:  customer.ID,
+			QuotaRuleID: rule.ID,
+			PeriodStart: periodStart,
+		})
+		if err != nil {
+			return err
+		}
+
+		if used+quantity > rule.MaxQty {
+			return &QuotaExceededError{
+				Period:    rule.Period,
+				MaxQty:    rule.MaxQty,
+				Used:      used,
+				Requested: quantity,
+			}
+		}
+
+		_, err = store.CreateQuotaUsage(ctx, db, CreateQuotaUsageParams{
+			CustomerID:  customer.ID,
+			ProductID:   productID,
+			QuotaRuleID: rule.ID,
+			PeriodStart: periodStart,
+			Quantity:    quantity,
+			SaleID:      saleID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
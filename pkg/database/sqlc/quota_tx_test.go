@@ -0,0 +1,45 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuotaPeriodStart(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	at := func(year int, month time.Month, day, hour int, zone *time.Location) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, zone)
+	}
+
+	tests := []struct {
+		name   string
+		period string
+		at     time.Time
+		want   time.Time
+	}{
+		{"daily", QuotaPeriodDaily, at(2025, 3, 5, 14, wib), at(2025, 3, 5, 0, wib)},
+		{"daily just after midnight", QuotaPeriodDaily, at(2025, 3, 5, 0, wib), at(2025, 3, 5, 0, wib)},
+		{"unknown period is a day", "yearly", at(2025, 3, 5, 14, wib), at(2025, 3, 5, 0, wib)},
+		// 2025-03-03 is a Monday
+		{"weekly on a monday", QuotaPeriodWeekly, at(2025, 3, 3, 8, wib), at(2025, 3, 3, 0, wib)},
+		{"weekly midweek", QuotaPeriodWeekly, at(2025, 3, 6, 8, wib), at(2025, 3, 3, 0, wib)},
+		{"weekly on a sunday", QuotaPeriodWeekly, at(2025, 3, 9, 23, wib), at(2025, 3, 3, 0, wib)},
+		{"weekly across the month", QuotaPeriodWeekly, at(2025, 4, 2, 8, wib), at(2025, 3, 31, 0, wib)},
+		{"weekly across the year", QuotaPeriodWeekly, at(2025, 1, 1, 8, wib), at(2024, 12, 30, 0, wib)},
+		{"monthly on the first", QuotaPeriodMonthly, at(2025, 3, 1, 0, wib), at(2025, 3, 1, 0, wib)},
+		{"monthly on the last", QuotaPeriodMonthly, at(2025, 3, 31, 23, wib), at(2025, 3, 1, 0, wib)},
+		{"monthly in a leap february", QuotaPeriodMonthly, at(2024, 2, 29, 12, wib), at(2024, 2, 1, 0, wib)},
+		// sunday 2025-03-09 18:00 UTC is already monday in the business zone
+		{"weekly in the business zone", QuotaPeriodWeekly, at(2025, 3, 9, 18, time.UTC).In(wib), at(2025, 3, 10, 0, wib)},
+		// 2025-03-31 20:00 UTC is already april in the business zone
+		{"monthly in the business zone", QuotaPeriodMonthly, at(2025, 3, 31, 20, time.UTC).In(wib), at(2025, 4, 1, 0, wib)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuotaPeriodStart(tt.period, tt.at); !got.Equal(tt.want) {
+				t.Errorf("QuotaPeriodStart() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
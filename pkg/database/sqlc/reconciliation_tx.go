@@ -0,0 +1,219 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrReconciliationMissingItem = errors.New("every product carried by the vehicle must be reported")
+	ErrReconciliationOverReport  = errors.New("reported quantity exceeds what should be on the vehicle")
+)
+
+type ReconciledItemParams struct {
+	ProductID int32 `json:"product_id"`
+	// FullQty and EmptyQty are the cylinders the driver brought back
+	FullQty  int32 `json:"full_qty"`
+	EmptyQty int32 `json:"empty_qty"`
+}
+
+type ReconcileDeliveryOrderTxParams struct {
+	DeliveryOrderID int64                  `json:"delivery_order_id"`
+	Items           []ReconciledItemParams `json:"items"`
+	CashCollected   int64                  `json:"cash_collected"`
+	Note            string                 `json:"note"`
+	ReconciledBy    string                 `json:"reconciled_by"`
+}
+
+// DeliveryReconciliationReport compares what the driver brought back with
+// what was loaded and delivered, and lists the shortages held against them
+type DeliveryReconciliationReport struct {
+	DeliveryOrder  DeliveryOrder                `json:"delivery_order"`
+	Reconciliation DeliveryReconciliation       `json:"reconciliation"`
+	Items          []DeliveryReconciliationItem `json:"items"`
+	Shortages      []DriverShortage             `json:"shortages"`
+}
+
+// ReconcileDeliveryOrderTx settles a vehicle back at its source location.
+// Every product on the order must be reported. What the driver brought back
+// is moved to the source location, anything missing is written off the
+// vehicle and recorded as a shortage against the driver, as is cash short of
+// the cash sales made at the stops.
+func (store *SQLStore) ReconcileDeliveryOrderTx(ctx context.Context, db TxBeginner, arg ReconcileDeliveryOrderTxParams) (DeliveryReconciliationReport, error) {
+	var result DeliveryReconciliationReport
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		order, err := store.lockDeliveryOrder(ctx, tx, arg.DeliveryOrderID, DeliveryStatusDelivered)
+		if err != nil {
+			return err
+		}
+
+		vehicle, err := store.GetVehicle(ctx, tx, order.VehicleID)
+		if err != nil {
+			return err
+		}
+
+		totals, err := store.SumDeliveryOrderItems(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		reported := make(map[int32]ReconciledItemParams, len(arg.Items))
+		for _, item := range arg.Items {
+			reported[item.ProductID] = item
+		}
+
+		for _, total := range totals {
+			if _, ok := reported[total.ProductID]; !ok {
+				return ErrReconciliationMissingItem
+			}
+		}
+
+		// all products of the order are reported, anything more is unknown
+		if len(reported) > len(totals) {
+			return ErrDeliveryUnknownItem
+		}
+
+		expectedCash, err := store.SumDeliveryOrderCash(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Reconciliation, err = store.CreateDeliveryReconciliation(ctx, tx, CreateDeliveryReconciliationParams{
+			DeliveryOrderID: order.ID,
+			DriverID:        order.DriverID,
+			ExpectedCash:    expectedCash,
+			ReportedCash:    arg.CashCollected,
+			CashVariance:    arg.CashCollected - expectedCash,
+			Note:            arg.Note,
+			ReconciledBy:    arg.ReconciledBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]DeliveryReconciliationItem, 0, len(totals))
+		result.Shortages = []DriverShortage{}
+		for _, total := range totals {
+			back := reported[total.ProductID]
+			fullShort := total.Quantity - total.DeliveredQty - back.FullQty
+			emptyShort := total.EmptiesCollected - back.EmptyQty
+			if fullShort < 0 || emptyShort < 0 {
+				return ErrReconciliationOverReport
+			}
+
+			item, err := store.CreateDeliveryReconciliationItem(ctx, tx, CreateDeliveryReconciliationItemParams{
+				ReconciliationID: result.Reconciliation.ID,
+				ProductID:        total.ProductID,
+				LoadedQty:        total.Quantity,
+				DeliveredQty:     total.DeliveredQty,
+				ReportedFullQty:  back.FullQty,
+				FullQtyShort:     fullShort,
+				EmptiesCollected: total.EmptiesCollected,
+				ReportedEmptyQty: back.EmptyQty,
+				EmptyQtyShort:    emptyShort,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, item)
+
+			if back.FullQty > 0 || back.EmptyQty > 0 {
+				err = store.moveStock(ctx, tx, moveStockParams{
+					FromLocationID: vehicle.LocationID,
+					ToLocationID:   order.SourceLocationID,
+					ProductID:      total.ProductID,
+					FullQty:        back.FullQty,
+					EmptyQty:       back.EmptyQty,
+					Reason:         MovementReasonDeliveryReturn,
+					ReferenceType:  ReferenceTypeDeliveryOrder,
+					ReferenceID:    order.ID,
+					CreatedBy:      arg.ReconciledBy,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if fullShort == 0 && emptyShort == 0 {
+				continue
+			}
+
+			_, err = store.postStockMovement(ctx, tx, CreateStockMovementParams{
+				LocationID:     vehicle.LocationID,
+				ProductID:      total.ProductID,
+				FullQtyChange:  -fullShort,
+				EmptyQtyChange: -emptyShort,
+				Reason:         MovementReasonDeliveryShortage,
+				ReferenceType:  ReferenceTypeDeliveryOrder,
+				ReferenceID:    order.ID,
+				CreatedBy:      arg.ReconciledBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			shortage, err := store.CreateDriverShortage(ctx, tx, CreateDriverShortageParams{
+				DriverID:        order.DriverID,
+				DeliveryOrderID: order.ID,
+				ProductID:       pgtype.Int4{Int32: total.ProductID, Valid: true},
+				FullQtyShort:    fullShort,
+				EmptyQtyShort:   emptyShort,
+				CreatedBy:       arg.ReconciledBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Shortages = append(result.Shortages, shortage)
+		}
+
+		// cash over is only reported, cash short is owed by the driver
+		if result.Reconciliation.CashVariance < 0 {
+			shortage, err := store.CreateDriverShortage(ctx, tx, CreateDriverShortageParams{
+				DriverID:        order.DriverID,
+				DeliveryOrderID: order.ID,
+				CashShort:       -result.Reconciliation.CashVariance,
+				CreatedBy:       arg.ReconciledBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Shortages = append(result.Shortages, shortage)
+		}
+
+		result.DeliveryOrder, err = store.UpdateDeliveryOrderStatus(ctx, tx, UpdateDeliveryOrderStatusParams{
+			ID:     order.ID,
+			Status: DeliveryStatusReconciled,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// GetDeliveryReconciliationReport loads the reconciliation of a settled order
+func (store *SQLStore) GetDeliveryReconciliationReport(ctx context.Context, db DBTX, deliveryOrderID int64) (DeliveryReconciliationReport, error) {
+	var report DeliveryReconciliationReport
+	var err error
+
+	report.DeliveryOrder, err = store.GetDeliveryOrder(ctx, db, deliveryOrderID)
+	if err != nil {
+		return report, err
+	}
+
+	report.Reconciliation, err = store.GetDeliveryReconciliation(ctx, db, deliveryOrderID)
+	if err != nil {
+		return report, err
+	}
+
+	report.Items, err = store.ListDeliveryReconciliationItems(ctx, db, report.Reconciliation.ID)
+	if err != nil {
+		return report, err
+	}
+
+	report.Shortages, err = store.ListDeliveryOrderShortages(ctx, db, deliveryOrderID)
+	return report, err
+}
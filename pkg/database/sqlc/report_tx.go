@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ReportPeriodDay   = "day"
+	ReportPeriodWeek  = "week"
+	ReportPeriodMonth = "month"
+)
+
+const (
+	ValuationMethodFIFO    = "fifo"
+	ValuationMethodAverage = "average"
+)
+
+var ErrValuationMethod = errors.New("unknown stock valuation method")
+
+type StockValuationParams struct {
+	Method     string      `json:"method"`
+	LocationID pgtype.Int4 `json:"location_id"`
+}
+
+type StockValuationRow struct {
+	LocationID int32 `json:"location_id"`
+	ProductID  int32 `json:"product_id"`
+	FullQty    int32 `json:"full_qty"`
+	UnitCost   int64 `json:"unit_cost"`
+	Value      int64 `json:"value"`
+}
+
+type StockValuation struct {
+	Method string              `json:"method"`
+	Rows   []StockValuationRow `json:"rows"`
+	Value  int64               `json:"value"`
+}
+
+// costLayer is the quantity of a product received at one purchase price
+type costLayer struct {
+	qty   int64
+	price int64
+}
+
+// productCost values qty units of a product from its receipt layers, newest
+// first. FIFO values the stock at the most recent receipts, as the older ones
+// were sold first; stock beyond what was ever received is valued at the oldest
+// price. The weighted average spreads the cost of every receipt evenly.
+func productCost(method string, layers []costLayer, qty int64) (int64, error) {
+	var value int64
+
+	switch method {
+	case ValuationMethodFIFO:
+		remaining := qty
+		for _, layer := range layers {
+			take := min(remaining, layer.qty)
+			value += take * layer.price
+			remaining -= take
+			if remaining == 0 {
+				break
+			}
+		}
+		if remaining > 0 && len(layers) > 0 {
+			value += remaining * layers[len(layers)-1].price
+		}
+	case ValuationMethodAverage:
+		var receivedQty, receivedCost int64
+		for _, layer := range layers {
+			receivedQty += layer.qty
+			receivedCost += layer.qty * layer.price
+		}
+		if receivedQty > 0 {
+			value = qty * receivedCost / receivedQty
+		}
+	default:
+		return 0, ErrValuationMethod
+	}
+
+	return value, nil
+}
+
+// StockValuation values the full cylinders on hand at purchase cost. The cost
+// of a product is worked out over its stock at every location, since receipts
+// are moved around by transfers, and shared among the locations by quantity.
+// Products never received through a purchase order are valued at 0.
+func (store *SQLStore) StockValuation(ctx context.Context, db DBTX, arg StockValuationParams) (StockValuation, error) {
+	result := StockValuation{Method: arg.Method, Rows: []StockValuationRow{}}
+
+	receipts, err := store.ListReceiptCosts(ctx, db)
+	if err != nil {
+		return result, err
+	}
+
+	layers := make(map[int32][]costLayer)
+	for _, receipt := range receipts {
+		layers[receipt.ProductID] = append(layers[receipt.ProductID], costLayer{
+			qty:   int64(receipt.ReceivedQty),
+			price: receipt.UnitPrice,
+		})
+	}
+
+	balances, err := store.ListStockOnHand(ctx, db)
+	if err != nil {
+		return result, err
+	}
+
+	onHand := make(map[int32]int64)
+	for _, balance := range balances {
+		onHand[balance.ProductID] += int64(balance.FullQty)
+	}
+
+	costs := make(map[int32]int64, len(onHand))
+	for productID, qty := range onHand {
+		costs[productID], err = productCost(arg.Method, layers[productID], qty)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	for _, balance := range balances {
+		if arg.LocationID.Valid && balance.LocationID != arg.LocationID.Int32 {
+			continue
+		}
+
+		qty := int64(balance.FullQty)
+		value := costs[balance.ProductID] * qty / onHand[balance.ProductID]
+		result.Rows = append(result.Rows, StockValuationRow{
+			LocationID: balance.LocationID,
+			ProductID:  balance.ProductID,
+			FullQty:    balance.FullQty,
+			UnitCost:   value / qty,
+			Value:      value,
+		})
+		result.Value += value
+	}
+
+	return result, nil
+}
+
+// RefreshReportViews brings the daily aggregates the reports read from up to
+// date with the ledgers, without blocking the reports reading them meanwhile
+func (store *SQLStore) RefreshReportViews(ctx context.Context, db DBTX) error {
+	refreshes := []func(context.Context, DBTX) error{
+		store.RefreshDailySalesSummary,
+		store.RefreshDailyCustomerSales,
+		store.RefreshDailyMovementSummary,
+	}
+
+	for _, refresh := range refreshes {
+		if err := refresh(ctx, db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
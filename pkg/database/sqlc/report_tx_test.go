@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestProductCost(t *testing.T) {
+	// newest receipt first
+	layers := []costLayer{
+		{qty: 10, price: 300},
+		{qty: 20, price: 200},
+		{qty: 30, price: 100},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		layers []costLayer
+		qty    int64
+		want   int64
+		err    error
+	}{
+		{"fifo from the newest receipt", ValuationMethodFIFO, layers, 5, 1500, nil},
+		{"fifo exactly one receipt", ValuationMethodFIFO, layers, 10, 3000, nil},
+		{"fifo across receipts", ValuationMethodFIFO, layers, 25, 3000 + 15*200, nil},
+		{"fifo every receipt", ValuationMethodFIFO, layers, 60, 3000 + 4000 + 3000, nil},
+		{"fifo beyond the receipts at the oldest price", ValuationMethodFIFO, layers, 65, 10000 + 5*100, nil},
+		{"fifo nothing on hand", ValuationMethodFIFO, layers, 0, 0, nil},
+		{"fifo never received", ValuationMethodFIFO, nil, 5, 0, nil},
+		{"fifo an empty receipt", ValuationMethodFIFO, []costLayer{{qty: 0, price: 500}, {qty: 4, price: 100}}, 3, 300, nil},
+		{"average of every receipt", ValuationMethodAverage, layers, 6, 6 * 10000 / 60, nil},
+		{"average beyond the receipts", ValuationMethodAverage, layers, 120, 20000, nil},
+		{"average rounds down", ValuationMethodAverage, []costLayer{{qty: 2, price: 100}, {qty: 1, price: 101}}, 1, 100, nil},
+		{"average keeps the fraction of many units", ValuationMethodAverage, []costLayer{{qty: 2, price: 100}, {qty: 1, price: 101}}, 3, 301, nil},
+		{"average nothing on hand", ValuationMethodAverage, layers, 0, 0, nil},
+		{"average never received", ValuationMethodAverage, nil, 5, 0, nil},
+		{"average only empty receipts", ValuationMethodAverage, []costLayer{{qty: 0, price: 500}}, 5, 0, nil},
+		{"unknown method", "lifo", layers, 5, 0, ErrValuationMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := productCost(tt.method, tt.layers, tt.qty)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("productCost() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("productCost() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSalesReportReconciles checks the daily sales summary against the totals
+// of the sales it is made of
+func TestSalesReportReconciles(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	store := NewStore(time.Local)
+
+	location, err := store.CreateLocation(ctx, tx, CreateLocationParams{
+		Code:         "TEST-REPORT",
+		Name:         "Report test outlet",
+		LocationType: LocationTypeOutlet,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	product, err := store.CreateProduct(ctx, tx, CreateProductParams{
+		Code:           "TEST-REPORT-12",
+		Name:           "Report test 12 kg",
+		NetWeightGrams: 12000,
+		Price:          200000,
+		DepositAmount:  150000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type line struct {
+		saleType string
+		qty      int32
+		price    int64
+		discount int64
+		deposit  int64
+	}
+
+	sales := []struct {
+		lines  []line
+		voided bool
+	}{
+		{lines: []line{{SaleTypeExchange, 3, 200000, 5000, 0}}},
+		{lines: []line{{SaleTypeNewCylinder, 2, 200000, 0, 150000}, {SaleTypeExchange, 1, 195000, 0, 0}}},
+		{lines: []line{{SaleTypeNewCylinder, 4, 200000, 10000, 150000}}},
+		// a voided sale is left out of the summary and of the totals
+		{lines: []line{{SaleTypeNewCylinder, 5, 200000, 0, 150000}}, voided: true},
+	}
+
+	var want ListSalesReportRow
+	var total, taxTotal int64
+	for _, s := range sales {
+		sale, err := store.CreateSale(ctx, tx, CreateSaleParams{
+			LocationID:    location.ID,
+			PaymentMethod: PaymentMethodCash,
+			CreatedBy:     "report-test",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var subtotal, discountTotal, depositTotal int64
+		for _, l := range s.lines {
+			amount := int64(l.qty) * l.price
+			_, err := store.CreateSaleItem(ctx, tx, CreateSaleItemParams{
+				SaleID:         sale.ID,
+				ProductID:      product.ID,
+				SaleType:       l.saleType,
+				Quantity:       l.qty,
+				UnitPrice:      l.price,
+				DiscountAmount: l.discount,
+				DepositAmount:  l.deposit,
+				LineTotal:      amount - l.discount + int64(l.qty)*l.deposit,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			subtotal += amount
+			discountTotal += l.discount
+			depositTotal += int64(l.qty) * l.deposit
+		}
+
+		tax := applyTax(subtotal-discountTotal, 1100)
+		sale, err = store.UpdateSaleTotals(ctx, tx, UpdateSaleTotalsParams{
+			ID:            sale.ID,
+			Subtotal:      subtotal,
+			DiscountTotal: discountTotal,
+			TaxTotal:      tax,
+			DepositTotal:  depositTotal,
+			Total:         subtotal - discountTotal + tax + depositTotal,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s.voided {
+			_, err = store.VoidSale(ctx, tx, VoidSaleParams{ID: sale.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		want.GrossAmount += sale.Subtotal
+		want.DiscountAmount += sale.DiscountTotal
+		want.DepositAmount += sale.DepositTotal
+		total += sale.Total
+		taxTotal += sale.TaxTotal
+	}
+
+	err = store.RefreshDailySalesSummary(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the sales were made today in the database's zone, a day either side
+	// covers that wherever the test runs
+	now := time.Now()
+	rows, err := store.ListSalesReport(ctx, tx, ListSalesReportParams{
+		Period:         ReportPeriodDay,
+		FromDate:       pgtype.Date{Time: now.AddDate(0, 0, -1), Valid: true},
+		ToDate:         pgtype.Date{Time: now.AddDate(0, 0, 1), Valid: true},
+		LocationFilter: pgtype.Int4{Int32: location.ID, Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got ListSalesReportRow
+	for _, row := range rows {
+		got.GrossAmount += row.GrossAmount
+		got.DiscountAmount += row.DiscountAmount
+		got.DepositAmount += row.DepositAmount
+		got.NetAmount += row.NetAmount
+	}
+
+	if got.GrossAmount != want.GrossAmount {
+		t.Errorf("gross amount = %d, the sales subtotal is %d", got.GrossAmount, want.GrossAmount)
+	}
+	if got.DiscountAmount != want.DiscountAmount {
+		t.Errorf("discount amount = %d, the sales discount is %d", got.DiscountAmount, want.DiscountAmount)
+	}
+	if got.DepositAmount != want.DepositAmount {
+		t.Errorf("deposit amount = %d, the sales deposit is %d", got.DepositAmount, want.DepositAmount)
+	}
+	if sum := got.GrossAmount - got.DiscountAmount + got.DepositAmount; sum != got.NetAmount {
+		t.Errorf("gross - discount + deposit = %d, net amount is %d", sum, got.NetAmount)
+	}
+	// the summary is before tax, sales.total is after it
+	if got.NetAmount+taxTotal != total {
+		t.Errorf("net amount + tax = %d, the sales total is %d", got.NetAmount+taxTotal, total)
+	}
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ReservationStatusActive   = "active"
+	ReservationStatusReleased = "released"
+	ReservationStatusExpired  = "expired"
+	// ReservationStatusFulfilled reservations were taken out of the location
+	// by the order holding them
+	ReservationStatusFulfilled = "fulfilled"
+)
+
+var ErrStockReserved = errors.New("stock is reserved for confirmed orders")
+
+type ReservationItemParams struct {
+	ProductID int32 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+}
+
+type ReserveStockParams struct {
+	LocationID    int32                   `json:"location_id"`
+	Items         []ReservationItemParams `json:"items"`
+	ReferenceType string                  `json:"reference_type"`
+	ReferenceID   int64                   `json:"reference_id"`
+	ExpiresAt     time.Time               `json:"expires_at"`
+	CreatedBy     string                  `json:"created_by"`
+}
+
+type ReservationTxResult struct {
+	Reservations  []StockReservation `json:"reservations"`
+	StockBalances []StockBalance     `json:"stock_balances"`
+}
+
+// postUnreservedStockMovement posts a movement that may only use stock no
+// order has reserved, such as a counter sale
+func (store *SQLStore) postUnreservedStockMovement(ctx context.Context, db DBTX, arg CreateStockMovementParams) (StockBalance, error) {
+	balance, err := store.postStockMovement(ctx, db, arg)
+	if err != nil {
+		return balance, err
+	}
+
+	if arg.FullQtyChange < 0 && balance.AvailableQty < 0 {
+		return StockBalance{}, ErrStockReserved
+	}
+
+	return balance, nil
+}
+
+// reserveStock holds back full stock of the location for an order. Each
+// balance is locked before its available quantity is checked, in product
+// order so concurrent reservations cannot deadlock.
+func (store *SQLStore) reserveStock(ctx context.Context, db DBTX, arg ReserveStockParams) (ReservationTxResult, error) {
+	var result ReservationTxResult
+
+	items := make([]ReservationItemParams, len(arg.Items))
+	copy(items, arg.Items)
+	sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+
+	for _, item := range items {
+		balance, err := store.GetStockBalanceForUpdate(ctx, db, GetStockBalanceForUpdateParams{
+			LocationID: arg.LocationID,
+			ProductID:  item.ProductID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, ErrInsufficientStock
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if balance.AvailableQty < item.Quantity {
+			return result, ErrInsufficientStock
+		}
+
+		balance, err = store.AddReservedStock(ctx, db, AddReservedStockParams{
+			Quantity:   item.Quantity,
+			LocationID: arg.LocationID,
+			ProductID:  item.ProductID,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.StockBalances = append(result.StockBalances, balance)
+
+		reservation, err := store.CreateStockReservation(ctx, db, CreateStockReservationParams{
+			LocationID:    arg.LocationID,
+			ProductID:     item.ProductID,
+			Quantity:      item.Quantity,
+			ReferenceType: arg.ReferenceType,
+			ReferenceID:   arg.ReferenceID,
+			ExpiresAt:     arg.ExpiresAt,
+			CreatedBy:     arg.CreatedBy,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Reservations = append(result.Reservations, reservation)
+	}
+
+	return result, nil
+}
+
+// closeReservations gives locked active reservations back to the available
+// stock of their location and closes them with the given status
+func (store *SQLStore) closeReservations(ctx context.Context, db DBTX, reservations []StockReservation, status string) (ReservationTxResult, error) {
+	var result ReservationTxResult
+
+	for _, reservation := range reservations {
+		balance, err := store.AddReservedStock(ctx, db, AddReservedStockParams{
+			Quantity:   -reservation.Quantity,
+			LocationID: reservation.LocationID,
+			ProductID:  reservation.ProductID,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.StockBalances = append(result.StockBalances, balance)
+
+		closed, err := store.CloseStockReservation(ctx, db, CloseStockReservationParams{
+			ID:     reservation.ID,
+			Status: status,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Reservations = append(result.Reservations, closed)
+	}
+
+	return result, nil
+}
+
+// releaseReservations closes every active reservation held by an order
+func (store *SQLStore) releaseReservations(ctx context.Context, db DBTX, referenceType string, referenceID int64, status string) (ReservationTxResult, error) {
+	reservations, err := store.ListActiveReservationsForUpdate(ctx, db, ListActiveReservationsForUpdateParams{
+		ReferenceType: referenceType,
+		ReferenceID:   referenceID,
+	})
+	if err != nil {
+		return ReservationTxResult{}, err
+	}
+
+	return store.closeReservations(ctx, db, reservations, status)
+}
+
+// ExpireReservationsTx releases every active reservation past its expiry.
+// Reservations locked by a running transaction are left for the next run.
+func (store *SQLStore) ExpireReservationsTx(ctx context.Context, db TxBeginner) (ReservationTxResult, error) {
+	var result ReservationTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		reservations, err := store.ListExpiredReservationsForUpdate(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		result, err = store.closeReservations(ctx, tx, reservations, ReservationStatusExpired)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,347 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RowsQuerier runs the list queries for their rows to be read one at a time,
+// which is how exports stream a list of any length without holding it in
+// memory. Each method runs the query of the same name, and the caller reads
+// the rows and closes them.
+type RowsQuerier interface {
+	GetReceivablesAgingRows(ctx context.Context, db DBTX, asOf pgtype.Date) (pgx.Rows, error)
+	ListCashSessionsRows(ctx context.Context, db DBTX, arg ListCashSessionsParams) (pgx.Rows, error)
+	ListCeilingPricesRows(ctx context.Context, db DBTX, region pgtype.Text) (pgx.Rows, error)
+	ListCustomersRows(ctx context.Context, db DBTX, arg ListCustomersParams) (pgx.Rows, error)
+	ListCylinderDepositsRows(ctx context.Context, db DBTX, arg ListCylinderDepositsParams) (pgx.Rows, error)
+	ListCylinderInspectionsRows(ctx context.Context, db DBTX, cylinderID int64) (pgx.Rows, error)
+	ListCylindersRows(ctx context.Context, db DBTX, arg ListCylindersParams) (pgx.Rows, error)
+	ListCylindersDueForTestRows(ctx context.Context, db DBTX, arg ListCylindersDueForTestParams) (pgx.Rows, error)
+	ListDailyClosingsRows(ctx context.Context, db DBTX, arg ListDailyClosingsParams) (pgx.Rows, error)
+	ListDeliveryOrdersRows(ctx context.Context, db DBTX, arg ListDeliveryOrdersParams) (pgx.Rows, error)
+	ListDriverShortagesRows(ctx context.Context, db DBTX, arg ListDriverShortagesParams) (pgx.Rows, error)
+	ListDriversRows(ctx context.Context, db DBTX) (pgx.Rows, error)
+	ListEmptiesBalancesRows(ctx context.Context, db DBTX, customerID int32) (pgx.Rows, error)
+	ListEmptiesOutstandingRows(ctx context.Context, db DBTX, arg ListEmptiesOutstandingParams) (pgx.Rows, error)
+	ListHolidaysRows(ctx context.Context, db DBTX, arg ListHolidaysParams) (pgx.Rows, error)
+	ListImportJobsRows(ctx context.Context, db DBTX, arg ListImportJobsParams) (pgx.Rows, error)
+	ListIncidentsRows(ctx context.Context, db DBTX, arg ListIncidentsParams) (pgx.Rows, error)
+	ListInvoicesRows(ctx context.Context, db DBTX, arg ListInvoicesParams) (pgx.Rows, error)
+	ListLocationsRows(ctx context.Context, db DBTX) (pgx.Rows, error)
+	ListMovementReportRows(ctx context.Context, db DBTX, arg ListMovementReportParams) (pgx.Rows, error)
+	ListPriceListsRows(ctx context.Context, db DBTX, productID int32) (pgx.Rows, error)
+	ListPriceOverridesRows(ctx context.Context, db DBTX, arg ListPriceOverridesParams) (pgx.Rows, error)
+	ListProductsRows(ctx context.Context, db DBTX) (pgx.Rows, error)
+	ListPurchaseOrdersRows(ctx context.Context, db DBTX, arg ListPurchaseOrdersParams) (pgx.Rows, error)
+	ListQuotaRulesRows(ctx context.Context, db DBTX) (pgx.Rows, error)
+	ListQuotaUsagesRows(ctx context.Context, db DBTX, arg ListQuotaUsagesParams) (pgx.Rows, error)
+	ListSalesRows(ctx context.Context, db DBTX, arg ListSalesParams) (pgx.Rows, error)
+	ListSalesReportRows(ctx context.Context, db DBTX, arg ListSalesReportParams) (pgx.Rows, error)
+	ListStockAlertsRows(ctx context.Context, db DBTX, arg ListStockAlertsParams) (pgx.Rows, error)
+	ListStockBalancesRows(ctx context.Context, db DBTX, locationID pgtype.Int4) (pgx.Rows, error)
+	ListStockCountsRows(ctx context.Context, db DBTX, arg ListStockCountsParams) (pgx.Rows, error)
+	ListStockMovementsRows(ctx context.Context, db DBTX, arg ListStockMovementsParams) (pgx.Rows, error)
+	ListStockReservationsRows(ctx context.Context, db DBTX, arg ListStockReservationsParams) (pgx.Rows, error)
+	ListStockThresholdsRows(ctx context.Context, db DBTX, locationID pgtype.Int4) (pgx.Rows, error)
+	ListSuppliersRows(ctx context.Context, db DBTX) (pgx.Rows, error)
+	ListTopCustomersRows(ctx context.Context, db DBTX, arg ListTopCustomersParams) (pgx.Rows, error)
+	ListTransfersRows(ctx context.Context, db DBTX, arg ListTransfersParams) (pgx.Rows, error)
+	ListVehiclesRows(ctx context.Context, db DBTX) (pgx.Rows, error)
+	SumDepositLiabilitiesRows(ctx context.Context, db DBTX, locationID pgtype.Int4) (pgx.Rows, error)
+}
+
+func (q *Queries) GetReceivablesAgingRows(ctx context.Context, db DBTX, asOf pgtype.Date) (pgx.Rows, error) {
+	return db.Query(ctx, getReceivablesAging, asOf)
+}
+
+func (q *Queries) ListCashSessionsRows(ctx context.Context, db DBTX, arg ListCashSessionsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listCashSessions,
+		arg.LocationID,
+		arg.Status,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListCeilingPricesRows(ctx context.Context, db DBTX, region pgtype.Text) (pgx.Rows, error) {
+	return db.Query(ctx, listCeilingPrices, region)
+}
+
+func (q *Queries) ListCustomersRows(ctx context.Context, db DBTX, arg ListCustomersParams) (pgx.Rows, error) {
+	return db.Query(ctx, listCustomers,
+		arg.CustomerType,
+		arg.Phone,
+		arg.IDNumber,
+		arg.Name,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListCylinderDepositsRows(ctx context.Context, db DBTX, arg ListCylinderDepositsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listCylinderDeposits,
+		arg.CustomerID,
+		arg.Status,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListCylinderInspectionsRows(ctx context.Context, db DBTX, cylinderID int64) (pgx.Rows, error) {
+	return db.Query(ctx, listCylinderInspections, cylinderID)
+}
+
+func (q *Queries) ListCylindersRows(ctx context.Context, db DBTX, arg ListCylindersParams) (pgx.Rows, error) {
+	return db.Query(ctx, listCylinders,
+		arg.ProductID,
+		arg.Status,
+		arg.LocationID,
+		arg.CustomerID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListCylindersDueForTestRows(ctx context.Context, db DBTX, arg ListCylindersDueForTestParams) (pgx.Rows, error) {
+	return db.Query(ctx, listCylindersDueForTest,
+		arg.DueBefore,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListDailyClosingsRows(ctx context.Context, db DBTX, arg ListDailyClosingsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listDailyClosings,
+		arg.LocationID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListDeliveryOrdersRows(ctx context.Context, db DBTX, arg ListDeliveryOrdersParams) (pgx.Rows, error) {
+	return db.Query(ctx, listDeliveryOrders,
+		arg.Status,
+		arg.ScheduledDate,
+		arg.VehicleID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListDriverShortagesRows(ctx context.Context, db DBTX, arg ListDriverShortagesParams) (pgx.Rows, error) {
+	return db.Query(ctx, listDriverShortages,
+		arg.DriverID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListDriversRows(ctx context.Context, db DBTX) (pgx.Rows, error) {
+	return db.Query(ctx, listDrivers)
+}
+
+func (q *Queries) ListEmptiesBalancesRows(ctx context.Context, db DBTX, customerID int32) (pgx.Rows, error) {
+	return db.Query(ctx, listEmptiesBalances, customerID)
+}
+
+func (q *Queries) ListEmptiesOutstandingRows(ctx context.Context, db DBTX, arg ListEmptiesOutstandingParams) (pgx.Rows, error) {
+	return db.Query(ctx, listEmptiesOutstanding,
+		arg.ProductID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListHolidaysRows(ctx context.Context, db DBTX, arg ListHolidaysParams) (pgx.Rows, error) {
+	return db.Query(ctx, listHolidays,
+		arg.FromDate,
+		arg.ToDate,
+	)
+}
+
+func (q *Queries) ListImportJobsRows(ctx context.Context, db DBTX, arg ListImportJobsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listImportJobs,
+		arg.Kind,
+		arg.Status,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListIncidentsRows(ctx context.Context, db DBTX, arg ListIncidentsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listIncidents,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Status,
+		arg.IncidentType,
+		arg.LocationID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListInvoicesRows(ctx context.Context, db DBTX, arg ListInvoicesParams) (pgx.Rows, error) {
+	return db.Query(ctx, listInvoices,
+		arg.CustomerID,
+		arg.Status,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListLocationsRows(ctx context.Context, db DBTX) (pgx.Rows, error) {
+	return db.Query(ctx, listLocations)
+}
+
+func (q *Queries) ListMovementReportRows(ctx context.Context, db DBTX, arg ListMovementReportParams) (pgx.Rows, error) {
+	return db.Query(ctx, listMovementReport,
+		arg.Period,
+		arg.ByLocation,
+		arg.ByProduct,
+		arg.FromDate,
+		arg.ToDate,
+		arg.LocationFilter,
+		arg.ProductFilter,
+		arg.ReasonFilter,
+	)
+}
+
+func (q *Queries) ListPriceListsRows(ctx context.Context, db DBTX, productID int32) (pgx.Rows, error) {
+	return db.Query(ctx, listPriceLists, productID)
+}
+
+func (q *Queries) ListPriceOverridesRows(ctx context.Context, db DBTX, arg ListPriceOverridesParams) (pgx.Rows, error) {
+	return db.Query(ctx, listPriceOverrides,
+		arg.FromTime,
+		arg.ToTime,
+	)
+}
+
+func (q *Queries) ListProductsRows(ctx context.Context, db DBTX) (pgx.Rows, error) {
+	return db.Query(ctx, listProducts)
+}
+
+func (q *Queries) ListPurchaseOrdersRows(ctx context.Context, db DBTX, arg ListPurchaseOrdersParams) (pgx.Rows, error) {
+	return db.Query(ctx, listPurchaseOrders,
+		arg.Status,
+		arg.SupplierID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListQuotaRulesRows(ctx context.Context, db DBTX) (pgx.Rows, error) {
+	return db.Query(ctx, listQuotaRules)
+}
+
+func (q *Queries) ListQuotaUsagesRows(ctx context.Context, db DBTX, arg ListQuotaUsagesParams) (pgx.Rows, error) {
+	return db.Query(ctx, listQuotaUsages,
+		arg.FromTime,
+		arg.ToTime,
+		arg.CustomerID,
+	)
+}
+
+func (q *Queries) ListSalesRows(ctx context.Context, db DBTX, arg ListSalesParams) (pgx.Rows, error) {
+	return db.Query(ctx, listSales,
+		arg.FromTime,
+		arg.ToTime,
+		arg.LocationID,
+		arg.CustomerID,
+		arg.Status,
+		arg.PaymentMethod,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListSalesReportRows(ctx context.Context, db DBTX, arg ListSalesReportParams) (pgx.Rows, error) {
+	return db.Query(ctx, listSalesReport,
+		arg.Period,
+		arg.ByLocation,
+		arg.ByProduct,
+		arg.FromDate,
+		arg.ToDate,
+		arg.LocationFilter,
+		arg.ProductFilter,
+	)
+}
+
+func (q *Queries) ListStockAlertsRows(ctx context.Context, db DBTX, arg ListStockAlertsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listStockAlerts,
+		arg.Status,
+		arg.LocationID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListStockBalancesRows(ctx context.Context, db DBTX, locationID pgtype.Int4) (pgx.Rows, error) {
+	return db.Query(ctx, listStockBalances, locationID)
+}
+
+func (q *Queries) ListStockCountsRows(ctx context.Context, db DBTX, arg ListStockCountsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listStockCounts,
+		arg.LocationID,
+		arg.Status,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListStockMovementsRows(ctx context.Context, db DBTX, arg ListStockMovementsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listStockMovements,
+		arg.LocationID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListStockReservationsRows(ctx context.Context, db DBTX, arg ListStockReservationsParams) (pgx.Rows, error) {
+	return db.Query(ctx, listStockReservations,
+		arg.LocationID,
+		arg.Status,
+		arg.ReferenceType,
+		arg.ReferenceID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListStockThresholdsRows(ctx context.Context, db DBTX, locationID pgtype.Int4) (pgx.Rows, error) {
+	return db.Query(ctx, listStockThresholds, locationID)
+}
+
+func (q *Queries) ListSuppliersRows(ctx context.Context, db DBTX) (pgx.Rows, error) {
+	return db.Query(ctx, listSuppliers)
+}
+
+func (q *Queries) ListTopCustomersRows(ctx context.Context, db DBTX, arg ListTopCustomersParams) (pgx.Rows, error) {
+	return db.Query(ctx, listTopCustomers,
+		arg.FromDate,
+		arg.ToDate,
+		arg.LocationID,
+		arg.RowLimit,
+	)
+}
+
+func (q *Queries) ListTransfersRows(ctx context.Context, db DBTX, arg ListTransfersParams) (pgx.Rows, error) {
+	return db.Query(ctx, listTransfers,
+		arg.Status,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+}
+
+func (q *Queries) ListVehiclesRows(ctx context.Context, db DBTX) (pgx.Rows, error) {
+	return db.Query(ctx, listVehicles)
+}
+
+func (q *Queries) SumDepositLiabilitiesRows(ctx context.Context, db DBTX, locationID pgtype.Int4) (pgx.Rows, error) {
+	return db.Query(ctx, sumDepositLiabilities, locationID)
+}
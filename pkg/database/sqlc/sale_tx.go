@@ -0,0 +1,521 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// SaleTypeExchange : the customer hands in empties and takes full cylinders
+	SaleTypeExchange = "exchange"
+	// SaleTypeNewCylinder : the customer takes full cylinders without handing
+	// anything in and pays a deposit for each cylinder instead
+	SaleTypeNewCylinder = "new_cylinder"
+)
+
+const (
+	SaleStatusCompleted = "completed"
+	SaleStatusVoided    = "voided"
+)
+
+const (
+	PaymentMethodCash     = "cash"
+	PaymentMethodTransfer = "transfer"
+	PaymentMethodQRIS     = "qris"
+	// PaymentMethodCredit bills the sale to the customer with an invoice
+	PaymentMethodCredit = "credit"
+)
+
+var (
+	ErrInvalidSaleType         = errors.New("invalid sale type")
+	ErrEmptiesWithoutCustomer  = errors.New("a customer is required when empties returned differ from quantity")
+	ErrDepositRequired         = errors.New("a new cylinder sale requires a deposit")
+	ErrEmptiesOnNewCylinderBuy = errors.New("a new cylinder sale cannot take empties")
+	ErrSaleEmpty               = errors.New("sale has no items")
+	ErrInvalidDiscount         = errors.New("discount cannot exceed the line amount")
+	ErrSaleStatus              = errors.New("sale is not in a valid status for this step")
+	ErrCustomerInactive        = errors.New("customer is no longer active")
+	ErrSerialsWithoutCustomer  = errors.New("a customer is required to hand over tracked cylinders")
+)
+
+type SaleItemParams struct {
+	ProductID       int32  `json:"product_id"`
+	SaleType        string `json:"sale_type"`
+	Quantity        int32  `json:"quantity"`
+	EmptiesReturned int32  `json:"empties_returned"`
+	// UnitPrice overrides the price list when set, which needs an admin's
+	// approval unless it is the price list's price
+	UnitPrice      *int64 `json:"unit_price"`
+	DiscountAmount int64  `json:"discount_amount"`
+	// OverrideReason allows the item to sell at a price other than the price
+	// list, or above the ceiling price, when the sale is approved by an admin
+	OverrideReason string `json:"override_reason"`
+	// Serials are the serial numbers or QR codes of the tracked cylinders
+	// handed over full
+	Serials []string `json:"serials"`
+	// EmptySerials are the tracked cylinders handed in empty
+	EmptySerials []string `json:"empty_serials"`
+}
+
+type CreateSaleTxParams struct {
+	LocationID int32 `json:"location_id"`
+	// PriceLocationID is the location whose price list and region price the
+	// sale when it is not LocationID, such as the depot a vehicle set out from
+	PriceLocationID int32       `json:"price_location_id"`
+	CustomerID      pgtype.Int4 `json:"customer_id"`
+	PaymentMethod   string      `json:"payment_method"`
+	// TaxBasisPoints is the tax rate applied to the discounted subtotal, 1100 is 11%
+	TaxBasisPoints int64            `json:"tax_basis_points"`
+	Note           string           `json:"note"`
+	Items          []SaleItemParams `json:"items"`
+	CreatedBy      string           `json:"created_by"`
+	// ApprovedBy is the admin allowing prices other than the price list or
+	// above the ceiling price, empty when the sale must stay within them
+	ApprovedBy string `json:"approved_by"`
+}
+
+type SaleTxResult struct {
+	Sale            Sale              `json:"sale"`
+	Items           []SaleItem        `json:"items"`
+	StockBalances   []StockBalance    `json:"stock_balances"`
+	EmptiesBalances []EmptiesBalance  `json:"empties_balances"`
+	Deposits        []CylinderDeposit `json:"deposits"`
+	// Invoice is only set for a credit sale
+	Invoice *Invoice `json:"invoice,omitempty"`
+	// Cylinders are the tracked cylinders handed over or handed in
+	Cylinders []Cylinder `json:"cylinders,omitempty"`
+}
+
+type VoidSaleTxParams struct {
+	SaleID   int64  `json:"sale_id"`
+	Reason   string `json:"reason"`
+	VoidedBy string `json:"voided_by"`
+}
+
+func validateSaleItem(customerID pgtype.Int4, item SaleItemParams) error {
+	if len(item.Serials) > 0 && !customerID.Valid {
+		return ErrSerialsWithoutCustomer
+	}
+
+	switch item.SaleType {
+	case SaleTypeExchange:
+		if item.EmptiesReturned != item.Quantity && !customerID.Valid {
+			return ErrEmptiesWithoutCustomer
+		}
+	case SaleTypeNewCylinder:
+		if item.EmptiesReturned != 0 {
+			return ErrEmptiesOnNewCylinderBuy
+		}
+	default:
+		return ErrInvalidSaleType
+	}
+
+	return nil
+}
+
+// emptiesOwed is how many empties a sale item leaves the customer owing, or
+// being owed when negative. A new cylinder sale is settled by its deposit, so
+// only an exchange can leave empties owing.
+func emptiesOwed(saleType string, quantity int32, emptiesReturned int32) int32 {
+	if saleType != SaleTypeExchange {
+		return 0
+	}
+	return quantity - emptiesReturned
+}
+
+// applyTax returns the tax on amount rounded half up to the nearest rupiah
+func applyTax(amount int64, basisPoints int64) int64 {
+	return (amount*basisPoints + 5000) / 10000
+}
+
+// CreateSaleTx records a sale and, in the same transaction, takes the full
+// cylinders out of the location's stock, puts the returned empties in, and
+// carries any difference to the customer's empties balance. The deposit on new
+// cylinders is recorded so it can be refunded when the empties come back.
+// Subsidized products are counted against the customer's quota in the same
+// transaction. Items are priced from the price list effective at the time of
+// the sale and may only deviate from it, or exceed the region's ceiling price,
+// with an admin's approval, which is logged as a price override. A credit sale
+// is invoiced to the customer as long as it fits under their credit limit.
+// Tracked cylinders handed over go to the customer, none of them may be
+// overdue for its re-test, and tracked empties handed in come to the location.
+func (store *SQLStore) CreateSaleTx(ctx context.Context, db TxBeginner, arg CreateSaleTxParams) (SaleTxResult, error) {
+	var result SaleTxResult
+
+	if len(arg.Items) == 0 {
+		return result, ErrSaleEmpty
+	}
+
+	if arg.PaymentMethod == PaymentMethodCredit && !arg.CustomerID.Valid {
+		return result, ErrCreditSaleNeedsCustomer
+	}
+
+	for _, item := range arg.Items {
+		if err := validateSaleItem(arg.CustomerID, item); err != nil {
+			return result, err
+		}
+	}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var customerType pgtype.Text
+		if arg.CustomerID.Valid {
+			customer, err := store.GetCustomer(ctx, tx, arg.CustomerID.Int32)
+			if err != nil {
+				return err
+			}
+
+			if !customer.IsActive {
+				return ErrCustomerInactive
+			}
+			customerType = pgtype.Text{String: customer.CustomerType, Valid: true}
+		}
+
+		location, err := store.GetLocation(ctx, tx, arg.LocationID)
+		if err != nil {
+			return err
+		}
+
+		// payment taken at an outlet goes into the cashier's drawer, a credit
+		// sale is paid later against its invoice
+		var sessionID pgtype.Int8
+		if arg.PaymentMethod != PaymentMethodCredit {
+			sessionID, err = store.cashSessionOf(ctx, tx, arg.CreatedBy, pgtype.Int4{Int32: arg.LocationID, Valid: true})
+			if err != nil {
+				return err
+			}
+
+			if !sessionID.Valid && location.LocationType == LocationTypeOutlet {
+				return ErrNoCashSession
+			}
+		}
+
+		err = store.checkSalesDayOpen(ctx, tx, arg.LocationID, sessionID, time.Now())
+		if err != nil {
+			return err
+		}
+
+		result.Sale, err = store.CreateSale(ctx, tx, CreateSaleParams{
+			LocationID:    arg.LocationID,
+			CustomerID:    arg.CustomerID,
+			PaymentMethod: arg.PaymentMethod,
+			Note:          arg.Note,
+			CreatedBy:     arg.CreatedBy,
+			CashSessionID: sessionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		priceLocationID := arg.LocationID
+		if arg.PriceLocationID != 0 {
+			priceLocationID = arg.PriceLocationID
+		}
+
+		var subtotal, discountTotal, depositTotal int64
+		result.Items = make([]SaleItem, 0, len(arg.Items))
+		result.StockBalances = make([]StockBalance, 0, len(arg.Items))
+		result.EmptiesBalances = []EmptiesBalance{}
+		result.Deposits = []CylinderDeposit{}
+		for _, item := range arg.Items {
+			product, err := store.GetProduct(ctx, tx, item.ProductID)
+			if err != nil {
+				return err
+			}
+
+			price, err := store.ResolvePrice(ctx, tx, ResolvePriceParams{
+				ProductID:    product.ID,
+				LocationID:   priceLocationID,
+				CustomerType: customerType,
+				At:           result.Sale.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			unitPrice := price.Price
+			if item.UnitPrice != nil {
+				unitPrice = *item.UnitPrice
+			}
+
+			var deposit int64
+			if item.SaleType == SaleTypeNewCylinder {
+				deposit = product.DepositAmount
+				if deposit <= 0 {
+					return ErrDepositRequired
+				}
+			}
+
+			amount := int64(item.Quantity) * unitPrice
+			if item.DiscountAmount > amount {
+				return ErrInvalidDiscount
+			}
+
+			// the ceiling price applies to what the customer pays per cylinder,
+			// after the discount
+			aboveCeiling := price.CeilingPrice.Valid &&
+				amount-item.DiscountAmount > int64(item.Quantity)*price.CeilingPrice.Int64
+			override := aboveCeiling || unitPrice != price.Price
+			if override && (arg.ApprovedBy == "" || item.OverrideReason == "") {
+				if aboveCeiling {
+					return ErrPriceAboveCeiling
+				}
+				return ErrPriceNotApproved
+			}
+
+			saleItem, err := store.CreateSaleItem(ctx, tx, CreateSaleItemParams{
+				SaleID:          result.Sale.ID,
+				ProductID:       product.ID,
+				SaleType:        item.SaleType,
+				Quantity:        item.Quantity,
+				EmptiesReturned: item.EmptiesReturned,
+				UnitPrice:       unitPrice,
+				DiscountAmount:  item.DiscountAmount,
+				DepositAmount:   deposit,
+				LineTotal:       amount - item.DiscountAmount + int64(item.Quantity)*deposit,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, saleItem)
+
+			if override {
+				_, err = store.CreatePriceOverride(ctx, tx, CreatePriceOverrideParams{
+					SaleID:       result.Sale.ID,
+					SaleItemID:   saleItem.ID,
+					ProductID:    product.ID,
+					LocationID:   arg.LocationID,
+					ListPrice:    pgtype.Int8{Int64: price.Price, Valid: true},
+					CeilingPrice: price.CeilingPrice,
+					ChargedPrice: unitPrice,
+					Reason:       item.OverrideReason,
+					ApprovedBy:   arg.ApprovedBy,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if deposit > 0 {
+				cylinderDeposit, err := store.CreateCylinderDeposit(ctx, tx, CreateCylinderDepositParams{
+					CustomerID:    arg.CustomerID,
+					ProductID:     product.ID,
+					LocationID:    arg.LocationID,
+					SaleID:        pgtype.Int8{Int64: result.Sale.ID, Valid: true},
+					Quantity:      item.Quantity,
+					AmountPerUnit: deposit,
+					CreatedBy:     arg.CreatedBy,
+				})
+				if err != nil {
+					return err
+				}
+				result.Deposits = append(result.Deposits, cylinderDeposit)
+			}
+
+			subtotal += amount
+			discountTotal += item.DiscountAmount
+			depositTotal += int64(item.Quantity) * deposit
+
+			if product.IsSubsidized {
+				if !arg.CustomerID.Valid {
+					return ErrSubsidizedSaleNeedsCustomer
+				}
+
+				err = store.consumeQuota(ctx, tx, arg.CustomerID.Int32, product.ID, item.Quantity, result.Sale.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			balance, err := store.postUnreservedStockMovement(ctx, tx, CreateStockMovementParams{
+				LocationID:     arg.LocationID,
+				ProductID:      product.ID,
+				FullQtyChange:  -item.Quantity,
+				EmptyQtyChange: item.EmptiesReturned,
+				Reason:         MovementReasonSale,
+				ReferenceType:  ReferenceTypeSale,
+				ReferenceID:    result.Sale.ID,
+				CreatedBy:      arg.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.StockBalances = append(result.StockBalances, balance)
+
+			cylinders, err := store.moveSerials(ctx, tx, moveSerialsParams{
+				Codes:          item.Serials,
+				Counts:         map[cylinderKey]int32{{ProductID: product.ID, Status: CylinderStatusFull}: item.Quantity},
+				FromLocationID: pgtype.Int4{Int32: arg.LocationID, Valid: true},
+				ToCustomerID:   arg.CustomerID,
+				Reason:         MovementReasonSale,
+				ReferenceType:  ReferenceTypeSale,
+				ReferenceID:    result.Sale.ID,
+				CreatedBy:      arg.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Cylinders = append(result.Cylinders, cylinders...)
+
+			cylinders, err = store.moveSerials(ctx, tx, moveSerialsParams{
+				Codes:         item.EmptySerials,
+				Counts:        map[cylinderKey]int32{{ProductID: product.ID, Status: CylinderStatusEmpty}: item.EmptiesReturned},
+				ToLocationID:  pgtype.Int4{Int32: arg.LocationID, Valid: true},
+				Reason:        MovementReasonSale,
+				ReferenceType: ReferenceTypeSale,
+				ReferenceID:   result.Sale.ID,
+				CreatedBy:     arg.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Cylinders = append(result.Cylinders, cylinders...)
+
+			owed := emptiesOwed(item.SaleType, item.Quantity, item.EmptiesReturned)
+			if owed != 0 {
+				emptiesBalance, err := store.AddEmptiesBalance(ctx, tx, AddEmptiesBalanceParams{
+					CustomerID: arg.CustomerID.Int32,
+					ProductID:  product.ID,
+					Balance:    owed,
+				})
+				if err != nil {
+					return err
+				}
+				result.EmptiesBalances = append(result.EmptiesBalances, emptiesBalance)
+			}
+		}
+
+		taxTotal := applyTax(subtotal-discountTotal, arg.TaxBasisPoints)
+		result.Sale, err = store.UpdateSaleTotals(ctx, tx, UpdateSaleTotalsParams{
+			ID:            result.Sale.ID,
+			Subtotal:      subtotal,
+			DiscountTotal: discountTotal,
+			TaxTotal:      taxTotal,
+			DepositTotal:  depositTotal,
+			Total:         subtotal - discountTotal + taxTotal + depositTotal,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.PaymentMethod == PaymentMethodCredit {
+			invoice, err := store.issueInvoice(ctx, tx, result.Sale)
+			if err != nil {
+				return err
+			}
+			result.Invoice = &invoice
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// VoidSaleTx cancels a completed sale. Nothing is deleted: every stock
+// movement, empties balance change and quota usage of the sale is offset by
+// a reversing entry, and the sale is kept with the voided status. Tracked
+// cylinders the sale moved go back, unless they have moved on since.
+func (store *SQLStore) VoidSaleTx(ctx context.Context, db TxBeginner, arg VoidSaleTxParams) (SaleTxResult, error) {
+	var result SaleTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		sale, err := store.GetSaleForUpdate(ctx, tx, arg.SaleID)
+		if err != nil {
+			return err
+		}
+
+		if sale.Status != SaleStatusCompleted {
+			return ErrSaleStatus
+		}
+
+		err = store.checkSalesDayOpen(ctx, tx, sale.LocationID, sale.CashSessionID, sale.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		result.Items, err = store.ListSaleItems(ctx, tx, sale.ID)
+		if err != nil {
+			return err
+		}
+
+		result.StockBalances = make([]StockBalance, 0, len(result.Items))
+		result.EmptiesBalances = []EmptiesBalance{}
+		for _, item := range result.Items {
+			balance, err := store.postStockMovement(ctx, tx, CreateStockMovementParams{
+				LocationID:     sale.LocationID,
+				ProductID:      item.ProductID,
+				FullQtyChange:  item.Quantity,
+				EmptyQtyChange: -item.EmptiesReturned,
+				Reason:         MovementReasonSaleVoid,
+				ReferenceType:  ReferenceTypeSale,
+				ReferenceID:    sale.ID,
+				CreatedBy:      arg.VoidedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.StockBalances = append(result.StockBalances, balance)
+
+			owed := emptiesOwed(item.SaleType, item.Quantity, item.EmptiesReturned)
+			if owed != 0 {
+				emptiesBalance, err := store.AddEmptiesBalance(ctx, tx, AddEmptiesBalanceParams{
+					CustomerID: sale.CustomerID.Int32,
+					ProductID:  item.ProductID,
+					Balance:    -owed,
+				})
+				if err != nil {
+					return err
+				}
+				result.EmptiesBalances = append(result.EmptiesBalances, emptiesBalance)
+			}
+		}
+
+		result.Cylinders, err = store.returnCylinders(ctx, tx, ReferenceTypeSale, sale.ID, MovementReasonSaleVoid, arg.VoidedBy)
+		if err != nil {
+			return err
+		}
+
+		result.Deposits, err = store.voidSaleDeposits(ctx, tx, sale.ID)
+		if err != nil {
+			return err
+		}
+
+		if sale.PaymentMethod == PaymentMethodCredit {
+			if err := store.voidSaleInvoice(ctx, tx, sale.ID); err != nil {
+				return err
+			}
+		}
+
+		usages, err := store.ListSaleQuotaUsages(ctx, tx, sale.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, usage := range usages {
+			_, err = store.CreateQuotaUsage(ctx, tx, CreateQuotaUsageParams{
+				CustomerID:  usage.CustomerID,
+				ProductID:   usage.ProductID,
+				QuotaRuleID: usage.QuotaRuleID,
+				PeriodStart: usage.PeriodStart,
+				Quantity:    -usage.Quantity,
+				SaleID:      usage.SaleID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Sale, err = store.VoidSale(ctx, tx, VoidSaleParams{
+			ID:         sale.ID,
+			VoidedBy:   pgtype.Text{String: arg.VoidedBy, Valid: true},
+			VoidReason: pgtype.Text{String: arg.Reason, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestValidateSaleItem(t *testing.T) {
+	customer := pgtype.Int4{Int32: 7, Valid: true}
+	walkIn := pgtype.Int4{}
+
+	tests := []struct {
+		name     string
+		customer pgtype.Int4
+		item     SaleItemParams
+		err      error
+	}{
+		{"exchange one for one", walkIn, SaleItemParams{SaleType: SaleTypeExchange, Quantity: 3, EmptiesReturned: 3}, nil},
+		{"exchange owing empties", customer, SaleItemParams{SaleType: SaleTypeExchange, Quantity: 3, EmptiesReturned: 1}, nil},
+		{"exchange returning extra empties", customer, SaleItemParams{SaleType: SaleTypeExchange, Quantity: 1, EmptiesReturned: 3}, nil},
+		{"walk-in exchange owing empties", walkIn, SaleItemParams{SaleType: SaleTypeExchange, Quantity: 3, EmptiesReturned: 1}, ErrEmptiesWithoutCustomer},
+		{"new cylinder", walkIn, SaleItemParams{SaleType: SaleTypeNewCylinder, Quantity: 2}, nil},
+		{"new cylinder with empties", customer, SaleItemParams{SaleType: SaleTypeNewCylinder, Quantity: 2, EmptiesReturned: 1}, ErrEmptiesOnNewCylinderBuy},
+		{"serials to a customer", customer, SaleItemParams{SaleType: SaleTypeExchange, Quantity: 1, EmptiesReturned: 1, Serials: []string{"A1"}}, nil},
+		{"serials to a walk-in", walkIn, SaleItemParams{SaleType: SaleTypeExchange, Quantity: 1, EmptiesReturned: 1, Serials: []string{"A1"}}, ErrSerialsWithoutCustomer},
+		{"unknown sale type", customer, SaleItemParams{SaleType: "refill", Quantity: 1, EmptiesReturned: 1}, ErrInvalidSaleType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSaleItem(tt.customer, tt.item); !errors.Is(err, tt.err) {
+				t.Errorf("validateSaleItem() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestEmptiesOwed(t *testing.T) {
+	tests := []struct {
+		name string
+		item SaleItemParams
+		want int32
+	}{
+		{"exchange one for one", SaleItemParams{SaleType: SaleTypeExchange, Quantity: 3, EmptiesReturned: 3}, 0},
+		{"exchange short of empties", SaleItemParams{SaleType: SaleTypeExchange, Quantity: 3, EmptiesReturned: 1}, 2},
+		{"exchange with no empties", SaleItemParams{SaleType: SaleTypeExchange, Quantity: 2}, 2},
+		{"exchange paying back owed empties", SaleItemParams{SaleType: SaleTypeExchange, Quantity: 1, EmptiesReturned: 4}, -3},
+		{"new cylinder is settled by its deposit", SaleItemParams{SaleType: SaleTypeNewCylinder, Quantity: 2}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emptiesOwed(tt.item.SaleType, tt.item.Quantity, tt.item.EmptiesReturned); got != tt.want {
+				t.Errorf("emptiesOwed() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTax(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      int64
+		basisPoints int64
+		want        int64
+	}{
+		{"eleven percent", 200000, 1100, 22000},
+		{"no tax", 200000, 0, 0},
+		{"nothing to tax", 0, 1100, 0},
+		{"rounds down below half a rupiah", 104, 1100, 11},
+		{"rounds up above half a rupiah", 15, 1100, 2},
+		{"rounds up from half a rupiah", 5, 1000, 1},
+		{"just below half a rupiah", 4, 1100, 0},
+		{"a large sale", 1_000_000_000, 1100, 110_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyTax(tt.amount, tt.basisPoints); got != tt.want {
+				t.Errorf("applyTax(%d, %d) = %d, want %d", tt.amount, tt.basisPoints, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	StockCountStatusCounting  = "counting"
+	StockCountStatusApproved  = "approved"
+	StockCountStatusCancelled = "cancelled"
+)
+
+var (
+	ErrStockCountStatus     = errors.New("stock count is not in a valid status for this step")
+	ErrStockCountIncomplete = errors.New("every product of the stock count must be counted before approval")
+)
+
+type OpenStockCountTxParams struct {
+	LocationID int32  `json:"location_id"`
+	Note       string `json:"note"`
+	CreatedBy  string `json:"created_by"`
+}
+
+type StockCountItemParams struct {
+	ProductID int32 `json:"product_id"`
+	FullQty   int32 `json:"full_qty"`
+	EmptyQty  int32 `json:"empty_qty"`
+}
+
+type SubmitStockCountTxParams struct {
+	StockCountID int64                  `json:"stock_count_id"`
+	Items        []StockCountItemParams `json:"items"`
+	CountedBy    string                 `json:"counted_by"`
+}
+
+type ApproveStockCountTxParams struct {
+	StockCountID int64  `json:"stock_count_id"`
+	ApprovedBy   string `json:"approved_by"`
+}
+
+// StockCountLineVariance is a count line with the difference between what
+// was counted and what was expected, the variance is zero until counted
+type StockCountLineVariance struct {
+	StockCountLine
+	FullVariance  int32 `json:"full_variance"`
+	EmptyVariance int32 `json:"empty_variance"`
+}
+
+type StockCountTxResult struct {
+	StockCount StockCount               `json:"stock_count"`
+	Lines      []StockCountLineVariance `json:"lines"`
+}
+
+// StockCountVariances computes the variance of every line of a stock count
+func StockCountVariances(lines []StockCountLine) []StockCountLineVariance {
+	variances := make([]StockCountLineVariance, 0, len(lines))
+	for _, line := range lines {
+		variance := StockCountLineVariance{StockCountLine: line}
+		if line.CountedFullQty.Valid {
+			variance.FullVariance = line.CountedFullQty.Int32 - line.ExpectedFullQty
+			variance.EmptyVariance = line.CountedEmptyQty.Int32 - line.ExpectedEmptyQty
+		}
+		variances = append(variances, variance)
+	}
+
+	return variances
+}
+
+// opnameAdjustment is what brings the balance of a counted line to the count.
+// It is taken against the balance at approval rather than the one frozen when
+// the count was opened, stock moved in the meantime is already on the ledger.
+func opnameAdjustment(line StockCountLine, balance StockBalance) (full int32, empty int32) {
+	return line.CountedFullQty.Int32 - balance.FullQty, line.CountedEmptyQty.Int32 - balance.EmptyQty
+}
+
+// OpenStockCountTx starts counting a location, the balance of every product
+// at that moment is frozen as the quantity expected by the count
+func (store *SQLStore) OpenStockCountTx(ctx context.Context, db TxBeginner, arg OpenStockCountTxParams) (StockCountTxResult, error) {
+	var result StockCountTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+
+		result.StockCount, err = store.CreateStockCount(ctx, tx, CreateStockCountParams(arg))
+		if err != nil {
+			return err
+		}
+
+		balances, err := store.ListStockBalances(ctx, tx, pgtype.Int4{Int32: arg.LocationID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		lines := make([]StockCountLine, 0, len(balances))
+		for _, balance := range balances {
+			line, err := store.CreateStockCountLine(ctx, tx, CreateStockCountLineParams{
+				StockCountID:     result.StockCount.ID,
+				ProductID:        balance.ProductID,
+				ExpectedFullQty:  balance.FullQty,
+				ExpectedEmptyQty: balance.EmptyQty,
+			})
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		}
+
+		result.Lines = StockCountVariances(lines)
+		return nil
+	})
+
+	return result, err
+}
+
+// SubmitStockCountTx records a counting pass. A product counted again
+// replaces its earlier count, and a product found without any balance is
+// added to the count as expected to be zero.
+func (store *SQLStore) SubmitStockCountTx(ctx context.Context, db TxBeginner, arg SubmitStockCountTxParams) (StockCountTxResult, error) {
+	var result StockCountTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+
+		result.StockCount, err = store.GetStockCountForUpdate(ctx, tx, arg.StockCountID)
+		if err != nil {
+			return err
+		}
+
+		if result.StockCount.Status != StockCountStatusCounting {
+			return ErrStockCountStatus
+		}
+
+		for _, item := range arg.Items {
+			line, err := store.GetStockCountLineForUpdate(ctx, tx, GetStockCountLineForUpdateParams{
+				StockCountID: arg.StockCountID,
+				ProductID:    item.ProductID,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				line, err = store.CreateStockCountLine(ctx, tx, CreateStockCountLineParams{
+					StockCountID: arg.StockCountID,
+					ProductID:    item.ProductID,
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = store.CreateStockCountEntry(ctx, tx, CreateStockCountEntryParams{
+				StockCountLineID: line.ID,
+				CountedFullQty:   item.FullQty,
+				CountedEmptyQty:  item.EmptyQty,
+				CountedBy:        arg.CountedBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = store.UpdateStockCountLineCount(ctx, tx, UpdateStockCountLineCountParams{
+				ID:              line.ID,
+				CountedFullQty:  pgtype.Int4{Int32: item.FullQty, Valid: true},
+				CountedEmptyQty: pgtype.Int4{Int32: item.EmptyQty, Valid: true},
+				CountedBy:       pgtype.Text{String: arg.CountedBy, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		lines, err := store.ListStockCountLines(ctx, tx, arg.StockCountID)
+		if err != nil {
+			return err
+		}
+
+		result.Lines = StockCountVariances(lines)
+		return nil
+	})
+
+	return result, err
+}
+
+// ApproveStockCountTx accepts the count of a location and posts to the ledger
+// an opname adjustment bringing every balance to what was counted. Stock
+// reserved by an order cannot be written off by a count.
+func (store *SQLStore) ApproveStockCountTx(ctx context.Context, db TxBeginner, arg ApproveStockCountTxParams) (StockCountTxResult, error) {
+	var result StockCountTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		count, err := store.GetStockCountForUpdate(ctx, tx, arg.StockCountID)
+		if err != nil {
+			return err
+		}
+
+		if count.Status != StockCountStatusCounting {
+			return ErrStockCountStatus
+		}
+
+		lines, err := store.ListStockCountLines(ctx, tx, count.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Lines = StockCountVariances(lines)
+		for _, line := range lines {
+			if !line.CountedFullQty.Valid {
+				return ErrStockCountIncomplete
+			}
+
+			balance, err := store.GetStockBalanceForUpdate(ctx, tx, GetStockBalanceForUpdateParams{
+				LocationID: count.LocationID,
+				ProductID:  line.ProductID,
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+
+			full, empty := opnameAdjustment(line, balance)
+			if full == 0 && empty == 0 {
+				continue
+			}
+
+			_, err = store.postUnreservedStockMovement(ctx, tx, CreateStockMovementParams{
+				LocationID:     count.LocationID,
+				ProductID:      line.ProductID,
+				FullQtyChange:  full,
+				EmptyQtyChange: empty,
+				Reason:         MovementReasonOpname,
+				ReferenceType:  ReferenceTypeStockCount,
+				ReferenceID:    count.ID,
+				CreatedBy:      arg.ApprovedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.StockCount, err = store.ApproveStockCount(ctx, tx, ApproveStockCountParams{
+			ID:         count.ID,
+			ApprovedBy: pgtype.Text{String: arg.ApprovedBy, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CancelStockCountTx abandons a count without posting anything
+func (store *SQLStore) CancelStockCountTx(ctx context.Context, db TxBeginner, id int64) (StockCount, error) {
+	var count StockCount
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		current, err := store.GetStockCountForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if current.Status != StockCountStatusCounting {
+			return ErrStockCountStatus
+		}
+
+		count, err = store.CancelStockCount(ctx, tx, id)
+		return err
+	})
+
+	return count, err
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func countedLine(expectedFull, expectedEmpty int32, counted bool, countedFull, countedEmpty int32) StockCountLine {
+	return StockCountLine{
+		ExpectedFullQty:  expectedFull,
+		ExpectedEmptyQty: expectedEmpty,
+		CountedFullQty:   pgtype.Int4{Int32: countedFull, Valid: counted},
+		CountedEmptyQty:  pgtype.Int4{Int32: countedEmpty, Valid: counted},
+	}
+}
+
+func TestStockCountVariances(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      StockCountLine
+		wantFull  int32
+		wantEmpty int32
+	}{
+		{"not counted yet", countedLine(10, 4, false, 0, 0), 0, 0},
+		{"counted as expected", countedLine(10, 4, true, 10, 4), 0, 0},
+		{"short", countedLine(10, 4, true, 8, 3), -2, -1},
+		{"over", countedLine(10, 4, true, 11, 6), 1, 2},
+		{"found without a balance", countedLine(0, 0, true, 3, 0), 3, 0},
+		{"counted as nothing", countedLine(10, 4, true, 0, 0), -10, -4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StockCountVariances([]StockCountLine{tt.line})
+			if len(got) != 1 {
+				t.Fatalf("StockCountVariances() returned %d lines, want 1", len(got))
+			}
+			if got[0].FullVariance != tt.wantFull || got[0].EmptyVariance != tt.wantEmpty {
+				t.Errorf("StockCountVariances() = %d full %d empty, want %d full %d empty",
+					got[0].FullVariance, got[0].EmptyVariance, tt.wantFull, tt.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestOpnameAdjustment(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      StockCountLine
+		balance   StockBalance
+		wantFull  int32
+		wantEmpty int32
+	}{
+		{"nothing moved during the count", countedLine(10, 4, true, 8, 4), StockBalance{FullQty: 10, EmptyQty: 4}, -2, 0},
+		{"sold during the count", countedLine(10, 4, true, 8, 6), StockBalance{FullQty: 8, EmptyQty: 6}, 0, 0},
+		{"received during the count", countedLine(10, 4, true, 15, 4), StockBalance{FullQty: 16, EmptyQty: 4}, -1, 0},
+		{"no balance at the location", countedLine(0, 0, true, 2, 1), StockBalance{}, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			full, empty := opnameAdjustment(tt.line, tt.balance)
+			if full != tt.wantFull || empty != tt.wantEmpty {
+				t.Errorf("opnameAdjustment() = %d full %d empty, want %d full %d empty",
+					full, empty, tt.wantFull, tt.wantEmpty)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	LocationTypeDepot     = "depot"
+	LocationTypeOutlet    = "outlet"
+	LocationTypeInTransit = "in_transit"
+	LocationTypeVehicle   = "vehicle"
+)
+
+// Reasons recorded on stock movements
+const (
+	MovementReasonSale             = "sale"
+	MovementReasonSaleVoid         = "sale_void"
+	MovementReasonTransferDispatch = "transfer_dispatch"
+	MovementReasonTransferReceipt  = "transfer_receipt"
+	MovementReasonTransferShortage = "transfer_shortage"
+	MovementReasonGoodsReceipt     = "goods_receipt"
+	MovementReasonDepositRefund    = "deposit_refund"
+	MovementReasonQuarantine       = "incident_quarantine"
+	MovementReasonRepaired         = "incident_repaired"
+	MovementReasonSupplierReturn   = "incident_returned_to_supplier"
+	MovementReasonScrapped         = "incident_scrapped"
+	MovementReasonCondemned        = "inspection_condemned"
+	MovementReasonOpname           = "opname"
+	MovementReasonDeliveryLoad     = "delivery_load"
+	MovementReasonDeliveryReturn   = "delivery_return"
+	MovementReasonDeliveryShortage = "delivery_shortage"
+	MovementReasonOpening          = "opening"
+)
+
+// Documents a stock movement can refer back to
+const (
+	ReferenceTypeSale          = "sale"
+	ReferenceTypeTransfer      = "transfer"
+	ReferenceTypeGoodsReceipt  = "goods_receipt"
+	ReferenceTypeDepositRefund = "deposit_refund"
+	ReferenceTypeIncident      = "incident"
+	ReferenceTypeInspection    = "cylinder_inspection"
+	ReferenceTypeStockCount    = "stock_count"
+	ReferenceTypeDeliveryOrder = "delivery_order"
+	ReferenceTypeImportJob     = "import_job"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// postStockMovement appends a movement to the ledger and applies it to the
+// running balance of the location. The balance row is locked by the update,
+// so concurrent postings for the same location and product are serialized.
+func (store *SQLStore) postStockMovement(ctx context.Context, db DBTX, arg CreateStockMovementParams) (StockBalance, error) {
+	balance, err := store.AddStockBalance(ctx, db, AddStockBalanceParams{
+		LocationID:    arg.LocationID,
+		ProductID:     arg.ProductID,
+		FullQty:       arg.FullQtyChange,
+		EmptyQty:      arg.EmptyQtyChange,
+		QuarantineQty: arg.QuarantineQtyChange,
+	})
+	if err != nil {
+		return StockBalance{}, err
+	}
+
+	if balance.FullQty < 0 || balance.EmptyQty < 0 || balance.QuarantineQty < 0 {
+		return StockBalance{}, ErrInsufficientStock
+	}
+
+	_, err = store.CreateStockMovement(ctx, db, arg)
+	if err != nil {
+		return StockBalance{}, err
+	}
+
+	return balance, nil
+}
+
+type moveStockParams struct {
+	FromLocationID int32
+	ToLocationID   int32
+	ProductID      int32
+	FullQty        int32
+	EmptyQty       int32
+	Reason         string
+	ReferenceType  string
+	ReferenceID    int64
+	CreatedBy      string
+}
+
+// moveStock takes quantities out of one location and puts them in another,
+// posting a movement on both sides of the ledger. Stock reserved at the
+// source location cannot be moved.
+func (store *SQLStore) moveStock(ctx context.Context, db DBTX, arg moveStockParams) error {
+	_, err := store.postUnreservedStockMovement(ctx, db, CreateStockMovementParams{
+		LocationID:     arg.FromLocationID,
+		ProductID:      arg.ProductID,
+		FullQtyChange:  -arg.FullQty,
+		EmptyQtyChange: -arg.EmptyQty,
+		Reason:         arg.Reason,
+		ReferenceType:  arg.ReferenceType,
+		ReferenceID:    arg.ReferenceID,
+		CreatedBy:      arg.CreatedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = store.postStockMovement(ctx, db, CreateStockMovementParams{
+		LocationID:     arg.ToLocationID,
+		ProductID:      arg.ProductID,
+		FullQtyChange:  arg.FullQty,
+		EmptyQtyChange: arg.EmptyQty,
+		Reason:         arg.Reason,
+		ReferenceType:  arg.ReferenceType,
+		ReferenceID:    arg.ReferenceID,
+		CreatedBy:      arg.CreatedBy,
+	})
+	return err
+}
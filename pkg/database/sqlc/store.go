@@ -1,15 +1,103 @@
 package database
 
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
 type Store interface {
 	Querier
+	RowsQuerier
+	CreateSaleTx(ctx context.Context, db TxBeginner, arg CreateSaleTxParams) (SaleTxResult, error)
+	VoidSaleTx(ctx context.Context, db TxBeginner, arg VoidSaleTxParams) (SaleTxResult, error)
+	CreateTransferTx(ctx context.Context, db TxBeginner, arg CreateTransferTxParams) (TransferTxResult, error)
+	DispatchTransferTx(ctx context.Context, db TxBeginner, arg DispatchTransferTxParams) (TransferTxResult, error)
+	ReceiveTransferTx(ctx context.Context, db TxBeginner, arg ReceiveTransferTxParams) (TransferTxResult, error)
+	CreatePurchaseOrderTx(ctx context.Context, db TxBeginner, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	OrderPurchaseOrderTx(ctx context.Context, db TxBeginner, id int64) (PurchaseOrder, error)
+	ClosePurchaseOrderTx(ctx context.Context, db TxBeginner, id int64) (PurchaseOrder, error)
+	ReceivePurchaseOrderTx(ctx context.Context, db TxBeginner, arg ReceivePurchaseOrderTxParams) (ReceivePurchaseOrderTxResult, error)
+	RecordInvoicePaymentTx(ctx context.Context, db TxBeginner, arg RecordInvoicePaymentTxParams) (InvoiceTxResult, error)
+	RefundDepositTx(ctx context.Context, db TxBeginner, arg RefundDepositTxParams) (RefundDepositTxResult, error)
+	ReconcileDeposits(ctx context.Context, db DBTX) ([]DepositReconciliation, error)
+	RegisterCylinderTx(ctx context.Context, db TxBeginner, arg RegisterCylinderTxParams) (CylinderTxResult, error)
+	MoveCylinderTx(ctx context.Context, db TxBeginner, arg MoveCylinderTxParams) (CylinderTxResult, error)
+	RecordInspectionTx(ctx context.Context, db TxBeginner, arg RecordInspectionTxParams) (InspectionTxResult, error)
+	ReportIncidentTx(ctx context.Context, db TxBeginner, arg ReportIncidentTxParams) (IncidentTxResult, error)
+	DisposeIncidentTx(ctx context.Context, db TxBeginner, arg DisposeIncidentTxParams) (IncidentTxResult, error)
+	OpenStockCountTx(ctx context.Context, db TxBeginner, arg OpenStockCountTxParams) (StockCountTxResult, error)
+	SubmitStockCountTx(ctx context.Context, db TxBeginner, arg SubmitStockCountTxParams) (StockCountTxResult, error)
+	ApproveStockCountTx(ctx context.Context, db TxBeginner, arg ApproveStockCountTxParams) (StockCountTxResult, error)
+	CancelStockCountTx(ctx context.Context, db TxBeginner, id int64) (StockCount, error)
+	ExpireReservationsTx(ctx context.Context, db TxBeginner) (ReservationTxResult, error)
+	CreateVehicleTx(ctx context.Context, db TxBeginner, arg CreateVehicleTxParams) (VehicleTxResult, error)
+	SetVehicleCapacitiesTx(ctx context.Context, db TxBeginner, vehicleID int32, capacities []VehicleCapacityParams) ([]VehicleCapacity, error)
+	CreateDeliveryOrderTx(ctx context.Context, db TxBeginner, arg CreateDeliveryOrderTxParams) (DeliveryOrderTxResult, error)
+	ConfirmDeliveryOrderTx(ctx context.Context, db TxBeginner, arg ConfirmDeliveryOrderTxParams) (DeliveryOrderTxResult, error)
+	LoadDeliveryOrderTx(ctx context.Context, db TxBeginner, arg LoadDeliveryOrderTxParams) (DeliveryOrderTxResult, error)
+	DepartDeliveryOrderTx(ctx context.Context, db TxBeginner, arg DeliveryOrderStepTxParams) (DeliveryOrderTxResult, error)
+	CompleteDeliveryStopTx(ctx context.Context, db TxBeginner, arg CompleteDeliveryStopTxParams) (DeliveryOrderTxResult, error)
+	FailDeliveryStopTx(ctx context.Context, db TxBeginner, arg FailDeliveryStopTxParams) (DeliveryOrderTxResult, error)
+	RecordDeliveryProofTx(ctx context.Context, db TxBeginner, arg RecordDeliveryProofTxParams) (DeliveryStop, error)
+	ReconcileDeliveryOrderTx(ctx context.Context, db TxBeginner, arg ReconcileDeliveryOrderTxParams) (DeliveryReconciliationReport, error)
+	GetDeliveryReconciliationReport(ctx context.Context, db DBTX, deliveryOrderID int64) (DeliveryReconciliationReport, error)
+	CancelDeliveryOrderTx(ctx context.Context, db TxBeginner, arg DeliveryOrderStepTxParams) (DeliveryOrderTxResult, error)
+	OpenCashSessionTx(ctx context.Context, db TxBeginner, arg OpenCashSessionTxParams) (CashSession, error)
+	CloseCashSessionTx(ctx context.Context, db TxBeginner, arg CloseCashSessionTxParams) (CashSession, error)
+	SubmitDailyClosingTx(ctx context.Context, db TxBeginner, arg SubmitDailyClosingTxParams) (DailyClosingTxResult, error)
+	ApproveDailyClosingTx(ctx context.Context, db TxBeginner, arg ApproveDailyClosingTxParams) (DailyClosing, error)
+	PlanDeliveries(ctx context.Context, db DBTX, arg PlanDeliveriesParams) (DeliveryPlan, error)
+	AcceptDeliveryPlanTx(ctx context.Context, db TxBeginner, arg AcceptDeliveryPlanTxParams) ([]DeliveryOrderTxResult, error)
+	EvaluateStockAlerts(ctx context.Context, db DBTX) (EvaluateStockAlertsResult, error)
+	SuggestReorders(ctx context.Context, db DBTX, arg SuggestReordersParams) ([]ReorderSuggestion, error)
+	ForecastDemand(ctx context.Context, db DBTX, arg ForecastDemandParams) (DemandForecast, error)
+	StockValuation(ctx context.Context, db DBTX, arg StockValuationParams) (StockValuation, error)
+	RefreshReportViews(ctx context.Context, db DBTX) error
+	ImportProductsTx(ctx context.Context, db TxBeginner, arg ImportProductsTxParams) (ImportResult, error)
+	ImportCustomersTx(ctx context.Context, db TxBeginner, arg ImportCustomersTxParams) (ImportResult, error)
+	ImportOpeningStockTx(ctx context.Context, db TxBeginner, arg ImportOpeningStockTxParams) (ImportResult, error)
+	FinishImportJobTx(ctx context.Context, db TxBeginner, arg FinishImportJobTxParams) (ImportJob, error)
+	ResolvePrice(ctx context.Context, db DBTX, arg ResolvePriceParams) (ResolvedPrice, error)
+	CreatePriceListTx(ctx context.Context, db TxBeginner, arg CreatePriceListTxParams) (PriceList, error)
+	CreateCeilingPriceTx(ctx context.Context, db TxBeginner, arg CreateCeilingPriceTxParams) (CeilingPrice, error)
+}
+
+// TxBeginner is satisfied by *pgxpool.Pool as well as pgx.Tx,
+// so a transaction can be started from either one.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type SQLStore struct {
 	*Queries
+	// timeZone is the business zone, days, weeks and months start in it
+	timeZone *time.Location
 }
 
-func NewStore() Store {
+func NewStore(timeZone *time.Location) Store {
 	return &SQLStore{
-		Queries: New(),
+		Queries:  New(),
+		timeZone: timeZone,
+	}
+}
+
+// execTx executes fn within a database transaction
+func (store *SQLStore) execTx(ctx context.Context, db TxBeginner, fn func(tx pgx.Tx) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
 	}
+
+	err = fn(tx)
+	if err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("tx err : %v, rb err : %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
@@ -0,0 +1,338 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	TransferStatusDraft             = "draft"
+	TransferStatusDispatched        = "dispatched"
+	TransferStatusPartiallyReceived = "partially_received"
+	TransferStatusReceived          = "received"
+)
+
+// InTransitLocationCode is the code of the virtual location seeded by the
+// transfer migration, dispatched stock waits there until it is received.
+const InTransitLocationCode = "IN-TRANSIT"
+
+var (
+	ErrTransferStatus      = errors.New("transfer is not in a valid status for this step")
+	ErrTransferEmpty       = errors.New("transfer has no items")
+	ErrTransferOverReceipt = errors.New("received quantity exceeds the quantity still in transit")
+	ErrTransferUnknownItem = errors.New("product is not part of the transfer")
+)
+
+type TransferItemParams struct {
+	ProductID int32 `json:"product_id"`
+	FullQty   int32 `json:"full_qty"`
+	EmptyQty  int32 `json:"empty_qty"`
+}
+
+type CreateTransferTxParams struct {
+	SourceLocationID      int32                `json:"source_location_id"`
+	DestinationLocationID int32                `json:"destination_location_id"`
+	Note                  string               `json:"note"`
+	Items                 []TransferItemParams `json:"items"`
+	CreatedBy             string               `json:"created_by"`
+}
+
+type TransferTxResult struct {
+	Transfer      Transfer              `json:"transfer"`
+	Items         []TransferItem        `json:"items"`
+	Discrepancies []TransferDiscrepancy `json:"discrepancies"`
+	// Cylinders are the tracked cylinders moved by the step
+	Cylinders []Cylinder `json:"cylinders,omitempty"`
+}
+
+type DispatchTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Serials are the serial numbers or QR codes of the tracked cylinders sent
+	Serials      []string `json:"serials"`
+	DispatchedBy string   `json:"dispatched_by"`
+}
+
+type ReceiveTransferTxParams struct {
+	TransferID int64                `json:"transfer_id"`
+	Items      []TransferItemParams `json:"items"`
+	// Serials are the serial numbers or QR codes of the tracked cylinders
+	// received
+	Serials []string `json:"serials"`
+	// Final closes the transfer, whatever is still in transit is recorded as a discrepancy
+	Final      bool   `json:"final"`
+	Note       string `json:"note"`
+	ReceivedBy string `json:"received_by"`
+}
+
+// CreateTransferTx creates a draft transfer together with its items
+func (store *SQLStore) CreateTransferTx(ctx context.Context, db TxBeginner, arg CreateTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	if len(arg.Items) == 0 {
+		return result, ErrTransferEmpty
+	}
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+
+		result.Transfer, err = store.CreateTransfer(ctx, tx, CreateTransferParams{
+			SourceLocationID:      arg.SourceLocationID,
+			DestinationLocationID: arg.DestinationLocationID,
+			Note:                  arg.Note,
+			CreatedBy:             arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]TransferItem, 0, len(arg.Items))
+		for _, item := range arg.Items {
+			transferItem, err := store.CreateTransferItem(ctx, tx, CreateTransferItemParams{
+				TransferID: result.Transfer.ID,
+				ProductID:  item.ProductID,
+				FullQty:    item.FullQty,
+				EmptyQty:   item.EmptyQty,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, transferItem)
+		}
+
+		result.Discrepancies = []TransferDiscrepancy{}
+		return nil
+	})
+
+	return result, err
+}
+
+// DispatchTransferTx moves every item of a draft transfer from its source
+// location into the in-transit location, together with the tracked cylinders
+// sent, none of which may be overdue for its re-test if it goes full
+func (store *SQLStore) DispatchTransferTx(ctx context.Context, db TxBeginner, arg DispatchTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		transfer, err := store.GetTransferForUpdate(ctx, tx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		if transfer.Status != TransferStatusDraft {
+			return ErrTransferStatus
+		}
+
+		inTransit, err := store.GetLocationByCode(ctx, tx, InTransitLocationCode)
+		if err != nil {
+			return fmt.Errorf("cannot find in-transit location : %w", err)
+		}
+
+		result.Items, err = store.ListTransferItems(ctx, tx, transfer.ID)
+		if err != nil {
+			return err
+		}
+
+		counts := make(map[cylinderKey]int32, 2*len(result.Items))
+		for _, item := range result.Items {
+			counts[cylinderKey{ProductID: item.ProductID, Status: CylinderStatusFull}] += item.FullQty
+			counts[cylinderKey{ProductID: item.ProductID, Status: CylinderStatusEmpty}] += item.EmptyQty
+
+			err = store.moveStock(ctx, tx, moveStockParams{
+				FromLocationID: transfer.SourceLocationID,
+				ToLocationID:   inTransit.ID,
+				ProductID:      item.ProductID,
+				FullQty:        item.FullQty,
+				EmptyQty:       item.EmptyQty,
+				Reason:         MovementReasonTransferDispatch,
+				ReferenceType:  ReferenceTypeTransfer,
+				ReferenceID:    transfer.ID,
+				CreatedBy:      arg.DispatchedBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Cylinders, err = store.moveSerials(ctx, tx, moveSerialsParams{
+			Codes:          arg.Serials,
+			Counts:         counts,
+			FromLocationID: pgtype.Int4{Int32: transfer.SourceLocationID, Valid: true},
+			ToLocationID:   pgtype.Int4{Int32: inTransit.ID, Valid: true},
+			Reason:         MovementReasonTransferDispatch,
+			ReferenceType:  ReferenceTypeTransfer,
+			ReferenceID:    transfer.ID,
+			CreatedBy:      arg.DispatchedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = store.DispatchTransfer(ctx, tx, DispatchTransferParams{
+			ID:           transfer.ID,
+			DispatchedBy: pgtype.Text{String: arg.DispatchedBy, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Discrepancies = []TransferDiscrepancy{}
+		return nil
+	})
+
+	return result, err
+}
+
+// ReceiveTransferTx lands received quantities at the destination, together
+// with the tracked cylinders received. A receipt may cover only part of the
+// transfer; once it is final, or everything has arrived, any quantity still in
+// transit is written off as a discrepancy.
+func (store *SQLStore) ReceiveTransferTx(ctx context.Context, db TxBeginner, arg ReceiveTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		transfer, err := store.GetTransferForUpdate(ctx, tx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		if transfer.Status != TransferStatusDispatched && transfer.Status != TransferStatusPartiallyReceived {
+			return ErrTransferStatus
+		}
+
+		inTransit, err := store.GetLocationByCode(ctx, tx, InTransitLocationCode)
+		if err != nil {
+			return fmt.Errorf("cannot find in-transit location : %w", err)
+		}
+
+		items, err := store.ListTransferItems(ctx, tx, transfer.ID)
+		if err != nil {
+			return err
+		}
+
+		itemsByProduct := make(map[int32]TransferItem, len(items))
+		for _, item := range items {
+			itemsByProduct[item.ProductID] = item
+		}
+
+		counts := make(map[cylinderKey]int32, 2*len(arg.Items))
+		for _, received := range arg.Items {
+			item, ok := itemsByProduct[received.ProductID]
+			if !ok {
+				return ErrTransferUnknownItem
+			}
+			counts[cylinderKey{ProductID: received.ProductID, Status: CylinderStatusFull}] += received.FullQty
+			counts[cylinderKey{ProductID: received.ProductID, Status: CylinderStatusEmpty}] += received.EmptyQty
+
+			if item.ReceivedFullQty+received.FullQty > item.FullQty ||
+				item.ReceivedEmptyQty+received.EmptyQty > item.EmptyQty {
+				return ErrTransferOverReceipt
+			}
+
+			err = store.moveStock(ctx, tx, moveStockParams{
+				FromLocationID: inTransit.ID,
+				ToLocationID:   transfer.DestinationLocationID,
+				ProductID:      received.ProductID,
+				FullQty:        received.FullQty,
+				EmptyQty:       received.EmptyQty,
+				Reason:         MovementReasonTransferReceipt,
+				ReferenceType:  ReferenceTypeTransfer,
+				ReferenceID:    transfer.ID,
+				CreatedBy:      arg.ReceivedBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			itemsByProduct[received.ProductID], err = store.AddTransferItemReceipt(ctx, tx, AddTransferItemReceiptParams{
+				ID:       item.ID,
+				FullQty:  received.FullQty,
+				EmptyQty: received.EmptyQty,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Cylinders, err = store.moveSerials(ctx, tx, moveSerialsParams{
+			Codes:          arg.Serials,
+			Counts:         counts,
+			FromLocationID: pgtype.Int4{Int32: inTransit.ID, Valid: true},
+			ToLocationID:   pgtype.Int4{Int32: transfer.DestinationLocationID, Valid: true},
+			Reason:         MovementReasonTransferReceipt,
+			ReferenceType:  ReferenceTypeTransfer,
+			ReferenceID:    transfer.ID,
+			CreatedBy:      arg.ReceivedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		complete := true
+		for i, item := range items {
+			items[i] = itemsByProduct[item.ProductID]
+			if items[i].ReceivedFullQty != items[i].FullQty || items[i].ReceivedEmptyQty != items[i].EmptyQty {
+				complete = false
+			}
+		}
+
+		status := TransferStatusPartiallyReceived
+		if arg.Final || complete {
+			status = TransferStatusReceived
+
+			for _, item := range items {
+				short := TransferItemParams{
+					ProductID: item.ProductID,
+					FullQty:   item.FullQty - item.ReceivedFullQty,
+					EmptyQty:  item.EmptyQty - item.ReceivedEmptyQty,
+				}
+				if short.FullQty == 0 && short.EmptyQty == 0 {
+					continue
+				}
+
+				_, err = store.CreateTransferDiscrepancy(ctx, tx, CreateTransferDiscrepancyParams{
+					TransferID:    transfer.ID,
+					ProductID:     item.ProductID,
+					FullQtyShort:  short.FullQty,
+					EmptyQtyShort: short.EmptyQty,
+					Note:          arg.Note,
+					CreatedBy:     arg.ReceivedBy,
+				})
+				if err != nil {
+					return err
+				}
+
+				_, err = store.postStockMovement(ctx, tx, CreateStockMovementParams{
+					LocationID:     inTransit.ID,
+					ProductID:      item.ProductID,
+					FullQtyChange:  -short.FullQty,
+					EmptyQtyChange: -short.EmptyQty,
+					Reason:         MovementReasonTransferShortage,
+					ReferenceType:  ReferenceTypeTransfer,
+					ReferenceID:    transfer.ID,
+					CreatedBy:      arg.ReceivedBy,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		result.Transfer, err = store.UpdateTransferReceiptStatus(ctx, tx, UpdateTransferReceiptStatusParams{
+			ID:     transfer.ID,
+			Status: status,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Items = items
+		result.Discrepancies, err = store.ListTransferDiscrepancies(ctx, tx, transfer.ID)
+		return err
+	})
+
+	return result, err
+}
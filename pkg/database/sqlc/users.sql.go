@@ -12,7 +12,7 @@ import (
 const createUser = `-- name: CreateUser :one
 INSERT INTO users ("firstName", "lastName", email, password)
 VALUES ($1, $2, $3, $4)
-RETURNING id, "firstName", "lastName", email, password, "isActive", created_at, updated_at
+RETURNING id, "firstName", "lastName", email, password, "isActive", created_at, updated_at, role
 `
 
 type CreateUserParams struct {
@@ -39,12 +39,13 @@ func (q *Queries) CreateUser(ctx context.Context, db DBTX, arg CreateUserParams)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, "firstName", "lastName", email, password, "isActive", created_at, updated_at
+SELECT id, "firstName", "lastName", email, password, "isActive", created_at, updated_at, role
 FROM users
 WHERE email = $1
 LIMIT 1
@@ -62,6 +63,64 @@ func (q *Queries) GetUser(ctx context.Context, db DBTX, email string) (User, err
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, "firstName", "lastName", email, password, "isActive", created_at, updated_at, role
+FROM users
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, db DBTX, id int32) (User, error) {
+	row := db.QueryRow(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Password,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $1,
+    updated_at = now()
+WHERE id = $2
+RETURNING id, "firstName", "lastName", email, password, "isActive", created_at, updated_at, role
+`
+
+type UpdateUserRoleParams struct {
+	Role string `json:"role"`
+	ID   int32  `json:"id"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, db DBTX, arg UpdateUserRoleParams) (User, error) {
+	row := db.QueryRow(ctx, updateUserRole,
+		arg.Role,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.Password,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
 	)
 	return i, err
 }
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// VehicleLocationCodePrefix prefixes the plate number in the code of the
+// location created for a vehicle
+const VehicleLocationCodePrefix = "VEH-"
+
+type VehicleCapacityParams struct {
+	ProductID int32 `json:"product_id"`
+	Capacity  int32 `json:"capacity"`
+}
+
+type CreateVehicleTxParams struct {
+	PlateNumber string                  `json:"plate_number"`
+	Region      pgtype.Text             `json:"region"`
+	Capacities  []VehicleCapacityParams `json:"capacities"`
+}
+
+type VehicleTxResult struct {
+	Vehicle    Vehicle           `json:"vehicle"`
+	Location   Location          `json:"location"`
+	Capacities []VehicleCapacity `json:"capacities"`
+}
+
+// CreateVehicleTx registers a vehicle together with the location its stock
+// is kept at while it is loaded
+func (store *SQLStore) CreateVehicleTx(ctx context.Context, db TxBeginner, arg CreateVehicleTxParams) (VehicleTxResult, error) {
+	var result VehicleTxResult
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		var err error
+
+		result.Location, err = store.CreateLocation(ctx, tx, CreateLocationParams{
+			Code:         VehicleLocationCodePrefix + arg.PlateNumber,
+			Name:         arg.PlateNumber,
+			LocationType: LocationTypeVehicle,
+			Region:       arg.Region,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Vehicle, err = store.CreateVehicle(ctx, tx, CreateVehicleParams{
+			PlateNumber: arg.PlateNumber,
+			LocationID:  result.Location.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Capacities, err = store.setVehicleCapacities(ctx, tx, result.Vehicle.ID, arg.Capacities)
+		return err
+	})
+
+	return result, err
+}
+
+func (store *SQLStore) setVehicleCapacities(ctx context.Context, db DBTX, vehicleID int32, capacities []VehicleCapacityParams) ([]VehicleCapacity, error) {
+	result := make([]VehicleCapacity, 0, len(capacities))
+	for _, capacity := range capacities {
+		row, err := store.UpsertVehicleCapacity(ctx, db, UpsertVehicleCapacityParams{
+			VehicleID: vehicleID,
+			ProductID: capacity.ProductID,
+			Capacity:  capacity.Capacity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, nil
+}
+
+// SetVehicleCapacitiesTx updates the capacity of the vehicle for every given
+// product, products left out keep their capacity
+func (store *SQLStore) SetVehicleCapacitiesTx(ctx context.Context, db TxBeginner, vehicleID int32, capacities []VehicleCapacityParams) ([]VehicleCapacity, error) {
+	var result []VehicleCapacity
+
+	err := store.execTx(ctx, db, func(tx pgx.Tx) error {
+		_, err := store.setVehicleCapacities(ctx, tx, vehicleID, capacities)
+		if err != nil {
+			return err
+		}
+
+		result, err = store.ListVehicleCapacities(ctx, tx, vehicleID)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,98 @@
+package document
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDeliveryNote prints the surat jalan of a delivery, every stop with
+// what is to be delivered and collected there, and room for signatures
+func (r *Renderer) WriteDeliveryNote(w io.Writer, note DeliveryNote) error {
+	layout := r.layouts.DeliveryNote
+	p := r.newPage(layout)
+
+	r.letterhead(p)
+	p.title(layout.Title, note.Number)
+
+	err := r.header(p, Party{Name: note.Source}, "Dikirim dari", [][2]string{
+		{"Tanggal", r.date(note.Date)},
+		{"Kendaraan", note.Vehicle},
+		{"Sopir", note.Driver},
+	}, note.Link)
+	if err != nil {
+		return err
+	}
+
+	columns := p.columns([]column{
+		{"No", 6, "C"},
+		{"Produk", 38, "L"},
+		{"Jumlah", 12, "R"},
+		{"Ambil kosong", 16, "R"},
+		{"Diterima", 12, "R"},
+		{"Kosong kembali", 16, "R"},
+	})
+	for _, stop := range note.Stops {
+		p.SetFont("Helvetica", "B", 10)
+		p.cell(p.width, 6, fmt.Sprintf("%d. %s", stop.Sequence, stop.Customer.Name), "", 1, "L", false)
+		if len(stop.Customer.Lines) > 0 {
+			p.SetFont("Helvetica", "", 9)
+			p.multiCell(p.width, 4.5, strings.Join(stop.Customer.Lines, ", "), "L")
+		}
+		p.Ln(1)
+
+		p.tableHeader(columns)
+		for i, item := range stop.Items {
+			// what was delivered is written in by hand until the stop is done
+			delivered, collected := "", ""
+			if stop.Closed {
+				delivered = r.number(int64(item.DeliveredQty))
+				collected = r.number(int64(item.EmptiesCollected))
+			}
+
+			p.tableRow(columns, []string{
+				fmt.Sprint(i + 1),
+				item.Description,
+				r.number(int64(item.Quantity)),
+				r.number(int64(item.EmptiesToCollect)),
+				delivered,
+				collected,
+			})
+		}
+		p.Ln(4)
+	}
+
+	if note.Note != "" {
+		p.SetFont("Helvetica", "", 9)
+		p.multiCell(p.width, 4.5, "Catatan : "+note.Note, "L")
+		p.Ln(4)
+	}
+
+	r.signatures(p, []string{"Dibuat oleh", "Sopir", "Penerima"})
+	return p.Output(w)
+}
+
+// signatures prints a box to sign in for each party, kept together on one
+// page
+func (r *Renderer) signatures(p *pdf, parties []string) {
+	const height = 30
+
+	_, pageHeight := p.GetPageSize()
+	_, _, _, bottom := p.GetMargins()
+	if p.GetY()+height > pageHeight-bottom {
+		p.AddPage()
+	}
+
+	left, _, _, _ := p.GetMargins()
+	width := p.width / float64(len(parties))
+	top := p.GetY()
+
+	p.SetFont("Helvetica", "", 9)
+	for i, party := range parties {
+		x := left + float64(i)*width
+		p.SetXY(x, top)
+		p.cell(width, 5, party, "", 0, "C", false)
+		p.Line(x+8, top+height-5, x+width-8, top+height-5)
+	}
+	p.SetY(top + height)
+}
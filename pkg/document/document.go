@@ -0,0 +1,343 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	qrcode "github.com/skip2/go-qrcode"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// ContentType is the media type of the documents
+const ContentType = "application/pdf"
+
+// Party is who a document is addressed to, its lines are printed under the
+// name
+type Party struct {
+	Name  string
+	Lines []string
+}
+
+// Line is a product sold on an invoice or a receipt. Its discount and
+// deposit are for the whole quantity, and its amount includes both.
+type Line struct {
+	Description string
+	Quantity    int32
+	UnitPrice   int64
+	Discount    int64
+	Deposit     int64
+	Amount      int64
+}
+
+// Totals are the amounts of a sale
+type Totals struct {
+	Subtotal int64
+	Discount int64
+	Tax      int64
+	Deposit  int64
+	Total    int64
+}
+
+// Dates are calendar days and are printed as they are, times are instants
+// and are printed in the renderer's time zone. Link is the address a person
+// opens the record a document was printed from at, which its QR code leads
+// back to.
+type (
+	Invoice struct {
+		Number    string
+		IssueDate time.Time
+		DueDate   time.Time
+		Status    string
+		Customer  Party
+		Lines     []Line
+		Totals
+		Paid int64
+		Link string
+	}
+
+	DeliveryNote struct {
+		Number  string
+		Date    time.Time
+		Source  string
+		Vehicle string
+		Driver  string
+		Note    string
+		Stops   []DeliveryStop
+		Link    string
+	}
+
+	// DeliveryStop is a customer on the route of a delivery, what was
+	// delivered is only printed once the stop is closed
+	DeliveryStop struct {
+		Sequence int32
+		Customer Party
+		Items    []DeliveryItem
+		Closed   bool
+	}
+
+	DeliveryItem struct {
+		Description      string
+		Quantity         int32
+		EmptiesToCollect int32
+		DeliveredQty     int32
+		EmptiesCollected int32
+	}
+
+	Receipt struct {
+		Number   string
+		Time     time.Time
+		Location string
+		Cashier  string
+		Customer string
+		Lines    []Line
+		Totals
+		PaymentMethod string
+		Voided        bool
+		Link          string
+	}
+)
+
+// Renderer prints documents with the company's layouts, in Indonesian
+type Renderer struct {
+	layouts  Layouts
+	location *time.Location
+	printer  *message.Printer
+}
+
+func NewRenderer(layouts Layouts, location *time.Location) *Renderer {
+	return &Renderer{
+		layouts:  layouts,
+		location: location,
+		printer:  message.NewPrinter(language.Indonesian),
+	}
+}
+
+func (r *Renderer) number(n int64) string {
+	return r.printer.Sprint(number.Decimal(n))
+}
+
+func (r *Renderer) money(n int64) string {
+	return "Rp " + r.number(n)
+}
+
+func (r *Renderer) date(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("02/01/2006")
+}
+
+func (r *Renderer) time(t time.Time) string {
+	return t.In(r.location).Format("02/01/2006 15:04")
+}
+
+// pdf is a document being printed. Text is translated from UTF-8 into the
+// encoding of the core fonts.
+type pdf struct {
+	*fpdf.Fpdf
+	tr    func(string) string
+	width float64
+}
+
+func newPDF(init *fpdf.InitType, margin float64) *pdf {
+	p := &pdf{Fpdf: fpdf.NewCustom(init)}
+	p.tr = p.UnicodeTranslatorFromDescriptor("")
+	p.SetMargins(margin, margin, margin)
+
+	pageWidth, _ := p.GetPageSize()
+	p.width = pageWidth - 2*margin
+	return p
+}
+
+// newPage starts a document on a page size, with its footer and page
+// numbers at the bottom of every page
+func (r *Renderer) newPage(layout Layout) *pdf {
+	p := newPDF(&fpdf.InitType{UnitStr: "mm", SizeStr: layout.Paper}, 15)
+	p.SetAutoPageBreak(true, 20)
+	p.AliasNbPages("")
+	p.SetFooterFunc(func() {
+		p.SetY(-15)
+		p.SetFont("Helvetica", "", 8)
+		p.SetTextColor(100, 100, 100)
+		if layout.Footer != "" {
+			p.cell(p.width, 4, layout.Footer, "", 1, "C", false)
+		}
+		p.cell(p.width, 4, fmt.Sprintf("Halaman %d/{nb}", p.PageNo()), "", 0, "C", false)
+		p.SetTextColor(0, 0, 0)
+	})
+	p.AddPage()
+	return p
+}
+
+func (p *pdf) cell(w, h float64, text, border string, ln int, align string, fill bool) {
+	p.CellFormat(w, h, p.tr(text), border, ln, align, fill, 0, "")
+}
+
+func (p *pdf) multiCell(w, h float64, text, align string) {
+	p.MultiCell(w, h, p.tr(text), "", align, false)
+}
+
+// letterhead prints the company's logo, name and lines across the top of the
+// first page and rules them off
+func (r *Renderer) letterhead(p *pdf) {
+	head := r.layouts.Letterhead
+	left, top, _, _ := p.GetMargins()
+
+	x, bottom := left, top
+	if head.logo != nil {
+		options := fpdf.ImageOptions{ImageType: head.logoType}
+		info := p.RegisterImageOptionsReader("logo", options, bytes.NewReader(head.logo))
+		if info != nil {
+			const height = 18
+			width := height * info.Width() / info.Height()
+			p.ImageOptions("logo", left, top, width, height, false, options, 0, "")
+			x, bottom = left+width+4, top+height
+		}
+	}
+
+	p.SetXY(x, top)
+	p.SetFont("Helvetica", "B", 14)
+	p.cell(0, 7, head.Name, "", 1, "L", false)
+	p.SetFont("Helvetica", "", 9)
+	for _, line := range head.Lines {
+		p.SetX(x)
+		p.cell(0, 4.5, line, "", 1, "L", false)
+	}
+
+	y := max(p.GetY(), bottom) + 2
+	p.SetLineWidth(0.5)
+	p.Line(left, y, left+p.width, y)
+	p.SetLineWidth(0.2)
+	p.SetY(y + 4)
+}
+
+// title prints the title of a document with its number on the right
+func (p *pdf) title(title, number string) {
+	p.SetFont("Helvetica", "B", 16)
+	p.cell(p.width/2, 8, title, "", 0, "L", false)
+	p.SetFont("Helvetica", "", 11)
+	p.cell(p.width/2, 8, number, "", 1, "R", false)
+	p.Ln(2)
+}
+
+// qrSize is the side of a QR code on a page, in millimetres
+const qrSize = 28
+
+// qrCode prints a QR code of a link, which can also be clicked on screen
+func (p *pdf) qrCode(link string, x, y, size float64) error {
+	png, err := qrcode.Encode(link, qrcode.Medium, 256)
+	if err != nil {
+		return err
+	}
+
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	p.RegisterImageOptionsReader("qr", options, bytes.NewReader(png))
+	p.ImageOptions("qr", x, y, size, size, false, options, 0, link)
+	return nil
+}
+
+// header prints who a document is for on the left, its details in the
+// middle and the QR code of its record on the right
+func (r *Renderer) header(p *pdf, party Party, partyLabel string, details [][2]string, link string) error {
+	left, _, _, _ := p.GetMargins()
+	top := p.GetY()
+	partyWidth := (p.width - qrSize) / 2
+	labelWidth := (p.width - qrSize - partyWidth) * 0.4
+	valueWidth := p.width - qrSize - partyWidth - labelWidth
+
+	p.SetFont("Helvetica", "", 9)
+	p.cell(partyWidth, 5, partyLabel, "", 2, "L", false)
+	p.SetFont("Helvetica", "B", 10)
+	p.multiCell(partyWidth, 5, party.Name, "L")
+	p.SetFont("Helvetica", "", 9)
+	for _, line := range party.Lines {
+		p.multiCell(partyWidth, 4.5, line, "L")
+	}
+	bottom := p.GetY()
+
+	p.SetXY(left+partyWidth, top)
+	for _, detail := range details {
+		p.SetX(left + partyWidth)
+		p.SetFont("Helvetica", "", 9)
+		p.cell(labelWidth, 5, detail[0], "", 0, "L", false)
+		p.SetFont("Helvetica", "B", 9)
+		p.cell(valueWidth, 5, detail[1], "", 1, "L", false)
+	}
+	bottom = max(bottom, p.GetY())
+
+	if err := p.qrCode(link, left+p.width-qrSize, top, qrSize); err != nil {
+		return err
+	}
+
+	p.SetY(max(bottom, top+qrSize) + 5)
+	return nil
+}
+
+// column of a table, its width and the alignment of its cells
+type column struct {
+	title string
+	width float64
+	align string
+}
+
+// columns turns the widths of columns given in percent of the page into
+// millimetres, so a table fits any paper size
+func (p *pdf) columns(columns []column) []column {
+	for i := range columns {
+		columns[i].width = p.width * columns[i].width / 100
+	}
+	return columns
+}
+
+// tableHeader prints the titles of the columns of a table on a grey band
+func (p *pdf) tableHeader(columns []column) {
+	p.SetFont("Helvetica", "B", 9)
+	p.SetFillColor(230, 230, 230)
+	for _, c := range columns {
+		p.cell(c.width, 7, c.title, "1", 0, "C", true)
+	}
+	p.Ln(-1)
+	p.SetFont("Helvetica", "", 9)
+}
+
+// tableRow prints a row of a table, the header is repeated on top of a new
+// page
+func (p *pdf) tableRow(columns []column, values []string) {
+	_, pageHeight := p.GetPageSize()
+	_, _, _, bottom := p.GetMargins()
+	if p.GetY()+6 > pageHeight-bottom {
+		p.AddPage()
+		p.tableHeader(columns)
+	}
+
+	for i, c := range columns {
+		p.cell(c.width, 6, values[i], "1", 0, c.align, false)
+	}
+	p.Ln(-1)
+}
+
+// amount is a labelled amount under a table
+type amount struct {
+	label string
+	value string
+	bold  bool
+}
+
+// amounts prints labelled amounts on the right of the page
+func (p *pdf) amounts(amounts []amount) {
+	left, _, _, _ := p.GetMargins()
+	for _, a := range amounts {
+		if a.bold {
+			p.SetFont("Helvetica", "B", 10)
+		} else {
+			p.SetFont("Helvetica", "", 9)
+		}
+		p.SetX(left + p.width/2)
+		p.cell(p.width/4, 6, a.label, "", 0, "L", false)
+		p.cell(p.width/4, 6, a.value, "", 1, "R", false)
+	}
+}
@@ -0,0 +1,128 @@
+package document
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// customer has a name and an address outside Latin-1, which the core fonts
+// cannot print as they are
+var customer = Party{
+	Name:  "Toko Ümit – Sumber Gas",
+	Lines: []string{"Jl. Merdeka 1, Bandung", "东京 branch"},
+}
+
+var lines = []Line{
+	{Description: "LPG 3 kg", Quantity: 10, UnitPrice: 18000, Amount: 180000},
+	{Description: "LPG 12 kg – refill", Quantity: 2, UnitPrice: 190000, Discount: 10000, Deposit: 300000, Amount: 670000},
+}
+
+var totals = Totals{Subtotal: 560000, Discount: 10000, Tax: 55000, Deposit: 300000, Total: 905000}
+
+func TestRender(t *testing.T) {
+	renderer := NewRenderer(DefaultLayouts(), time.FixedZone("WIB", 7*60*60))
+	at := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		write func(w io.Writer) error
+	}{
+		{"invoice", func(w io.Writer) error {
+			return renderer.WriteInvoice(w, Invoice{
+				Number:    "INV-00042",
+				IssueDate: at,
+				DueDate:   at.AddDate(0, 0, 30),
+				Status:    "partially_paid",
+				Customer:  customer,
+				Lines:     lines,
+				Totals:    totals,
+				Paid:      400000,
+				Link:      "https://app.example.com/invoices/42",
+			})
+		}},
+		{"delivery note", func(w io.Writer) error {
+			return renderer.WriteDeliveryNote(w, DeliveryNote{
+				Number:  "DO-00007",
+				Date:    at,
+				Source:  "Depot Bandung",
+				Vehicle: "D 1234 AB",
+				Driver:  "Dédé",
+				Note:    "Hati-hati – jalan rusak",
+				Stops: []DeliveryStop{
+					{Sequence: 1, Customer: customer, Items: []DeliveryItem{
+						{Description: "LPG 3 kg", Quantity: 10, EmptiesToCollect: 10, DeliveredQty: 9, EmptiesCollected: 9},
+					}, Closed: true},
+					{Sequence: 2, Customer: Party{Name: "Warung Bu Sri"}, Items: []DeliveryItem{
+						{Description: "LPG 12 kg", Quantity: 2, EmptiesToCollect: 2},
+					}},
+				},
+				Link: "https://app.example.com/deliveries/7",
+			})
+		}},
+		{"receipt", func(w io.Writer) error {
+			return renderer.WriteReceipt(w, Receipt{
+				Number:        "SALE-00101",
+				Time:          at,
+				Location:      "Pangkalan Cihampelas",
+				Cashier:       "kasir@example.com",
+				Customer:      customer.Name,
+				Lines:         lines,
+				Totals:        totals,
+				PaymentMethod: "qris",
+				Voided:        true,
+				Link:          "https://app.example.com/sales/101",
+			})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := tt.write(&buffer); err != nil {
+				t.Fatalf("rendering failed: %v", err)
+			}
+
+			if !bytes.HasPrefix(buffer.Bytes(), []byte("%PDF-")) {
+				t.Errorf("document starts with %q, want a PDF header", buffer.Bytes()[:min(buffer.Len(), 8)])
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"ascii", "LPG 3 kg", "LPG 3 kg"},
+		{"latin-1", "Dédé Ümit", "D\xe9d\xe9 \xdcmit"},
+		{"windows-1252 dash", "12 kg – refill", "12 kg \x96 refill"},
+		{"outside the font", "东京 branch", ".. branch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPDF(&fpdf.InitType{UnitStr: "mm", SizeStr: "A4"}, 15)
+			p.SetCompression(false)
+			p.AddPage()
+			p.SetFont("Helvetica", "", 10)
+			p.cell(p.width, 5, tt.text, "", 1, "L", false)
+			p.multiCell(p.width, 5, tt.text, "L")
+
+			var buffer bytes.Buffer
+			if err := p.Output(&buffer); err != nil {
+				t.Fatalf("Output() error = %v", err)
+			}
+
+			// both the cell and the multi-line cell print the translated text
+			if got := bytes.Count(buffer.Bytes(), []byte("("+tt.want+")")); got != 2 {
+				t.Errorf("found %q printed %d times, want 2", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package document
+
+import (
+	"fmt"
+	"io"
+)
+
+// invoiceStatuses are the statuses of an invoice as printed
+var invoiceStatuses = map[string]string{
+	"open":           "Belum dibayar",
+	"partially_paid": "Dibayar sebagian",
+	"paid":           "Lunas",
+	"voided":         "Dibatalkan",
+}
+
+// WriteInvoice prints an invoice on the invoice layout
+func (r *Renderer) WriteInvoice(w io.Writer, invoice Invoice) error {
+	layout := r.layouts.Invoice
+	p := r.newPage(layout)
+
+	r.letterhead(p)
+	p.title(layout.Title, invoice.Number)
+
+	status, ok := invoiceStatuses[invoice.Status]
+	if !ok {
+		status = invoice.Status
+	}
+
+	err := r.header(p, invoice.Customer, "Kepada", [][2]string{
+		{"Tanggal", r.date(invoice.IssueDate)},
+		{"Jatuh tempo", r.date(invoice.DueDate)},
+		{"Status", status},
+	}, invoice.Link)
+	if err != nil {
+		return err
+	}
+
+	columns := p.columns([]column{
+		{"No", 5, "C"},
+		{"Produk", 27, "L"},
+		{"Jumlah", 10, "R"},
+		{"Harga", 15, "R"},
+		{"Diskon", 13, "R"},
+		{"Deposit", 15, "R"},
+		{"Total", 15, "R"},
+	})
+	p.tableHeader(columns)
+	for i, line := range invoice.Lines {
+		p.tableRow(columns, []string{
+			fmt.Sprint(i + 1),
+			line.Description,
+			r.number(int64(line.Quantity)),
+			r.number(line.UnitPrice),
+			r.number(line.Discount),
+			r.number(line.Deposit),
+			r.number(line.Amount),
+		})
+	}
+	p.Ln(3)
+
+	p.amounts([]amount{
+		{"Subtotal", r.money(invoice.Subtotal), false},
+		{"Diskon", r.money(-invoice.Discount), false},
+		{"Pajak", r.money(invoice.Tax), false},
+		{"Deposit tabung", r.money(invoice.Deposit), false},
+		{"Total", r.money(invoice.Total), true},
+		{"Dibayar", r.money(invoice.Paid), false},
+		{"Sisa tagihan", r.money(invoice.Total - invoice.Paid), true},
+	})
+
+	return p.Output(w)
+}
@@ -0,0 +1,112 @@
+package document
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+var (
+	ErrUnknownPaper = errors.New("unknown paper size")
+	ErrLogoType     = errors.New("the letterhead logo must be a png or jpeg image")
+)
+
+// papers are the paper sizes invoices and delivery notes can be printed on
+var papers = map[string]bool{"A4": true, "A5": true, "Letter": true, "Legal": true}
+
+// Letterhead heads every document with the company's name, the lines under
+// it, such as its address, phone number and NPWP, and its logo
+type Letterhead struct {
+	Name  string   `json:"name"`
+	Lines []string `json:"lines"`
+	// Logo is the path of a png or jpeg image, it is left out of receipts
+	Logo string `json:"logo"`
+
+	logo     []byte
+	logoType string
+}
+
+// Layout is how one kind of document is printed. Paper is a page size for
+// invoices and delivery notes, while receipts are printed on a roll of the
+// given width in millimetres.
+type Layout struct {
+	Title  string  `json:"title"`
+	Paper  string  `json:"paper"`
+	Width  float64 `json:"width"`
+	Footer string  `json:"footer"`
+}
+
+// Layouts are the letterhead and the layout of every kind of document
+type Layouts struct {
+	Letterhead   Letterhead `json:"letterhead"`
+	Invoice      Layout     `json:"invoice"`
+	DeliveryNote Layout     `json:"delivery_note"`
+	Receipt      Layout     `json:"receipt"`
+}
+
+// DefaultLayouts are used for whatever a layout file leaves out
+func DefaultLayouts() Layouts {
+	return Layouts{
+		Invoice: Layout{
+			Title: "FAKTUR",
+			Paper: "A4",
+		},
+		DeliveryNote: Layout{
+			Title: "SURAT JALAN",
+			Paper: "A4",
+		},
+		Receipt: Layout{
+			Title:  "STRUK PENJUALAN",
+			Width:  80,
+			Footer: "Terima kasih",
+		},
+	}
+}
+
+// LoadLayouts reads the layouts from a JSON file over the defaults, along
+// with the logo of the letterhead. Without a file the defaults are used.
+func LoadLayouts(path string) (Layouts, error) {
+	layouts := DefaultLayouts()
+	if path == "" {
+		return layouts, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return layouts, err
+	}
+
+	if err := json.Unmarshal(content, &layouts); err != nil {
+		return layouts, fmt.Errorf("cannot read %s : %w", path, err)
+	}
+
+	for _, layout := range []Layout{layouts.Invoice, layouts.DeliveryNote} {
+		if !papers[layout.Paper] {
+			return layouts, fmt.Errorf("%w : %s", ErrUnknownPaper, layout.Paper)
+		}
+	}
+	if layouts.Receipt.Width < 40 {
+		return layouts, fmt.Errorf("%w : a receipt roll of %gmm", ErrUnknownPaper, layouts.Receipt.Width)
+	}
+
+	if layouts.Letterhead.Logo != "" {
+		logo, err := os.ReadFile(layouts.Letterhead.Logo)
+		if err != nil {
+			return layouts, err
+		}
+
+		switch http.DetectContentType(logo) {
+		case "image/png":
+			layouts.Letterhead.logoType = "PNG"
+		case "image/jpeg":
+			layouts.Letterhead.logoType = "JPG"
+		default:
+			return layouts, ErrLogoType
+		}
+		layouts.Letterhead.logo = logo
+	}
+
+	return layouts, nil
+}
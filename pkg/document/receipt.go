@@ -0,0 +1,147 @@
+package document
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// receiptMargin is the margin around a receipt, in millimetres
+const receiptMargin = 4
+
+// paymentMethods are the payment methods of a sale as printed
+var paymentMethods = map[string]string{
+	"cash":     "Tunai",
+	"transfer": "Transfer",
+	"qris":     "QRIS",
+	"credit":   "Kredit",
+}
+
+// WriteReceipt prints the receipt of a sale on the roll of the receipt
+// layout. The roll is cut after the receipt, so the page is as long as what
+// is printed on it, which is measured on a first pass.
+func (r *Renderer) WriteReceipt(w io.Writer, receipt Receipt) error {
+	p, err := r.printReceipt(receipt, 1000)
+	if err != nil {
+		return err
+	}
+
+	p, err = r.printReceipt(receipt, p.GetY()+receiptMargin)
+	if err != nil {
+		return err
+	}
+
+	return p.Output(w)
+}
+
+func (r *Renderer) printReceipt(receipt Receipt, height float64) (*pdf, error) {
+	layout := r.layouts.Receipt
+	p := newPDF(&fpdf.InitType{
+		UnitStr: "mm",
+		Size:    fpdf.SizeType{Wd: layout.Width, Ht: height},
+	}, receiptMargin)
+	p.SetAutoPageBreak(false, 0)
+	p.AddPage()
+
+	// the logo is left out, receipt printers print it poorly
+	head := r.layouts.Letterhead
+	p.SetFont("Helvetica", "B", 10)
+	p.multiCell(p.width, 5, head.Name, "C")
+	p.SetFont("Helvetica", "", 7)
+	for _, line := range head.Lines {
+		p.multiCell(p.width, 3.5, line, "C")
+	}
+	p.Ln(2)
+
+	if layout.Title != "" {
+		p.SetFont("Helvetica", "B", 9)
+		p.multiCell(p.width, 4.5, layout.Title, "C")
+		p.Ln(1)
+	}
+
+	p.SetFont("Helvetica", "", 7)
+	details := [][2]string{
+		{"No", receipt.Number},
+		{"Waktu", r.time(receipt.Time)},
+		{"Outlet", receipt.Location},
+		{"Kasir", receipt.Cashier},
+	}
+	if receipt.Customer != "" {
+		details = append(details, [2]string{"Pelanggan", receipt.Customer})
+	}
+	for _, detail := range details {
+		p.cell(15, 3.5, detail[0], "", 0, "L", false)
+		p.cell(p.width-15, 3.5, detail[1], "", 1, "L", false)
+	}
+	p.rule()
+
+	for _, line := range receipt.Lines {
+		p.multiCell(p.width, 3.5, line.Description, "L")
+		p.spread(fmt.Sprintf("  %s x %s", r.number(int64(line.Quantity)), r.number(line.UnitPrice)),
+			r.number(int64(line.Quantity)*line.UnitPrice))
+		if line.Discount != 0 {
+			p.spread("  Diskon", r.number(-line.Discount))
+		}
+		if line.Deposit != 0 {
+			p.spread("  Deposit tabung", r.number(line.Deposit))
+		}
+	}
+	p.rule()
+
+	p.spread("Subtotal", r.number(receipt.Subtotal))
+	if receipt.Discount != 0 {
+		p.spread("Diskon", r.number(-receipt.Discount))
+	}
+	if receipt.Tax != 0 {
+		p.spread("Pajak", r.number(receipt.Tax))
+	}
+	if receipt.Deposit != 0 {
+		p.spread("Deposit tabung", r.number(receipt.Deposit))
+	}
+	p.SetFont("Helvetica", "B", 9)
+	p.spread("TOTAL", r.money(receipt.Total))
+	p.SetFont("Helvetica", "", 7)
+
+	method, ok := paymentMethods[receipt.PaymentMethod]
+	if !ok {
+		method = receipt.PaymentMethod
+	}
+	p.spread("Pembayaran", method)
+
+	if receipt.Voided {
+		p.Ln(2)
+		p.SetFont("Helvetica", "B", 12)
+		p.multiCell(p.width, 6, "DIBATALKAN", "C")
+		p.SetFont("Helvetica", "", 7)
+	}
+
+	p.Ln(3)
+	size := min(28, p.width*0.6)
+	if err := p.qrCode(receipt.Link, receiptMargin+(p.width-size)/2, p.GetY(), size); err != nil {
+		return nil, err
+	}
+	p.SetY(p.GetY() + size + 2)
+
+	if layout.Footer != "" {
+		p.multiCell(p.width, 3.5, layout.Footer, "C")
+	}
+
+	return p, p.Error()
+}
+
+// spread prints a label on the left of the roll and a value on its right
+func (p *pdf) spread(label, value string) {
+	p.cell(p.width/2, 3.5, label, "", 0, "L", false)
+	p.cell(p.width/2, 3.5, value, "", 1, "R", false)
+}
+
+// rule prints a dashed line across the roll
+func (p *pdf) rule() {
+	left, _, _, _ := p.GetMargins()
+	y := p.GetY() + 1
+	p.SetDashPattern([]float64{1, 1}, 0)
+	p.Line(left, y, left+p.width, y)
+	p.SetDashPattern([]float64{}, 0)
+	p.SetY(y + 1)
+}
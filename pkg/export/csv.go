@@ -0,0 +1,42 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	writer  *csv.Writer
+	locale  Locale
+	columns []string
+	record  []string
+}
+
+func newCSVWriter(w io.Writer, locale Locale) *csvWriter {
+	writer := csv.NewWriter(w)
+	writer.Comma = locale.separator
+	return &csvWriter{
+		writer: writer,
+		locale: locale,
+	}
+}
+
+func (w *csvWriter) WriteHeader(columns []string) error {
+	w.columns = columns
+	w.record = make([]string, len(columns))
+	return w.writer.Write(columns)
+}
+
+// WriteRow formats the row as text, csv.Writer flushes to the underlying
+// writer as its buffer fills
+func (w *csvWriter) WriteRow(values []any) error {
+	for i, value := range values {
+		w.record[i] = w.locale.format(w.columns[i], normalize(value))
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,101 @@
+// Package export writes tables as CSV or XLSX spreadsheets one row at a time,
+// with numbers and dates formatted for the reader's language
+package export
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ContentType is the media type of a format, empty for an unknown one
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Date is a calendar date, told apart from a point in time so that it is
+// written without a time of day
+type Date time.Time
+
+// Writer writes a table, its header first and then the rows
+type Writer interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []any) error
+	// Close writes out whatever is still buffered
+	Close() error
+}
+
+// NewWriter creates a writer of the format writing to w
+func NewWriter(format string, w io.Writer, locale Locale) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, locale), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, locale)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// isID tells the columns holding identifiers, which are written as they are
+// rather than as quantities
+func isID(column string) bool {
+	return column == "id" || strings.HasSuffix(column, "_id")
+}
+
+// normalize turns a value read from the database into nil, a string, a bool,
+// an int64, a float64, a time.Time or a Date
+func normalize(value any) any {
+	switch v := value.(type) {
+	case nil, string, bool, int64, float64, time.Time, Date:
+		return v
+	case int:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case []byte:
+		return string(v)
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+	case pgtype.Date:
+		if !v.Valid {
+			return nil
+		}
+		return Date(v.Time)
+	case pgtype.Numeric:
+		f, err := v.Float64Value()
+		if err != nil || !f.Valid {
+			return nil
+		}
+		return f.Float64
+	case driver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return nil
+		}
+		return normalize(value)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
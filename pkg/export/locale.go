@@ -0,0 +1,88 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Languages are the languages exports are formatted for, the first one is
+// the default
+var Languages = []string{"id", "en"}
+
+// Locale formats numbers and dates the way the reader of an export expects.
+// Times are shown in the locale's time zone.
+type Locale struct {
+	printer  *message.Printer
+	location *time.Location
+	// separator splits the fields of a CSV, a semicolon where the comma is
+	// the decimal separator
+	separator  rune
+	dateLayout string
+	timeLayout string
+	// dateFormat and timeFormat are the spreadsheet number formats of dates
+	// and times
+	dateFormat string
+	timeFormat string
+}
+
+// NewLocale creates the locale of a language, falling back to the default
+// language for one exports are not formatted for
+func NewLocale(lang string, location *time.Location) Locale {
+	switch lang {
+	case "en":
+		return Locale{
+			printer:    message.NewPrinter(language.English),
+			location:   location,
+			separator:  ',',
+			dateLayout: "01/02/2006",
+			timeLayout: "01/02/2006 15:04",
+			dateFormat: "mm/dd/yyyy",
+			timeFormat: "mm/dd/yyyy hh:mm",
+		}
+	default:
+		return Locale{
+			printer:    message.NewPrinter(language.Indonesian),
+			location:   location,
+			separator:  ';',
+			dateLayout: "02/01/2006",
+			timeLayout: "02/01/2006 15:04",
+			dateFormat: "dd/mm/yyyy",
+			timeFormat: "dd/mm/yyyy hh:mm",
+		}
+	}
+}
+
+// wallClock moves a time into the locale's time zone and returns its wall
+// clock as UTC, which is how spreadsheets take times
+func (locale Locale) wallClock(t time.Time) time.Time {
+	t = t.In(locale.location)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// format writes a normalized value of a column as text
+func (locale Locale) format(column string, value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		if isID(column) {
+			return strconv.FormatInt(v, 10)
+		}
+		return locale.printer.Sprint(number.Decimal(v))
+	case float64:
+		return locale.printer.Sprint(number.Decimal(v, number.MaxFractionDigits(2)))
+	case Date:
+		return time.Time(v).Format(locale.dateLayout)
+	case time.Time:
+		return v.In(locale.location).Format(locale.timeLayout)
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrNotAList = errors.New("only a list of records can be exported")
+
+// WriteRows writes the rows of a query as they are read, headed by the names
+// of its columns, and closes them
+func WriteRows(w Writer, rows pgx.Rows) error {
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.Name
+	}
+
+	if err := w.WriteHeader(columns); err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return err
+		}
+
+		for i, field := range fields {
+			if t, ok := values[i].(time.Time); ok && field.DataTypeOID == pgtype.DateOID {
+				values[i] = Date(t)
+			}
+		}
+
+		if err := w.WriteRow(values); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// WriteSlice writes a slice of structs already in memory, each field a column
+// named after its json tag. Embedded structs add their own fields.
+func WriteSlice(w Writer, list any) error {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return ErrNotAList
+	}
+
+	record := value.Type().Elem()
+	if record.Kind() != reflect.Struct {
+		return ErrNotAList
+	}
+
+	var columns []string
+	var paths [][]int
+	structFields(record, nil, &columns, &paths)
+	if err := w.WriteHeader(columns); err != nil {
+		return err
+	}
+
+	values := make([]any, len(paths))
+	for i := 0; i < value.Len(); i++ {
+		item := value.Index(i)
+		for j, path := range paths {
+			values[j] = item.FieldByIndex(path).Interface()
+		}
+
+		if err := w.WriteRow(values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// structFields lists the exported fields of a struct by the name of their
+// json tag, going into embedded structs
+func structFields(record reflect.Type, index []int, columns *[]string, paths *[][]int) {
+	for i := 0; i < record.NumField(); i++ {
+		field := record.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := append(append([]int{}, index...), i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			structFields(field.Type, path, columns, paths)
+			continue
+		}
+
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		*columns = append(*columns, name)
+		*paths = append(*paths, path)
+	}
+}
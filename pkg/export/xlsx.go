@@ -0,0 +1,120 @@
+package export
+
+import (
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxSheet = "Sheet1"
+
+// Number formats built into spreadsheets
+const (
+	xlsxIntegerFormat = 3 // #,##0
+	xlsxDecimalFormat = 4 // #,##0.00
+)
+
+// xlsxWriter writes through a stream writer, which keeps the rows on disk
+// rather than in memory once there are many of them. Numbers and dates are
+// written as such, so the spreadsheet shows them in the reader's own format.
+type xlsxWriter struct {
+	w       io.Writer
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	locale  Locale
+	columns []string
+	row     int
+	cells   []any
+
+	headerStyle  int
+	integerStyle int
+	decimalStyle int
+	dateStyle    int
+	timeStyle    int
+}
+
+func newXLSXWriter(w io.Writer, locale Locale) (*xlsxWriter, error) {
+	writer := &xlsxWriter{
+		w:      w,
+		file:   excelize.NewFile(),
+		locale: locale,
+	}
+
+	styles := []struct {
+		id    *int
+		style excelize.Style
+	}{
+		{&writer.headerStyle, excelize.Style{Font: &excelize.Font{Bold: true}}},
+		{&writer.integerStyle, excelize.Style{NumFmt: xlsxIntegerFormat}},
+		{&writer.decimalStyle, excelize.Style{NumFmt: xlsxDecimalFormat}},
+		{&writer.dateStyle, excelize.Style{CustomNumFmt: &locale.dateFormat}},
+		{&writer.timeStyle, excelize.Style{CustomNumFmt: &locale.timeFormat}},
+	}
+	for _, s := range styles {
+		id, err := writer.file.NewStyle(&s.style)
+		if err != nil {
+			writer.file.Close()
+			return nil, err
+		}
+		*s.id = id
+	}
+
+	var err error
+	writer.stream, err = writer.file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		writer.file.Close()
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (w *xlsxWriter) setRow(cells []any) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	return w.stream.SetRow(cell, cells)
+}
+
+func (w *xlsxWriter) WriteHeader(columns []string) error {
+	w.columns = columns
+	w.cells = make([]any, len(columns))
+	for i, column := range columns {
+		w.cells[i] = excelize.Cell{StyleID: w.headerStyle, Value: column}
+	}
+	return w.setRow(w.cells)
+}
+
+func (w *xlsxWriter) WriteRow(values []any) error {
+	for i, value := range values {
+		switch v := normalize(value).(type) {
+		case int64:
+			if isID(w.columns[i]) {
+				w.cells[i] = v
+				continue
+			}
+			w.cells[i] = excelize.Cell{StyleID: w.integerStyle, Value: v}
+		case float64:
+			w.cells[i] = excelize.Cell{StyleID: w.decimalStyle, Value: v}
+		case Date:
+			w.cells[i] = excelize.Cell{StyleID: w.dateStyle, Value: time.Time(v)}
+		case time.Time:
+			w.cells[i] = excelize.Cell{StyleID: w.timeStyle, Value: w.locale.wallClock(v)}
+		default:
+			w.cells[i] = v
+		}
+	}
+	return w.setRow(w.cells)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.w)
+}
@@ -0,0 +1,133 @@
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrNotEnoughHistory = errors.New("not enough history to forecast")
+	ErrUnknownAlgorithm = errors.New("unknown forecasting algorithm")
+)
+
+// Forecaster predicts the values that follow a series of evenly spaced
+// observations
+type Forecaster interface {
+	Forecast(history []float64, horizon int) ([]float64, error)
+}
+
+// daysPerWeek is the season of daily demand, sales follow the week
+const daysPerWeek = 7
+
+// algorithms are the forecasters that can be picked by name
+var algorithms = map[string]func() Forecaster{
+	"holt_winters": func() Forecaster {
+		return WithFallback(NewHoltWinters(daysPerWeek), NewExponentialSmoothing())
+	},
+	"exponential_smoothing": func() Forecaster {
+		return NewExponentialSmoothing()
+	},
+}
+
+// New returns the forecaster of an algorithm by its name
+func New(algorithm string) (Forecaster, error) {
+	newForecaster, ok := algorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w : %s", ErrUnknownAlgorithm, algorithm)
+	}
+	return newForecaster(), nil
+}
+
+type fallback struct {
+	primary  Forecaster
+	fallback Forecaster
+}
+
+// WithFallback uses primary and, when the history is too short for it,
+// fallback instead
+func WithFallback(primary Forecaster, fallbackForecaster Forecaster) Forecaster {
+	return fallback{primary: primary, fallback: fallbackForecaster}
+}
+
+func (f fallback) Forecast(history []float64, horizon int) ([]float64, error) {
+	values, err := f.primary.Forecast(history, horizon)
+	if errors.Is(err, ErrNotEnoughHistory) {
+		return f.fallback.Forecast(history, horizon)
+	}
+	return values, err
+}
+
+// Holiday raises demand by Multiplier on every day from Start to End
+type Holiday struct {
+	Start      time.Time
+	End        time.Time
+	Multiplier float64
+}
+
+// multiplier is the demand multiplier of a day, the largest of the holidays
+// covering it and 1 outside holidays
+func multiplier(day time.Time, holidays []Holiday) float64 {
+	factor := 1.0
+	for _, holiday := range holidays {
+		if !day.Before(holiday.Start) && !day.After(holiday.End) && holiday.Multiplier > factor {
+			factor = holiday.Multiplier
+		}
+	}
+	return factor
+}
+
+// Point is the forecast quantity of a day, or of the week starting on Date
+type Point struct {
+	Date     time.Time `json:"date"`
+	Quantity float64   `json:"quantity"`
+}
+
+// Day returns the calendar date of t as midnight UTC, the way dates are
+// read from the database
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Daily forecasts the horizon days that follow a daily series starting at
+// start. Holiday spikes are taken out of the history before fitting, so they
+// do not inflate ordinary days, and put back on the holidays ahead.
+func Daily(f Forecaster, start time.Time, history []float64, horizon int, holidays []Holiday) ([]Point, error) {
+	start = Day(start)
+
+	baseline := make([]float64, len(history))
+	for i, value := range history {
+		baseline[i] = value / multiplier(start.AddDate(0, 0, i), holidays)
+	}
+
+	values, err := f.Forecast(baseline, horizon)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]Point, 0, len(values))
+	for i, value := range values {
+		day := start.AddDate(0, 0, len(history)+i)
+		points = append(points, Point{
+			Date:     day,
+			Quantity: math.Max(0, value*multiplier(day, holidays)),
+		})
+	}
+	return points, nil
+}
+
+// Weekly sums daily points into weeks starting on Monday, the first and last
+// weeks may be partial
+func Weekly(daily []Point) []Point {
+	weeks := []Point{}
+	for _, point := range daily {
+		offset := (int(point.Date.Weekday()) + 6) % 7
+		monday := point.Date.AddDate(0, 0, -offset)
+		if len(weeks) == 0 || !weeks[len(weeks)-1].Date.Equal(monday) {
+			weeks = append(weeks, Point{Date: monday})
+		}
+		weeks[len(weeks)-1].Quantity += point.Quantity
+	}
+	return weeks
+}
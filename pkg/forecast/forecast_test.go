@@ -0,0 +1,322 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// weekly is the added demand of each day of the week, it sums to zero
+var weekly = []float64{-3, -1, 0, 1, 2, 4, -3}
+
+// series is level plus trend per day plus the season, for days from the
+// first
+func series(level, trend float64, season []float64, first, days int) []float64 {
+	values := make([]float64, days)
+	for i := range values {
+		day := first + i
+		values[i] = level + trend*float64(day)
+		if season != nil {
+			values[i] += season[day%len(season)]
+		}
+	}
+	return values
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestHoltWinters(t *testing.T) {
+	tests := []struct {
+		name      string
+		history   []float64
+		horizon   int
+		want      []float64
+		tolerance float64
+		err       error
+	}{
+		{
+			name:    "season alone is repeated exactly",
+			history: series(20, 0, weekly, 0, 4*daysPerWeek),
+			horizon: 10,
+			want:    series(20, 0, weekly, 4*daysPerWeek, 10),
+		},
+		{
+			name:      "trend and season are followed",
+			history:   series(10, 0.5, weekly, 0, 8*daysPerWeek),
+			horizon:   2 * daysPerWeek,
+			want:      series(10, 0.5, weekly, 8*daysPerWeek, 2*daysPerWeek),
+			tolerance: 1,
+		},
+		{
+			name:    "trend without a season",
+			history: series(10, 2, nil, 0, 8*daysPerWeek),
+			horizon: daysPerWeek,
+			want:    series(10, 2, nil, 8*daysPerWeek, daysPerWeek),
+			// the season is seeded from the first week, trend and all, and
+			// takes a while to flatten out
+			tolerance: 2.5,
+		},
+		{
+			name:    "exactly two seasons",
+			history: series(20, 0, weekly, 0, 2*daysPerWeek),
+			horizon: 3,
+			want:    series(20, 0, weekly, 2*daysPerWeek, 3),
+		},
+		{
+			name:    "no horizon",
+			history: series(20, 0, weekly, 0, 2*daysPerWeek),
+			want:    []float64{},
+		},
+		{
+			name:    "less than two seasons",
+			history: series(20, 0, weekly, 0, 2*daysPerWeek-1),
+			horizon: 1,
+			err:     ErrNotEnoughHistory,
+		},
+		{
+			name:    "no history",
+			horizon: 1,
+			err:     ErrNotEnoughHistory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewHoltWinters(daysPerWeek).Forecast(tt.history, tt.horizon)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Forecast() error = %v, want %v", err, tt.err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Forecast() gave %d values, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > tt.tolerance+1e-9 {
+					t.Errorf("value %d = %.3f, want %.3f", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("a season of one is refused", func(t *testing.T) {
+		_, err := NewHoltWinters(1).Forecast(series(20, 0, weekly, 0, 10), 1)
+		if !errors.Is(err, ErrNotEnoughHistory) {
+			t.Errorf("Forecast() error = %v, want %v", err, ErrNotEnoughHistory)
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		history   []float64
+		want      []float64
+		err       error
+	}{
+		{
+			name:      "holt winters",
+			algorithm: "holt_winters",
+			history:   series(20, 0, weekly, 0, 2*daysPerWeek),
+			want:      series(20, 0, weekly, 2*daysPerWeek, 2),
+		},
+		{
+			name:      "holt winters falls back on a short history",
+			algorithm: "holt_winters",
+			history:   []float64{10, 20},
+			want:      []float64{13, 13},
+		},
+		{
+			name:      "exponential smoothing",
+			algorithm: "exponential_smoothing",
+			history:   []float64{10, 20, 10},
+			want:      []float64{12.1, 12.1},
+		},
+		{
+			name:      "unknown algorithm",
+			algorithm: "arima",
+			err:       ErrUnknownAlgorithm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecaster, err := New(tt.algorithm)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("New() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := forecaster.Forecast(tt.history, len(tt.want))
+			if err != nil {
+				t.Fatalf("Forecast() error = %v", err)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("value %d = %.3f, want %.3f", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMultiplier(t *testing.T) {
+	lebaran := Holiday{Start: date(2025, 3, 28), End: date(2025, 4, 7), Multiplier: 1.8}
+	peak := Holiday{Start: date(2025, 3, 30), End: date(2025, 3, 31), Multiplier: 2.5}
+	quiet := Holiday{Start: date(2025, 4, 7), End: date(2025, 4, 10), Multiplier: 0.5}
+
+	tests := []struct {
+		name     string
+		day      time.Time
+		holidays []Holiday
+		want     float64
+	}{
+		{"no holidays", date(2025, 3, 30), nil, 1},
+		{"before the holiday", date(2025, 3, 27), []Holiday{lebaran}, 1},
+		{"first day", date(2025, 3, 28), []Holiday{lebaran}, 1.8},
+		{"last day", date(2025, 4, 7), []Holiday{lebaran}, 1.8},
+		{"after the holiday", date(2025, 4, 8), []Holiday{lebaran}, 1},
+		{"overlapping holidays take the largest", date(2025, 3, 30), []Holiday{lebaran, peak}, 2.5},
+		{"in either order", date(2025, 3, 31), []Holiday{peak, lebaran}, 2.5},
+		{"demand is never lowered", date(2025, 4, 9), []Holiday{quiet}, 1},
+		{"nor by an overlap", date(2025, 4, 7), []Holiday{lebaran, quiet}, 1.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := multiplier(tt.day, tt.holidays); got != tt.want {
+				t.Errorf("multiplier() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaily(t *testing.T) {
+	// 2025-03-03 is a Monday
+	start := date(2025, 3, 3)
+
+	tests := []struct {
+		name     string
+		history  []float64
+		holidays []Holiday
+		horizon  int
+		want     []float64
+	}{
+		{
+			name:    "no holidays",
+			history: []float64{10, 10, 10},
+			horizon: 2,
+			want:    []float64{10, 10},
+		},
+		{
+			name:     "a holiday in the history is taken out",
+			history:  []float64{10, 20, 10},
+			holidays: []Holiday{{Start: date(2025, 3, 4), End: date(2025, 3, 4), Multiplier: 2}},
+			horizon:  2,
+			want:     []float64{10, 10},
+		},
+		{
+			name:     "a holiday ahead is put back",
+			history:  []float64{10, 10, 10},
+			holidays: []Holiday{{Start: date(2025, 3, 7), End: date(2025, 3, 8), Multiplier: 1.5}},
+			horizon:  4,
+			want:     []float64{10, 15, 15, 10},
+		},
+		{
+			name:    "demand is never negative",
+			history: []float64{-4, -4},
+			horizon: 1,
+			want:    []float64{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a start later in the day is the same day
+			got, err := Daily(NewExponentialSmoothing(), start.Add(15*time.Hour), tt.history, tt.horizon, tt.holidays)
+			if err != nil {
+				t.Fatalf("Daily() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Daily() gave %d points, want %d", len(got), len(tt.want))
+			}
+			for i, point := range got {
+				if want := start.AddDate(0, 0, len(tt.history)+i); !point.Date.Equal(want) {
+					t.Errorf("point %d is on %s, want %s", i, point.Date, want)
+				}
+				if math.Abs(point.Quantity-tt.want[i]) > 1e-9 {
+					t.Errorf("point %d = %.3f, want %.3f", i, point.Quantity, tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("no history", func(t *testing.T) {
+		_, err := Daily(NewExponentialSmoothing(), start, nil, 1, nil)
+		if !errors.Is(err, ErrNotEnoughHistory) {
+			t.Errorf("Daily() error = %v, want %v", err, ErrNotEnoughHistory)
+		}
+	})
+}
+
+func TestWeekly(t *testing.T) {
+	days := func(first time.Time, quantities ...float64) []Point {
+		points := make([]Point, len(quantities))
+		for i, quantity := range quantities {
+			points[i] = Point{Date: first.AddDate(0, 0, i), Quantity: quantity}
+		}
+		return points
+	}
+
+	tests := []struct {
+		name  string
+		daily []Point
+		want  []Point
+	}{
+		{
+			name:  "no days",
+			daily: nil,
+			want:  []Point{},
+		},
+		{
+			name:  "one full week",
+			daily: days(date(2025, 3, 3), 1, 2, 3, 4, 5, 6, 7),
+			want:  []Point{{Date: date(2025, 3, 3), Quantity: 28}},
+		},
+		{
+			name:  "partial weeks at both ends",
+			daily: days(date(2025, 3, 8), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10),
+			want: []Point{
+				{Date: date(2025, 3, 3), Quantity: 3},
+				{Date: date(2025, 3, 10), Quantity: 42},
+				{Date: date(2025, 3, 17), Quantity: 10},
+			},
+		},
+		{
+			name:  "a week across the end of the month",
+			daily: days(date(2025, 3, 30), 1, 1, 1),
+			want: []Point{
+				{Date: date(2025, 3, 24), Quantity: 1},
+				{Date: date(2025, 3, 31), Quantity: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Weekly(tt.daily)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Weekly() gave %d weeks, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Date.Equal(tt.want[i].Date) || got[i].Quantity != tt.want[i].Quantity {
+					t.Errorf("week %d = %s %.0f, want %s %.0f", i, got[i].Date.Format(time.DateOnly), got[i].Quantity, tt.want[i].Date.Format(time.DateOnly), tt.want[i].Quantity)
+				}
+			}
+		})
+	}
+}
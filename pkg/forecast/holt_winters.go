@@ -0,0 +1,83 @@
+package forecast
+
+// HoltWinters is triple exponential smoothing with an additive trend and
+// season. Alpha smooths the level, Beta the trend and Gamma the season of
+// Period observations.
+type HoltWinters struct {
+	Alpha  float64
+	Beta   float64
+	Gamma  float64
+	Period int
+}
+
+func NewHoltWinters(period int) HoltWinters {
+	return HoltWinters{Alpha: 0.3, Beta: 0.05, Gamma: 0.2, Period: period}
+}
+
+// Forecast needs two full seasons of history, the first to seed the season
+// and both to seed the trend
+func (hw HoltWinters) Forecast(history []float64, horizon int) ([]float64, error) {
+	period := hw.Period
+	if period < 2 || len(history) < 2*period {
+		return nil, ErrNotEnoughHistory
+	}
+
+	first := mean(history[:period])
+	second := mean(history[period : 2*period])
+
+	level := first
+	trend := (second - first) / float64(period)
+	season := make([]float64, period)
+	for i := range season {
+		season[i] = history[i] - first
+	}
+
+	for i := period; i < len(history); i++ {
+		s := season[i%period]
+		previous := level
+		level = hw.Alpha*(history[i]-s) + (1-hw.Alpha)*(level+trend)
+		trend = hw.Beta*(level-previous) + (1-hw.Beta)*trend
+		season[i%period] = hw.Gamma*(history[i]-level) + (1-hw.Gamma)*s
+	}
+
+	values := make([]float64, horizon)
+	for h := range values {
+		values[h] = level + float64(h+1)*trend + season[(len(history)+h)%period]
+	}
+	return values, nil
+}
+
+// ExponentialSmoothing is simple exponential smoothing, it forecasts the
+// smoothed level for every step ahead
+type ExponentialSmoothing struct {
+	Alpha float64
+}
+
+func NewExponentialSmoothing() ExponentialSmoothing {
+	return ExponentialSmoothing{Alpha: 0.3}
+}
+
+func (es ExponentialSmoothing) Forecast(history []float64, horizon int) ([]float64, error) {
+	if len(history) == 0 {
+		return nil, ErrNotEnoughHistory
+	}
+
+	level := history[0]
+	for _, value := range history[1:] {
+		level = es.Alpha*value + (1-es.Alpha)*level
+	}
+
+	values := make([]float64, horizon)
+	for h := range values {
+		values[h] = level
+	}
+	return values, nil
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
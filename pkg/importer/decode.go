@@ -0,0 +1,311 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrNotARowList    = errors.New("rows must point to a slice of import rows")
+	ErrNoHeader       = errors.New("the file has no header row")
+	ErrUnknownColumn  = errors.New("unknown column")
+	ErrMissingColumn  = errors.New("missing column")
+	ErrRepeatedColumn = errors.New("repeated column")
+)
+
+// validate reports the fields of a row by their column
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("csv"), ",")
+		return name
+	})
+	return v
+}
+
+// groupedNumber is a whole number written with thousands separators, which
+// are a dot or a comma depending on the locale of the spreadsheet
+var groupedNumber = regexp.MustCompile(`^-?\d{1,3}([.,]\d{3})+$`)
+
+// normalizer is a row that tidies its values before they are validated
+type normalizer interface {
+	normalize()
+}
+
+// column is the field of a row a column of the file is read into
+type column struct {
+	name  string
+	index int
+	key   bool
+}
+
+// Decode reads a CSV file with a header row into rows, a pointer to a slice
+// of ProductRow, CustomerRow or OpeningStockRow. The columns may come in any
+// order and be separated by commas or semicolons, as spreadsheets save them
+// with either depending on their locale.
+//
+// A line that cannot be read into a row or fails validation is left out of
+// rows and reported in errs, total counts every line read. err is only set
+// when the file as a whole cannot be read.
+func Decode(r io.Reader, rows any) (total int, errs []RowError, err error) {
+	list := reflect.ValueOf(rows)
+	if list.Kind() != reflect.Pointer || list.Elem().Kind() != reflect.Slice {
+		return 0, nil, ErrNotARowList
+	}
+	list = list.Elem()
+
+	record := list.Type().Elem()
+	if record.Kind() != reflect.Struct {
+		return 0, nil, ErrNotARowList
+	}
+	lineField, ok := record.FieldByName("Line")
+	if !ok || lineField.Type.Kind() != reflect.Int {
+		return 0, nil, ErrNotARowList
+	}
+
+	reader := newCSVReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return 0, nil, ErrNoHeader
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	columns, err := mapColumns(record, header)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// the line each key value was first seen on
+	seen := make(map[string]int)
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+			total++
+			errs = append(errs, RowError{
+				Line:    parseErr.StartLine,
+				Message: fmt.Sprintf("has %d columns instead of %d", len(values), len(header)),
+			})
+			continue
+		}
+		if err != nil {
+			return total, errs, err
+		}
+		total++
+
+		line, _ := reader.FieldPos(0)
+		row := reflect.New(record).Elem()
+		row.FieldByIndex(lineField.Index).SetInt(int64(line))
+
+		rowErrs := decodeRow(row, columns, values, line)
+		if n, ok := row.Addr().Interface().(normalizer); ok {
+			n.normalize()
+		}
+		// a cell that could not be converted is not validated as well
+		for _, rowErr := range validateRow(row.Interface(), line) {
+			if !slices.ContainsFunc(rowErrs, func(e RowError) bool { return e.Column == rowErr.Column }) {
+				rowErrs = append(rowErrs, rowErr)
+			}
+		}
+		if len(rowErrs) == 0 {
+			rowErrs = checkKeys(row, columns, seen, line)
+		}
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+
+		list.Set(reflect.Append(list, row))
+	}
+
+	return total, errs, nil
+}
+
+// newCSVReader reads a file separated by semicolons when its header has
+// more of them than commas
+func newCSVReader(r io.Reader) *csv.Reader {
+	buffered := bufio.NewReader(r)
+	head, _ := buffered.Peek(4096)
+	if i := bytes.IndexByte(head, '\n'); i >= 0 {
+		head = head[:i]
+	}
+
+	reader := csv.NewReader(buffered)
+	if bytes.Count(head, []byte{';'}) > bytes.Count(head, []byte{','}) {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// mapColumns matches the header of a file to the fields of a row. A column
+// the row does not know is refused rather than ignored, as it is most likely
+// a misspelt one, and so is a file without a column that must be filled in.
+func mapColumns(record reflect.Type, header []string) ([]column, error) {
+	fields := make(map[string]column)
+	for i := 0; i < record.NumField(); i++ {
+		name, options, _ := strings.Cut(record.Field(i).Tag.Get("csv"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = column{name: name, index: i, key: options == "key"}
+	}
+
+	columns := make([]column, len(header))
+	found := make(map[string]bool)
+	for i, cell := range header {
+		// spreadsheets may start the file with a byte order mark
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cell, "\ufeff")))
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("%w : %s", ErrUnknownColumn, name)
+		}
+		if found[name] {
+			return nil, fmt.Errorf("%w : %s", ErrRepeatedColumn, name)
+		}
+		found[name] = true
+		columns[i] = field
+	}
+
+	for name, field := range fields {
+		tag := record.Field(field.index).Tag.Get("validate")
+		if (tag == "required" || strings.HasPrefix(tag, "required,")) && !found[name] {
+			return nil, fmt.Errorf("%w : %s", ErrMissingColumn, name)
+		}
+	}
+
+	return columns, nil
+}
+
+// decodeRow converts the cells of a line into the fields of a row
+func decodeRow(row reflect.Value, columns []column, values []string, line int) []RowError {
+	var errs []RowError
+	for i, column := range columns {
+		if err := setCell(row.Field(column.index), strings.TrimSpace(values[i])); err != nil {
+			errs = append(errs, RowError{Line: line, Column: column.name, Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// setCell converts a cell to the type of its field, an empty cell leaves the
+// field at its zero value
+func setCell(field reflect.Value, cell string) error {
+	if cell == "" {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Int32, reflect.Int64:
+		if groupedNumber.MatchString(cell) {
+			cell = strings.NewReplacer(".", "", ",", "").Replace(cell)
+		}
+		n, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		switch strings.ToLower(cell) {
+		case "1", "true", "yes", "ya":
+			field.SetBool(true)
+		case "0", "false", "no", "tidak":
+			field.SetBool(false)
+		default:
+			return errors.New("must be yes or no")
+		}
+	case reflect.Pointer:
+		value := reflect.New(field.Type().Elem())
+		if err := setCell(value.Elem(), cell); err != nil {
+			return err
+		}
+		field.Set(value)
+	default:
+		return fmt.Errorf("cannot be read into %s", field.Type())
+	}
+
+	return nil
+}
+
+// validateRow checks a row against the rules of its validate tags
+func validateRow(row any, line int) []RowError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(validate.Struct(row), &validationErrs) {
+		return nil
+	}
+
+	errs := make([]RowError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		errs = append(errs, RowError{Line: line, Column: fieldErr.Field(), Message: validationMessage(fieldErr)})
+	}
+	return errs
+}
+
+// validationMessage words a failed rule for the person fixing the file
+func validationMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "required_without_all":
+		return "is required when the other identifiers are empty"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "numeric":
+		return "must only hold digits"
+	case "len":
+		return "must be " + fieldErr.Param() + " characters long"
+	case "gt":
+		return "must be more than " + fieldErr.Param()
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at least " + fieldErr.Param() + " characters long"
+		}
+		return "must be at least " + fieldErr.Param()
+	}
+	return "fails " + fieldErr.Tag()
+}
+
+// checkKeys refuses a row that repeats the key of an earlier one, as both
+// would be imported into the same record
+func checkKeys(row reflect.Value, columns []column, seen map[string]int, line int) []RowError {
+	var errs []RowError
+	for _, column := range columns {
+		if !column.key {
+			continue
+		}
+
+		value := row.Field(column.index).String()
+		if value == "" {
+			continue
+		}
+
+		key := column.name + "=" + value
+		if first, ok := seen[key]; ok {
+			errs = append(errs, RowError{Line: line, Column: column.name, Message: fmt.Sprintf("repeats line %d", first)})
+			continue
+		}
+		seen[key] = line
+	}
+	return errs
+}
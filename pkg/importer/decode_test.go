@@ -0,0 +1,253 @@
+package importer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func int32Pointer(n int32) *int32 {
+	return &n
+}
+
+func TestDecodeProducts(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		rows  []ProductRow
+		total int
+		errs  []RowError
+		err   error
+	}{
+		{
+			name: "comma separated",
+			file: "code,name,net_weight_grams,is_subsidized,price,deposit_amount\n" +
+				"LPG3,Elpiji 3 kg,3000,yes,20000,150000\n" +
+				"LPG12,Elpiji 12 kg,12000,no,210000,500000\n",
+			rows: []ProductRow{
+				{Line: 2, Code: "LPG3", Name: "Elpiji 3 kg", NetWeightGrams: 3000, IsSubsidized: true, Price: 20000, DepositAmount: 150000},
+				{Line: 3, Code: "LPG12", Name: "Elpiji 12 kg", NetWeightGrams: 12000, Price: 210000, DepositAmount: 500000},
+			},
+			total: 2,
+		},
+		{
+			name: "semicolon separated with grouped numbers",
+			file: "code;name;net_weight_grams;price\n" +
+				"LPG3;Elpiji 3 kg;3.000;20.000\n" +
+				"LPG12;Elpiji, 12 kg;12,000;1.210.000\n",
+			rows: []ProductRow{
+				{Line: 2, Code: "LPG3", Name: "Elpiji 3 kg", NetWeightGrams: 3000, Price: 20000},
+				{Line: 3, Code: "LPG12", Name: "Elpiji, 12 kg", NetWeightGrams: 12000, Price: 1210000},
+			},
+			total: 2,
+		},
+		{
+			name:  "byte order mark and untidy header",
+			file:  "\ufeffNet_Weight_Grams, CODE ,Name\r\n3000,LPG3,Elpiji 3 kg\r\n",
+			rows:  []ProductRow{{Line: 2, Code: "LPG3", Name: "Elpiji 3 kg", NetWeightGrams: 3000}},
+			total: 1,
+		},
+		{
+			name: "columns in any order and quoted cells",
+			file: "name,price,code,net_weight_grams\n" +
+				"\"Elpiji \"\"Bright\"\" 5,5 kg\",\"90,000\",BG55,5500\n",
+			rows:  []ProductRow{{Line: 2, Code: "BG55", Name: `Elpiji "Bright" 5,5 kg`, NetWeightGrams: 5500, Price: 90000}},
+			total: 1,
+		},
+		{
+			name: "a quoted cell across lines keeps the line it starts on",
+			file: "code,name,net_weight_grams\n" +
+				"LPG3,\"Elpiji\n3 kg\",3000\n" +
+				"LPG12,Elpiji 12 kg,12000\n",
+			rows: []ProductRow{
+				{Line: 2, Code: "LPG3", Name: "Elpiji\n3 kg", NetWeightGrams: 3000},
+				{Line: 4, Code: "LPG12", Name: "Elpiji 12 kg", NetWeightGrams: 12000},
+			},
+			total: 2,
+		},
+		{
+			name:  "header only",
+			file:  "code,name,net_weight_grams\n",
+			total: 0,
+		},
+		{
+			name: "empty file",
+			file: "",
+			err:  ErrNoHeader,
+		},
+		{
+			name: "repeated column",
+			file: "code,name,net_weight_grams,Code\nLPG3,Elpiji 3 kg,3000,LPG3\n",
+			err:  ErrRepeatedColumn,
+		},
+		{
+			name: "unknown column",
+			file: "code,name,net_weight_gram\nLPG3,Elpiji 3 kg,3000\n",
+			err:  ErrUnknownColumn,
+		},
+		{
+			name: "missing required column",
+			file: "code,name,price\nLPG3,Elpiji 3 kg,20000\n",
+			err:  ErrMissingColumn,
+		},
+		{
+			name: "a line with the wrong number of cells",
+			file: "code,name,net_weight_grams\n" +
+				"LPG3,Elpiji 3 kg\n" +
+				"LPG5,Elpiji 5 kg,5000,extra\n" +
+				"LPG12,Elpiji 12 kg,12000\n",
+			rows:  []ProductRow{{Line: 4, Code: "LPG12", Name: "Elpiji 12 kg", NetWeightGrams: 12000}},
+			total: 3,
+			errs: []RowError{
+				{Line: 2, Message: "has 2 columns instead of 3"},
+				{Line: 3, Message: "has 4 columns instead of 3"},
+			},
+		},
+		{
+			name: "cells that cannot be converted are not validated as well",
+			file: "code,name,net_weight_grams,is_subsidized,price\n" +
+				"LPG3,Elpiji 3 kg,3 kg,maybe,-20000\n",
+			total: 1,
+			errs: []RowError{
+				{Line: 2, Column: "net_weight_grams", Message: "must be a whole number"},
+				{Line: 2, Column: "is_subsidized", Message: "must be yes or no"},
+				{Line: 2, Column: "price", Message: "must be at least 0"},
+			},
+		},
+		{
+			name:  "empty required cells",
+			file:  "code,name,net_weight_grams\n,,0\n",
+			total: 1,
+			errs: []RowError{
+				{Line: 2, Column: "code", Message: "is required"},
+				{Line: 2, Column: "name", Message: "is required"},
+				{Line: 2, Column: "net_weight_grams", Message: "is required"},
+			},
+		},
+		{
+			name: "repeated key",
+			file: "code,name,net_weight_grams\n" +
+				"LPG3,Elpiji 3 kg,3000\n" +
+				"LPG12,Elpiji 12 kg,12000\n" +
+				"LPG3,Elpiji 3 kg lagi,3000\n",
+			rows: []ProductRow{
+				{Line: 2, Code: "LPG3", Name: "Elpiji 3 kg", NetWeightGrams: 3000},
+				{Line: 3, Code: "LPG12", Name: "Elpiji 12 kg", NetWeightGrams: 12000},
+			},
+			total: 3,
+			errs:  []RowError{{Line: 4, Column: "code", Message: "repeats line 2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []ProductRow
+			total, errs, err := Decode(strings.NewReader(tt.file), &rows)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.err)
+			}
+			if total != tt.total {
+				t.Errorf("total = %d, want %d", total, tt.total)
+			}
+			if !reflect.DeepEqual(errs, tt.errs) {
+				t.Errorf("row errors = %v, want %v", errs, tt.errs)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("rows = %+v, want %+v", rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestDecodeCustomers(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		rows []CustomerRow
+		errs []RowError
+	}{
+		{
+			name: "optional cells",
+			file: "name;customer_type;phone;payment_term_days\n" +
+				"Warung Bu Sri;micro_business;0812-3456-7890;14\n" +
+				"Pak Budi;household;+62 811 1111 111;\n",
+			rows: []CustomerRow{
+				{Line: 2, Name: "Warung Bu Sri", CustomerType: "micro_business", Phone: "6281234567890", PaymentTermDays: int32Pointer(14)},
+				{Line: 3, Name: "Pak Budi", CustomerType: "household", Phone: "628111111111"},
+			},
+		},
+		{
+			name: "a phone number written two ways is repeated",
+			file: "name,customer_type,phone\n" +
+				"Warung Bu Sri,micro_business,0812-3456-7890\n" +
+				"Bu Sri,household,+62 812 3456 7890\n",
+			rows: []CustomerRow{{Line: 2, Name: "Warung Bu Sri", CustomerType: "micro_business", Phone: "6281234567890"}},
+			errs: []RowError{{Line: 3, Column: "phone", Message: "repeats line 2"}},
+		},
+		{
+			name: "an identifier is needed",
+			file: "name,customer_type,phone,national_id\nPak Budi,household,,\n",
+			errs: []RowError{{Line: 2, Column: "phone", Message: "is required when the other identifiers are empty"}},
+		},
+		{
+			name: "invalid cells",
+			file: "name,customer_type,national_id,business_id\n" +
+				"Pak Budi,shop,32010101010100,12345678901ab\n",
+			errs: []RowError{
+				{Line: 2, Column: "customer_type", Message: "must be one of household, micro_business, restaurant, sub_agent"},
+				{Line: 2, Column: "national_id", Message: "must be 16 characters long"},
+				{Line: 2, Column: "business_id", Message: "must only hold digits"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []CustomerRow
+			_, errs, err := Decode(strings.NewReader(tt.file), &rows)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(errs, tt.errs) {
+				t.Errorf("row errors = %v, want %v", errs, tt.errs)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("rows = %+v, want %+v", rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestDecodeRefusesOtherLists(t *testing.T) {
+	file := "code,name,net_weight_grams\nLPG3,Elpiji 3 kg,3000\n"
+
+	tests := []struct {
+		name string
+		rows any
+	}{
+		{"not a pointer", []ProductRow{}},
+		{"not a slice", &ProductRow{}},
+		{"not a slice of structs", &[]string{}},
+		{"a row without a line", &[]struct {
+			Code string `csv:"code"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Decode(strings.NewReader(file), tt.rows)
+			if !errors.Is(err, ErrNotARowList) {
+				t.Errorf("Decode() error = %v, want %v", err, ErrNotARowList)
+			}
+		})
+	}
+
+	t.Run("a stray quote", func(t *testing.T) {
+		var rows []ProductRow
+		_, _, err := Decode(strings.NewReader(file+"LPG5,Elpiji \"5 kg,5000\n"), &rows)
+		if err == nil {
+			t.Error("Decode() read a file with a stray quote")
+		}
+	})
+}
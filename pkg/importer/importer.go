@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"fmt"
+
+	"github.com/blanc08/stok-gas-management-backend/pkg/util"
+)
+
+// Kinds of file that can be imported
+const (
+	KindProducts     = "products"
+	KindCustomers    = "customers"
+	KindOpeningStock = "opening_stock"
+)
+
+// RowError is a problem with one line of an imported file. Column is empty
+// when the line as a whole is at fault.
+type RowError struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d : %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d, %s : %s", e.Line, e.Column, e.Message)
+}
+
+// Each row type has the line it was read from and a field per column, named
+// by its csv tag. A column tagged key identifies the record it is imported
+// into, so it cannot repeat within a file.
+type (
+	ProductRow struct {
+		Line           int    `csv:"-"`
+		Code           string `csv:"code,key" validate:"required"`
+		Name           string `csv:"name" validate:"required"`
+		NetWeightGrams int32  `csv:"net_weight_grams" validate:"required,gt=0"`
+		IsSubsidized   bool   `csv:"is_subsidized"`
+		Price          int64  `csv:"price" validate:"min=0"`
+		DepositAmount  int64  `csv:"deposit_amount" validate:"min=0"`
+	}
+
+	// CustomerRow is matched to a registered customer by its phone number,
+	// NIK or NIB, so at least one of them is needed
+	CustomerRow struct {
+		Line         int    `csv:"-"`
+		Name         string `csv:"name" validate:"required"`
+		CustomerType string `csv:"customer_type" validate:"required,oneof=household micro_business restaurant sub_agent"`
+		Phone        string `csv:"phone,key" validate:"required_without_all=NationalID BusinessID,omitempty,min=8"`
+		NationalID   string `csv:"national_id,key" validate:"omitempty,numeric,len=16"`
+		BusinessID   string `csv:"business_id,key" validate:"omitempty,numeric,len=13"`
+		Address      string `csv:"address"`
+		CreditLimit  int64  `csv:"credit_limit" validate:"min=0"`
+		// PaymentTermDays is left as it is, or 30 days for a new customer,
+		// when the cell is empty
+		PaymentTermDays *int32 `csv:"payment_term_days" validate:"omitempty,min=0"`
+	}
+
+	// OpeningStockRow is the stock a location holds of a product
+	OpeningStockRow struct {
+		Line        int    `csv:"-"`
+		ProductCode string `csv:"product_code,key" validate:"required"`
+		FullQty     int32  `csv:"full_qty" validate:"min=0"`
+		EmptyQty    int32  `csv:"empty_qty" validate:"min=0"`
+	}
+)
+
+// normalize brings the phone number to the form customers are registered
+// under, so the same number written two ways is seen as one
+func (row *CustomerRow) normalize() {
+	row.Phone = util.NormalizePhone(row.Phone)
+}
@@ -0,0 +1,162 @@
+package routing
+
+import (
+	"math"
+	"sort"
+)
+
+// earthRadiusKm is the mean radius used for great-circle distances
+const earthRadiusKm = 6371.0
+
+// Point is a position in decimal degrees
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Distance is the great-circle distance between two points in kilometers,
+// there is no road network so this stands in for the driving distance
+func Distance(a, b Point) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Load is a quantity of cylinders per product
+type Load map[int32]int32
+
+// fits tells whether load can be added to used without going over capacity
+func (capacity Load) fits(used Load, load Load) bool {
+	for productID, quantity := range load {
+		if used[productID]+quantity > capacity[productID] {
+			return false
+		}
+	}
+	return true
+}
+
+func (used Load) add(load Load) {
+	for productID, quantity := range load {
+		used[productID] += quantity
+	}
+}
+
+type Stop struct {
+	ID    int64 `json:"id"`
+	Point Point `json:"point"`
+	Load  Load  `json:"load"`
+}
+
+type Vehicle struct {
+	ID       int32 `json:"id"`
+	Capacity Load  `json:"capacity"`
+}
+
+// Route is the stops a vehicle visits in order, starting from and returning
+// to the depot
+type Route struct {
+	VehicleID  int32   `json:"vehicle_id"`
+	Stops      []Stop  `json:"stops"`
+	Load       Load    `json:"load"`
+	DistanceKm float64 `json:"distance_km"`
+}
+
+type Plan struct {
+	Routes []Route `json:"routes"`
+	// Unassigned stops did not fit on any vehicle
+	Unassigned []Stop  `json:"unassigned"`
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// Solve fills the vehicles one after the other, each time driving to the
+// nearest stop that still fits, then shortens every route with 2-opt. It is a
+// heuristic: the plan is good, not necessarily the shortest possible. There
+// is a route per vehicle, possibly empty, in the order the vehicles are given;
+// a vehicle making several trips is given once per trip.
+func Solve(depot Point, vehicles []Vehicle, stops []Stop) Plan {
+	remaining := make([]Stop, len(stops))
+	copy(remaining, stops)
+	// ties are broken by stop ID so the same input always gives the same plan
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i].ID < remaining[j].ID })
+
+	plan := Plan{Routes: []Route{}, Unassigned: []Stop{}}
+	for _, vehicle := range vehicles {
+		route := Route{VehicleID: vehicle.ID, Stops: []Stop{}, Load: Load{}}
+
+		position := depot
+		for {
+			next := -1
+			for i, stop := range remaining {
+				if !vehicle.Capacity.fits(route.Load, stop.Load) {
+					continue
+				}
+				if next < 0 || Distance(position, stop.Point) < Distance(position, remaining[next].Point) {
+					next = i
+				}
+			}
+			if next < 0 {
+				break
+			}
+
+			stop := remaining[next]
+			remaining = append(remaining[:next], remaining[next+1:]...)
+			route.Stops = append(route.Stops, stop)
+			route.Load.add(stop.Load)
+			position = stop.Point
+		}
+
+		twoOpt(depot, route.Stops)
+		route.DistanceKm = TourDistance(depot, route.Stops)
+		plan.DistanceKm += route.DistanceKm
+		plan.Routes = append(plan.Routes, route)
+	}
+
+	plan.Unassigned = append(plan.Unassigned, remaining...)
+	return plan
+}
+
+// TourDistance is the length of the tour from the depot through the stops
+// and back
+func TourDistance(depot Point, stops []Stop) float64 {
+	var total float64
+	position := depot
+	for _, stop := range stops {
+		total += Distance(position, stop.Point)
+		position = stop.Point
+	}
+	return total + Distance(position, depot)
+}
+
+// twoOpt reverses segments of the route in place for as long as doing so
+// makes the tour shorter
+func twoOpt(depot Point, stops []Stop) {
+	// point returns the i-th point of the tour, the depot is at both ends
+	point := func(i int) Point {
+		if i < 0 || i >= len(stops) {
+			return depot
+		}
+		return stops[i].Point
+	}
+
+	const epsilon = 1e-9
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(stops)-1; i++ {
+			for j := i + 1; j < len(stops); j++ {
+				before := Distance(point(i-1), point(i)) + Distance(point(j), point(j+1))
+				after := Distance(point(i-1), point(j)) + Distance(point(i), point(j+1))
+				if after < before-epsilon {
+					for l, r := i, j; l < r; l, r = l+1, r-1 {
+						stops[l], stops[r] = stops[r], stops[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package routing
+
+import (
+	"math"
+	"testing"
+)
+
+// crossing is a small instance on which the nearest neighbour tour from the
+// depot crosses itself, so 2-opt has to shorten it
+var (
+	crossingDepot = Point{}
+	crossingStops = []Stop{
+		{ID: 1, Point: Point{0, 0.01}, Load: Load{1: 1}},
+		{ID: 2, Point: Point{0.04, 0.04}, Load: Load{1: 1}},
+		{ID: 3, Point: Point{0, 0.03}, Load: Load{1: 1}},
+		{ID: 4, Point: Point{0.01, 0}, Load: Load{1: 1}},
+		{ID: 5, Point: Point{0.02, 0.03}, Load: Load{1: 1}},
+	}
+	// crossingNearest is the order the nearest neighbour visits the stops in
+	crossingNearest = []int64{1, 4, 5, 3, 2}
+)
+
+func stopsByID(stops []Stop, ids []int64) []Stop {
+	byID := make(map[int64]Stop, len(stops))
+	for _, stop := range stops {
+		byID[stop.ID] = stop
+	}
+
+	ordered := make([]Stop, len(ids))
+	for i, id := range ids {
+		ordered[i] = byID[id]
+	}
+	return ordered
+}
+
+func stopIDs(stops []Stop) []int64 {
+	ids := make([]int64, len(stops))
+	for i, stop := range stops {
+		ids[i] = stop.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// shortestTour tries every order of the stops
+func shortestTour(depot Point, stops []Stop) float64 {
+	best := math.Inf(1)
+	tour := make([]Stop, len(stops))
+	copy(tour, stops)
+
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(tour) {
+			best = math.Min(best, TourDistance(depot, tour))
+			return
+		}
+		for i := k; i < len(tour); i++ {
+			tour[k], tour[i] = tour[i], tour[k]
+			permute(k + 1)
+			tour[k], tour[i] = tour[i], tour[k]
+		}
+	}
+	permute(0)
+
+	return best
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{"same point", Point{-6.2, 106.8}, Point{-6.2, 106.8}, 0},
+		{"one degree of latitude", Point{0, 0}, Point{1, 0}, 111.195},
+		{"one degree of longitude on the equator", Point{0, 0}, Point{0, 1}, 111.195},
+		{"half way around the earth", Point{0, 0}, Point{0, 180}, math.Pi * earthRadiusKm},
+		{"Jakarta to Bandung", Point{-6.2, 106.8167}, Point{-6.9147, 107.6098}, 118.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); math.Abs(got-tt.want) > 0.1 {
+				t.Errorf("Distance() = %.3f, want %.3f", got, tt.want)
+			}
+			if got, back := Distance(tt.a, tt.b), Distance(tt.b, tt.a); math.Abs(got-back) > 1e-9 {
+				t.Errorf("Distance() is not symmetric: %.6f and %.6f", got, back)
+			}
+		})
+	}
+}
+
+func TestTwoOpt(t *testing.T) {
+	tests := []struct {
+		name  string
+		stops []Stop
+	}{
+		{"no stops", nil},
+		{"one stop", crossingStops[:1]},
+		{"two stops", crossingStops[:2]},
+		{"crossing tour", stopsByID(crossingStops, crossingNearest)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tour := make([]Stop, len(tt.stops))
+			copy(tour, tt.stops)
+
+			twoOpt(crossingDepot, tour)
+
+			before := TourDistance(crossingDepot, tt.stops)
+			after := TourDistance(crossingDepot, tour)
+			if after > before+1e-9 {
+				t.Errorf("tour grew from %.3f to %.3f km", before, after)
+			}
+			if want := shortestTour(crossingDepot, tt.stops); math.Abs(after-want) > 1e-9 {
+				t.Errorf("tour is %.3f km, the shortest is %.3f km", after, want)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		vehicles   []Vehicle
+		stops      []Stop
+		routes     [][]int64
+		unassigned []int64
+	}{
+		{
+			name:     "2-opt beats nearest neighbour",
+			vehicles: []Vehicle{{ID: 1, Capacity: Load{1: 10}}},
+			stops:    crossingStops,
+			routes:   [][]int64{{4, 2, 5, 3, 1}},
+		},
+		{
+			name:     "stops over capacity go on the next vehicle",
+			vehicles: []Vehicle{{ID: 1, Capacity: Load{1: 2}}, {ID: 2, Capacity: Load{1: 3}}},
+			stops:    crossingStops,
+			routes:   [][]int64{{4, 1}, {3, 5, 2}},
+		},
+		{
+			name:     "a vehicle making two trips",
+			vehicles: []Vehicle{{ID: 1, Capacity: Load{1: 2}}, {ID: 1, Capacity: Load{1: 3}}},
+			stops:    crossingStops,
+			routes:   [][]int64{{4, 1}, {3, 5, 2}},
+		},
+		{
+			name:     "a vehicle left with nothing to carry still gets a route",
+			vehicles: []Vehicle{{ID: 1, Capacity: Load{1: 10}}, {ID: 2, Capacity: Load{1: 10}}},
+			stops:    crossingStops,
+			routes:   [][]int64{{4, 2, 5, 3, 1}, {}},
+		},
+		{
+			name:     "stops fitting on no vehicle are unassigned",
+			vehicles: []Vehicle{{ID: 1, Capacity: Load{1: 1, 2: 1}}},
+			stops: []Stop{
+				{ID: 1, Point: Point{0, 0.01}, Load: Load{1: 1}},
+				{ID: 2, Point: Point{0, 0.02}, Load: Load{1: 1}},
+				{ID: 3, Point: Point{0, 0.03}, Load: Load{3: 1}},
+			},
+			routes:     [][]int64{{1}},
+			unassigned: []int64{2, 3},
+		},
+		{
+			name:       "no vehicles",
+			stops:      crossingStops[:2],
+			routes:     [][]int64{},
+			unassigned: []int64{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Solve(crossingDepot, tt.vehicles, tt.stops)
+
+			if len(plan.Routes) != len(tt.routes) {
+				t.Fatalf("got %d routes, want %d", len(plan.Routes), len(tt.routes))
+			}
+
+			var total float64
+			for i, route := range plan.Routes {
+				// a tour may be driven either way around
+				got := stopIDs(route.Stops)
+				reversed := make([]int64, len(got))
+				for j, id := range got {
+					reversed[len(got)-1-j] = id
+				}
+				if !equalIDs(got, tt.routes[i]) && !equalIDs(reversed, tt.routes[i]) {
+					t.Errorf("route %d visits %v, want %v", i, got, tt.routes[i])
+				}
+
+				if want := TourDistance(crossingDepot, route.Stops); math.Abs(route.DistanceKm-want) > 1e-9 {
+					t.Errorf("route %d is %.3f km, its stops are %.3f km", i, route.DistanceKm, want)
+				}
+				total += route.DistanceKm
+			}
+
+			if math.Abs(plan.DistanceKm-total) > 1e-9 {
+				t.Errorf("plan is %.3f km, its routes are %.3f km", plan.DistanceKm, total)
+			}
+			if got := stopIDs(plan.Unassigned); !equalIDs(got, tt.unassigned) {
+				t.Errorf("unassigned %v, want %v", got, tt.unassigned)
+			}
+		})
+	}
+
+	t.Run("shorter than nearest neighbour", func(t *testing.T) {
+		plan := Solve(crossingDepot, []Vehicle{{ID: 1, Capacity: Load{1: 10}}}, crossingStops)
+
+		nearest := TourDistance(crossingDepot, stopsByID(crossingStops, crossingNearest))
+		shortest := shortestTour(crossingDepot, crossingStops)
+		if plan.DistanceKm >= nearest {
+			t.Errorf("plan is %.3f km, nearest neighbour is %.3f km", plan.DistanceKm, nearest)
+		}
+		if math.Abs(plan.DistanceKm-shortest) > 1e-9 {
+			t.Errorf("plan is %.3f km, the shortest tour is %.3f km", plan.DistanceKm, shortest)
+		}
+	})
+}
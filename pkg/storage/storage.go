@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrNotFound   = errors.New("file not found")
+	ErrInvalidKey = errors.New("invalid file key")
+)
+
+// Storage keeps uploaded files as blobs under a slash separated key
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	Delete(ctx context.Context, key string) error
+}
@@ -17,6 +17,21 @@ type Config struct {
 	V4SymmetricSecretKeyHex string        `mapstructure:"V4_SYMMETRIC_SECRET_KEY_HEX"`
 	AccessTokenDuration     time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration    time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	SalesTaxBasisPoints     int64         `mapstructure:"SALES_TAX_BASIS_POINTS"`
+	InspectionDueWindowDays int           `mapstructure:"INSPECTION_DUE_WINDOW_DAYS"`
+	AlertEvaluationInterval time.Duration `mapstructure:"ALERT_EVALUATION_INTERVAL"`
+	ReservationTTL          time.Duration `mapstructure:"RESERVATION_TTL"`
+	StorageDir              string        `mapstructure:"STORAGE_DIR"`
+	UploadMaxBytes          int64         `mapstructure:"UPLOAD_MAX_BYTES"`
+	ForecastAlgorithm       string        `mapstructure:"FORECAST_ALGORITHM"`
+	ForecastHistoryDays     int           `mapstructure:"FORECAST_HISTORY_DAYS"`
+	ReorderCoverDays        int           `mapstructure:"REORDER_COVER_DAYS"`
+	ReportRefreshInterval   time.Duration `mapstructure:"REPORT_REFRESH_INTERVAL"`
+	ExportTimeZone          string        `mapstructure:"EXPORT_TIME_ZONE"`
+	ImportPollInterval      time.Duration `mapstructure:"IMPORT_POLL_INTERVAL"`
+	DocumentLayoutFile      string        `mapstructure:"DOCUMENT_LAYOUT_FILE"`
+	DocumentBaseURL         string        `mapstructure:"DOCUMENT_BASE_URL"`
+	AdminEmail              string        `mapstructure:"ADMIN_EMAIL"`
 }
 
 // LoadConfig read configuration from file or environment variables
@@ -25,6 +40,17 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigName("local")
 	viper.SetConfigType("env")
 
+	viper.SetDefault("INSPECTION_DUE_WINDOW_DAYS", 30)
+	viper.SetDefault("ALERT_EVALUATION_INTERVAL", "15m")
+	viper.SetDefault("RESERVATION_TTL", "24h")
+	viper.SetDefault("STORAGE_DIR", "uploads")
+	viper.SetDefault("UPLOAD_MAX_BYTES", 5<<20)
+	viper.SetDefault("FORECAST_ALGORITHM", "holt_winters")
+	viper.SetDefault("FORECAST_HISTORY_DAYS", 182)
+	viper.SetDefault("REORDER_COVER_DAYS", 14)
+	viper.SetDefault("REPORT_REFRESH_INTERVAL", "15m")
+	viper.SetDefault("EXPORT_TIME_ZONE", "Asia/Jakarta")
+	viper.SetDefault("IMPORT_POLL_INTERVAL", "10s")
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
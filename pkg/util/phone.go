@@ -0,0 +1,21 @@
+package util
+
+import "strings"
+
+// NormalizePhone reduces an Indonesian phone number to its digits in the
+// international form, so "0812-3456 789" and "+62 812 3456 789" compare equal
+func NormalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	normalized := digits.String()
+	if strings.HasPrefix(normalized, "0") {
+		normalized = "62" + normalized[1:]
+	}
+
+	return normalized
+}
@@ -0,0 +1,28 @@
+package util
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name  string
+		phone string
+		want  string
+	}{
+		{"local form", "081234567890", "6281234567890"},
+		{"local form with dashes and spaces", "0812-3456 7890", "6281234567890"},
+		{"international form", "+62 812 3456 7890", "6281234567890"},
+		{"international form without a plus", "6281234567890", "6281234567890"},
+		{"landline in brackets", "(021) 555-1234", "62215551234"},
+		{"only the leading zero is replaced", "0800", "62800"},
+		{"no digits", "n/a", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizePhone(tt.phone); got != tt.want {
+				t.Errorf("NormalizePhone(%q) = %q, want %q", tt.phone, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package worker
+
+import (
+	"context"
+	"log"
+)
+
+// evaluateStockAlerts raises and resolves the stock alerts of every threshold
+func (worker *Worker) evaluateStockAlerts(ctx context.Context) error {
+	result, err := worker.store.EvaluateStockAlerts(ctx, worker.pool)
+	if err != nil {
+		return err
+	}
+
+	if result.Raised > 0 || result.Resolved > 0 {
+		log.Printf("worker : %d stock alerts raised, %d resolved", result.Raised, result.Resolved)
+	}
+
+	return nil
+}
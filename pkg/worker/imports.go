@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/importer"
+	"github.com/jackc/pgx/v5"
+)
+
+// runImports works through the pending import jobs, oldest first, until
+// there are none left
+func (worker *Worker) runImports(ctx context.Context) error {
+	for {
+		job, err := worker.store.ClaimImportJob(ctx, worker.pool)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		total, result, err := worker.runImport(ctx, job)
+		job, finishErr := worker.store.FinishImportJobTx(ctx, worker.pool, database.FinishImportJobTxParams{
+			ImportJob: job,
+			Total:     total,
+			Result:    result,
+			Err:       err,
+		})
+		if finishErr != nil {
+			return finishErr
+		}
+
+		log.Printf("worker : import %d of %s %s, %d created, %d updated, %d rows with errors",
+			job.ID, job.Kind, job.Status, job.CreatedRows, job.UpdatedRows, job.ErrorRows)
+	}
+}
+
+// runImport reads the file of a job and imports its rows. The rows that fail
+// to decode are reported along with those the database refuses, so every
+// error of the file is found at once, but the import is then only a dry run.
+func (worker *Worker) runImport(ctx context.Context, job database.ImportJob) (total int, result database.ImportResult, err error) {
+	file, err := worker.storage.Get(ctx, job.FileKey)
+	if err != nil {
+		return 0, result, err
+	}
+	defer file.Close()
+
+	var decodeErrs []importer.RowError
+	switch job.Kind {
+	case importer.KindProducts:
+		var rows []importer.ProductRow
+		if total, decodeErrs, err = importer.Decode(file, &rows); err != nil {
+			return total, result, err
+		}
+
+		result, err = worker.store.ImportProductsTx(ctx, worker.pool, database.ImportProductsTxParams{
+			Rows:   rows,
+			DryRun: job.DryRun || len(decodeErrs) > 0,
+		})
+	case importer.KindCustomers:
+		var rows []importer.CustomerRow
+		if total, decodeErrs, err = importer.Decode(file, &rows); err != nil {
+			return total, result, err
+		}
+
+		result, err = worker.store.ImportCustomersTx(ctx, worker.pool, database.ImportCustomersTxParams{
+			Rows:   rows,
+			DryRun: job.DryRun || len(decodeErrs) > 0,
+		})
+	case importer.KindOpeningStock:
+		var rows []importer.OpeningStockRow
+		if total, decodeErrs, err = importer.Decode(file, &rows); err != nil {
+			return total, result, err
+		}
+
+		result, err = worker.store.ImportOpeningStockTx(ctx, worker.pool, database.ImportOpeningStockTxParams{
+			ImportJobID: job.ID,
+			LocationID:  job.LocationID.Int32,
+			Rows:        rows,
+			DryRun:      job.DryRun || len(decodeErrs) > 0,
+			CreatedBy:   job.CreatedBy,
+		})
+	default:
+		return 0, result, fmt.Errorf("%w : %s", database.ErrImportKind, job.Kind)
+	}
+	if err != nil {
+		return total, result, err
+	}
+
+	result.Errors = append(decodeErrs, result.Errors...)
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Line < result.Errors[j].Line
+	})
+
+	return total, result, nil
+}
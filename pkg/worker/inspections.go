@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"log"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// flagCylindersDueForTest marks the cylinders coming due for their re-test
+// within the configured window, each cylinder is only flagged once per test
+func (worker *Worker) flagCylindersDueForTest(ctx context.Context) error {
+	today := database.Today()
+	dueBefore := pgtype.Date{Time: today.Time.AddDate(0, 0, worker.config.InspectionDueWindowDays), Valid: true}
+
+	cylinders, err := worker.store.FlagCylindersDueForTest(ctx, worker.pool, dueBefore)
+	if err != nil {
+		return err
+	}
+
+	for _, cylinder := range cylinders {
+		log.Printf("worker : cylinder %s is due for re-test on %s",
+			cylinder.SerialNumber, cylinder.NextTestDue.Time.Format("2006-01-02"))
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package worker
+
+import "context"
+
+// refreshReportViews brings the daily aggregates behind the reports up to date
+func (worker *Worker) refreshReportViews(ctx context.Context) error {
+	return worker.store.RefreshReportViews(ctx, worker.pool)
+}
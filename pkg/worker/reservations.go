@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"context"
+	"log"
+)
+
+// expireReservations gives back the stock of reservations past their expiry
+func (worker *Worker) expireReservations(ctx context.Context) error {
+	result, err := worker.store.ExpireReservationsTx(ctx, worker.pool)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range result.Reservations {
+		log.Printf("worker : reservation %d of %s %d expired",
+			reservation.ID, reservation.ReferenceType, reservation.ReferenceID)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	database "github.com/blanc08/stok-gas-management-backend/pkg/database/sqlc"
+	"github.com/blanc08/stok-gas-management-backend/pkg/storage"
+	"github.com/blanc08/stok-gas-management-backend/pkg/util"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Worker runs the background jobs until its context is cancelled
+type Worker struct {
+	config  util.Config
+	store   database.Store
+	pool    *pgxpool.Pool
+	storage storage.Storage
+}
+
+// job is run once when the worker starts and then on every tick
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func NewWorker(config util.Config, store database.Store, pool *pgxpool.Pool, fileStorage storage.Storage) *Worker {
+	return &Worker{
+		config:  config,
+		store:   store,
+		pool:    pool,
+		storage: fileStorage,
+	}
+}
+
+// Start schedules every job in its own goroutine and returns
+func (worker *Worker) Start(ctx context.Context) {
+	jobs := []job{
+		{name: "flag cylinders due for test", interval: 24 * time.Hour, run: worker.flagCylindersDueForTest},
+		{name: "evaluate stock alerts", interval: worker.config.AlertEvaluationInterval, run: worker.evaluateStockAlerts},
+		{name: "expire stock reservations", interval: time.Minute, run: worker.expireReservations},
+		{name: "refresh report views", interval: worker.config.ReportRefreshInterval, run: worker.refreshReportViews},
+		{name: "run imports", interval: worker.config.ImportPollInterval, run: worker.runImports},
+	}
+
+	for _, j := range jobs {
+		go worker.schedule(ctx, j)
+	}
+}
+
+func (worker *Worker) schedule(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.run(ctx); err != nil {
+			log.Printf("worker : %s failed : %v", j.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}